@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/DucTran999/dbkit"
+	"github.com/DucTran999/go-clean-archx/internal/cli"
+	"github.com/DucTran999/go-clean-archx/internal/config"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+)
+
+// command is a subcommand of the binary, run instead of the server.
+type command struct {
+	usage string
+	run   func(ctx context.Context, cfg *config.Config, args []string, out io.Writer, appLogger port.Logger) error
+}
+
+// commands lists the subcommands by name.
+var commands = map[string]command{
+	"migrate":  {usage: migrateUsage, run: runMigrate},
+	"schema":   {usage: schemaUsage, run: runSchema},
+	"products": {usage: cli.ProductUsage, run: runProducts},
+}
+
+// errUsage reports a malformed command line; runCommand prints the usage for it.
+// It is shared with package cli so its commands report usage errors the same way.
+var errUsage = cli.ErrUsage
+
+// runCommand runs cmd until it returns or a signal cancels it. It exits with status 2
+// for a malformed command line and 1 when the command fails.
+func runCommand(cmd command, cfg *config.Config, args []string, appLogger port.Logger) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := cmd.run(ctx, cfg, args, os.Stdout, appLogger)
+	stop()
+
+	if errors.Is(err, errUsage) {
+		fmt.Fprintf(os.Stderr, "%v\n\n%s\n", err, cmd.usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		fatal("command failed", err)
+	}
+}
+
+// openPostgres connects to the configured database for the commands that manage it.
+func openPostgres(cfg *config.Config) (dbkit.Connection, error) {
+	if cfg.Storage != "postgres" {
+		return nil, fmt.Errorf("this command needs STORAGE=postgres, got %q", cfg.Storage)
+	}
+
+	conn, err := setupDB(cfg.DB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up database: %w", err)
+	}
+
+	return conn, nil
+}
@@ -2,71 +2,383 @@
 package main
 
 import (
-	"log"
+	"context"
+	"flag"
+	"log/slog"
 	"net"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/DucTran999/dbkit"
-	"github.com/DucTran999/dbkit/config"
+	dbconfig "github.com/DucTran999/dbkit/config"
+	"github.com/DucTran999/go-clean-archx/internal/config"
 	"github.com/DucTran999/go-clean-archx/internal/controller"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/graphqlapi"
+	"github.com/DucTran999/go-clean-archx/internal/grpcserver"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/middleware"
+	"github.com/DucTran999/go-clean-archx/internal/outbox"
+	"github.com/DucTran999/go-clean-archx/internal/port"
 	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/internal/server"
 	"github.com/DucTran999/go-clean-archx/internal/usecase"
+	"github.com/DucTran999/go-clean-archx/internal/webhook"
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
 )
 
-func init() {
-	err := godotenv.Load()
+func main() {
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to an optional YAML config file")
+	storageMode := flag.String("storage", "", "where data is kept: postgres, or memory to run without a database (overrides STORAGE)")
+	flag.Parse()
+
+	// Load config: defaults → YAML file → .env → environment
+	cfg, err := config.Load(config.Sources{
+		YAMLFile: *configFile,
+		EnvFile:  ".env",
+		Storage:  *storageMode,
+	})
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		fatal("failed to load config", err)
 	}
-}
 
-func main() {
-	// Setup DB
-	conn, err := setupDB()
+	// Setup logging: JSON in deployed environments, text locally.
+	// slog.Default is replaced too, so libraries logging through it share the same output.
+	// Subcommands log to stderr, keeping stdout for their results.
+	logOut := os.Stdout
+	if _, ok := commands[flag.Arg(0)]; ok {
+		logOut = os.Stderr
+	}
+	slogger := logger.NewSlog(logOut, logger.Options{
+		Format:  logger.FormatForEnv(cfg.Service.Env),
+		Level:   cfg.Service.Level(),
+		Service: cfg.Service.Name,
+	})
+	slog.SetDefault(slogger)
+	appLogger := logger.FromSlog(slogger)
+
+	// A subcommand such as "migrate up" runs instead of the server.
+	if cmd, ok := commands[flag.Arg(0)]; ok {
+		runCommand(cmd, cfg, flag.Args()[1:], appLogger)
+		return
+	}
+
+	// Setup storage
+	store, err := setupStorage(cfg, appLogger)
 	if err != nil {
-		log.Fatalln("setup db err:", err)
+		fatal("failed to set up storage", err)
+	}
+	if store.webhooks == nil {
+		appLogger.Warn(context.Background(), "running with in-memory storage: data is lost on restart and webhooks are disabled")
 	}
 
 	// Dependency Injection (DI): repo → usecase → controller
-	productRepo := repository.NewProductRepository(conn.DB())
-	productUC := usecase.NewProductUsecase(productRepo)
-	productCtrl := controller.NewProductController(productUC)
+	productUC, closeCache, err := newProductUsecase(cfg, store, appLogger)
+	if err != nil {
+		fatal("failed to set up cache", err)
+	}
+	productCtrl := controller.NewProductController(productUC, controller.PriceFormat(cfg.HTTP.PriceFormat))
+	healthCtrl := controller.NewHealthController(cfg.HTTP.HealthCheckTimeout, store.checkers...)
+	graphqlHdl, err := graphqlapi.NewHandler(productUC, graphqlapi.Options{
+		Limits: graphqlapi.Limits{
+			MaxDepth:      cfg.GraphQL.MaxDepth,
+			MaxComplexity: cfg.GraphQL.MaxComplexity,
+		},
+		Logger: appLogger,
+	})
+	if err != nil {
+		fatal("failed to build graphql schema", err)
+	}
+
+	// Retried creates replay the first response instead of creating duplicates.
+	idempotency := middleware.Idempotency(store.idempotency, middleware.IdempotencyOptions{
+		TTL:     cfg.Idempotency.TTL,
+		LockTTL: cfg.Idempotency.LockTTL,
+		Logger:  appLogger,
+	})
+
+	// Init router; gin's own text logger is replaced by the structured request logger.
+	// RequestID goes first so the access log and recovered panics carry the ID.
+	router := gin.New()
+	router.Use(
+		middleware.RequestID(),
+		middleware.RequestLogger(appLogger),
+		gin.Recovery(),
+		controller.ErrorFormatSelector(controller.ErrorFormat(cfg.HTTP.ErrorFormat)),
+		controller.ErrorHandler(appLogger),
+	)
+	router.GET("/healthz", healthCtrl.Liveness)
+	router.GET("/readyz", healthCtrl.Readiness)
+	router.POST("/products", idempotency, productCtrl.CreateProduct)
+	router.GET("/products", productCtrl.ListProducts)
+	router.GET("/products/:id", productCtrl.GetProduct)
+	router.PUT("/products/:id", productCtrl.UpdateProduct)
+	router.PUT("/products/by-sku/:sku", productCtrl.UpsertProductBySKU)
+	router.PATCH("/products/:id", productCtrl.PatchProduct)
+	router.DELETE("/products/:id", productCtrl.DeleteProduct)
+	router.POST("/products/:id/restore", productCtrl.RestoreProduct)
+	router.GET("/graphql", graphqlHdl.ServeGraphQL)
+	router.POST("/graphql", graphqlHdl.ServeGraphQL)
+
+	// Start server; SIGINT/SIGTERM trigger a graceful shutdown that drains
+	// in-flight requests before the DB pool is closed.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		// Restore default signal handling so a second signal force-quits.
+		<-ctx.Done()
+		stop()
+	}()
 
-	// Init router
-	router := gin.Default()
-	router.POST("/products", productCtrl.CreateProduct)
+	// Publish product events recorded in the outbox. Subscribers of the in-process
+	// publisher receive them: every event is logged, fanned out to webhook subscribers
+	// and broadcast to the gRPC watchers of every instance.
+	publisher := outbox.NewInProcessPublisher()
+	publisher.Subscribe(func(ctx context.Context, event entity.Event) error {
+		appLogger.Debug(ctx, "event published", "event_id", event.ID, "event_type", event.Type, "aggregate_id", event.AggregateID)
+		return nil
+	})
+	relay := outbox.NewRelay(store.txManager, store.outbox, publisher, outbox.RelayOptions{
+		PollInterval: cfg.Outbox.PollInterval,
+		BatchSize:    cfg.Outbox.BatchSize,
+		MaxBackoff:   cfg.Outbox.MaxBackoff,
+		Retention:    cfg.Outbox.Retention,
+		Logger:       appLogger,
+	})
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(ctx)
+	}()
 
-	// Start server
-	host := os.Getenv("HOST")
-	port := os.Getenv("PORT")
-	if host == "" || port == "" {
-		log.Fatal("HOST and PORT environment variables are required")
+	// Webhooks need the database: subscriptions and deliveries are not kept in memory.
+	dispatcherDone := make(chan struct{})
+	if store.webhooks != nil {
+		webhookUC := usecase.NewWebhookUsecase(store.webhooks, appLogger)
+		webhookCtrl := controller.NewWebhookController(webhookUC)
+		router.POST("/webhooks", webhookCtrl.CreateWebhook)
+		router.GET("/webhooks", webhookCtrl.ListWebhooks)
+		router.GET("/webhooks/:id", webhookCtrl.GetWebhook)
+		router.PUT("/webhooks/:id", webhookCtrl.UpdateWebhook)
+		router.DELETE("/webhooks/:id", webhookCtrl.DeleteWebhook)
+		router.GET("/webhooks/:id/deliveries", webhookCtrl.ListDeliveries)
+		publisher.Subscribe(webhookUC.HandleEvent)
+
+		// Send queued webhook deliveries, retrying failures until they are dead-lettered.
+		sender := webhook.NewHTTPSender(webhook.SenderOptions{
+			Timeout:              cfg.Webhook.Timeout,
+			AllowPrivateNetworks: cfg.Webhook.AllowPrivateNetworks,
+		})
+		// Claimed deliveries stay leased long enough for a whole batch to time out.
+		lease := time.Duration(cfg.Webhook.BatchSize)*cfg.Webhook.Timeout + time.Minute
+		dispatcher := webhook.NewDispatcher(store.txManager, store.webhooks, sender, webhook.DispatcherOptions{
+			PollInterval: cfg.Webhook.DispatchInterval,
+			BatchSize:    cfg.Webhook.BatchSize,
+			Lease:        lease,
+			MaxBackoff:   cfg.Webhook.MaxBackoff,
+			MaxAttempts:  cfg.Webhook.MaxAttempts,
+			Logger:       appLogger,
+		})
+		go func() {
+			defer close(dispatcherDone)
+			dispatcher.Run(ctx)
+		}()
+	} else {
+		close(dispatcherDone)
 	}
-	addr := net.JoinHostPort(host, port)
-	if err := router.Run(addr); err != nil {
-		log.Fatalf("failed to run server: %v", err)
+
+	// Serve the gRPC API next to the HTTP one. The relay publishes an event on one
+	// instance only, so WatchProducts streams the events broadcast by any of them.
+	grpcDone := make(chan error, 1)
+	listenerDone := make(chan struct{})
+	if cfg.GRPC.Port != 0 {
+		ln, err := net.Listen("tcp", cfg.GRPCAddr())
+		if err != nil {
+			fatal("failed to listen for grpc", err)
+		}
+		events := grpcserver.NewEventHub(grpcserver.DefaultWatchBuffer)
+		publisher.Subscribe(store.events.Broadcast, entity.EventProductCreated, entity.EventProductUpdated, entity.EventProductDeleted)
+		go func() {
+			defer close(listenerDone)
+			store.events.Listen(ctx, events.Handle)
+		}()
+		grpcSrv := grpcserver.New(productUC, events, grpcserver.Options{
+			ShutdownTimeout: cfg.HTTP.ShutdownTimeout,
+			Logger:          appLogger,
+		})
+		go func() {
+			err := grpcSrv.Serve(ctx, ln)
+			if err != nil {
+				// Without its gRPC API the instance is unhealthy: shut down the HTTP server too.
+				stop()
+			}
+			grpcDone <- err
+		}()
+	} else {
+		grpcDone <- nil
+		close(listenerDone)
 	}
+
+	srv := server.New(cfg.HTTP.Addr(), router, server.Options{
+		ShutdownTimeout: cfg.HTTP.ShutdownTimeout,
+		ShutdownDelay:   cfg.HTTP.ShutdownDelay,
+		Logger:          appLogger,
+	})
+	srv.OnShutdown(healthCtrl.MarkShuttingDown)
+	srv.OnClose("database", store.close)
+	srv.OnClose("cache", closeCache)
+	// Closed first (reverse order): the workers must finish their batch before the pool goes away.
+	srv.OnClose("outbox relay", func() error {
+		<-relayDone
+		return nil
+	})
+	srv.OnClose("event listener", func() error {
+		<-listenerDone
+		return nil
+	})
+	srv.OnClose("webhook dispatcher", func() error {
+		<-dispatcherDone
+		return nil
+	})
+	srv.OnClose("grpc server", func() error {
+		return <-grpcDone
+	})
+
+	if err := srv.Run(ctx); err != nil {
+		fatal("server stopped with error", err)
+	}
+	appLogger.Info(context.Background(), "server stopped gracefully")
+}
+
+// fatal logs err through slog.Default and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
 
-func setupDB() (dbkit.Connection, error) {
-	port, err := strconv.Atoi(os.Getenv("DB_PORT"))
+// storage holds the repositories of the selected storage mode.
+type storage struct {
+	txManager   port.TxManager
+	products    port.ProductRepository
+	outbox      port.OutboxRepository
+	events      port.EventBroadcaster
+	idempotency port.IdempotencyStore
+	checkers    []port.HealthChecker
+
+	// webhooks is nil when webhooks are unavailable.
+	webhooks port.WebhookRepository
+
+	// close releases the database connections.
+	close func() error
+}
+
+// setupStorage connects to Postgres, or with STORAGE=memory keeps everything in process
+// memory so the API runs without a database, e.g. for demos.
+func setupStorage(cfg *config.Config, appLogger port.Logger) (*storage, error) {
+	switch cfg.Storage {
+	case "memory":
+		return &storage{
+			txManager:   repository.NewMemoryTxManager(),
+			products:    repository.NewMemoryProductRepository(),
+			outbox:      repository.NewMemoryOutboxRepository(),
+			events:      repository.NewMemoryEventBroadcaster(),
+			idempotency: repository.NewMemoryIdempotencyStore(),
+			close:       func() error { return nil },
+		}, nil
+	default:
+		conn, err := setupDB(cfg.DB)
+		if err != nil {
+			return nil, err
+		}
+
+		// Route GORM's logs through the app logger so failed queries carry the request ID.
+		db := conn.DB()
+		db.Logger = repository.NewGormLogger(appLogger, repository.DefaultSlowQueryThreshold)
+
+		idempotencyStore := repository.NewPostgresIdempotencyStore(db)
+		if cfg.Idempotency.Store == "memory" {
+			idempotencyStore = repository.NewMemoryIdempotencyStore()
+		}
+
+		return &storage{
+			txManager:   repository.NewTxManager(db),
+			products:    repository.NewProductRepository(db),
+			outbox:      repository.NewOutboxRepository(db),
+			events:      repository.NewPostgresEventBroadcaster(db, appLogger),
+			idempotency: idempotencyStore,
+			checkers:    []port.HealthChecker{repository.NewPostgresHealthChecker(db)},
+			webhooks:    repository.NewWebhookRepository(db),
+			close:       conn.Close,
+		}, nil
+	}
+}
+
+// newProductUsecase wires the product usecase onto store, serving product reads from
+// cache when one is configured. A cache server is added to the readiness checks of
+// store; the returned function releases its connections.
+func newProductUsecase(cfg *config.Config, store *storage, appLogger port.Logger) (port.ProductUsecase, func() error, error) {
+	productRepo := store.products
+	cache, checker, closeCache, err := setupCache(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if checker != nil {
+		store.checkers = append(store.checkers, checker)
+	}
+	if cache != nil {
+		productRepo = repository.NewCachedProductRepository(productRepo, cache, repository.ProductCacheOptions{
+			TTL:    cfg.Cache.TTL,
+			Logger: appLogger,
+		})
 	}
 
-	conn, err := dbkit.NewPostgreSQLConnection(config.PostgreSQLConfig{
-		Config: config.Config{
-			Host:     os.Getenv("DB_HOST"),
-			Port:     port,
-			Username: os.Getenv("DB_USERNAME"),
-			Password: os.Getenv("DB_PASSWORD"),
-			Database: os.Getenv("DB_DATABASE"),
-			TimeZone: os.Getenv("DB_TIMEZONE"),
+	return usecase.NewProductUsecase(productRepo, store.outbox, store.txManager, appLogger), closeCache, nil
+}
+
+// setupCache returns the cache selected by CACHE_STORE, or nil when caching is disabled,
+// along with the health checker of its server, if it has one, and the function
+// releasing its connections.
+func setupCache(cfg *config.Config) (port.Cache, port.HealthChecker, func() error, error) {
+	noop := func() error { return nil }
+
+	switch cfg.Cache.Store {
+	case "redis":
+		client := redis.NewClient(&redis.Options{
+			Addr:     net.JoinHostPort(cfg.Redis.Host, strconv.Itoa(cfg.Redis.Port)),
+			DB:       cfg.Redis.Database,
+			Password: cfg.Redis.Password,
+		})
+		return repository.NewRedisCache(client, cfg.Service.Name+":"), repository.NewRedisHealthChecker(client), client.Close, nil
+	case "memory":
+		cache, err := repository.NewLRUCache(cfg.Cache.Size)
+		return cache, nil, noop, err
+	default:
+		return nil, nil, noop, nil
+	}
+}
+
+func setupDB(cfg config.DBConfig) (dbkit.Connection, error) {
+	conn, err := dbkit.NewPostgreSQLConnection(dbconfig.PostgreSQLConfig{
+		Config: dbconfig.Config{
+			Host:     cfg.Host,
+			Port:     cfg.Port,
+			Username: cfg.Username,
+			Password: cfg.Password,
+			Database: cfg.Database,
+			TimeZone: cfg.TimeZone,
+		},
+		PoolConfig: dbconfig.PoolConfig{
+			MaxOpenConnection: cfg.MaxOpenConnections,
+			MaxIdleConnection: cfg.MaxIdleConnections,
+			ConnMaxIdleTime:   cfg.MaxConnectionIdleTime,
+			ConnMaxLifetime:   cfg.MaxConnectionLifetime,
 		},
+		SSLMode: dbconfig.PgSSLConfig(cfg.SSLMode),
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/DucTran999/go-clean-archx/internal/config"
+	"github.com/DucTran999/go-clean-archx/internal/migrate"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/migrations"
+)
+
+const migrateUsage = `usage: main migrate <command>
+
+commands:
+  up               apply every pending migration
+  down N           roll back the last N migrations
+  status           show the current version and the pending migrations
+  goto VERSION     migrate up or down to VERSION (0 rolls back everything)
+  force VERSION    record VERSION and clear the dirty flag without running anything`
+
+// runMigrate implements the migrate subcommand with the migrations embedded in the binary.
+func runMigrate(ctx context.Context, cfg *config.Config, args []string, out io.Writer, appLogger port.Logger) error {
+	command, arg, err := parseMigrateArgs(args)
+	if err != nil {
+		return err
+	}
+	conn, err := openPostgres(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	sqlDB, err := conn.DB().DB()
+	if err != nil {
+		return err
+	}
+
+	migrator, err := migrate.New(sqlDB, migrations.FS, migrate.Options{Logger: appLogger})
+	if err != nil {
+		return err
+	}
+
+	var applied int
+	switch command {
+	case "up":
+		applied, err = migrator.Up(ctx)
+	case "down":
+		applied, err = migrator.Down(ctx, int(arg))
+	case "goto":
+		applied, err = migrator.Goto(ctx, arg)
+	case "force":
+		if err := migrator.Force(ctx, arg); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "version forced to %d\n", arg)
+		return err
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printMigrateStatus(out, status)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "%d migration(s) applied\n", applied)
+	return err
+}
+
+// parseMigrateArgs validates the command line and returns the command and its numeric argument.
+func parseMigrateArgs(args []string) (string, uint64, error) {
+	if len(args) == 0 {
+		return "", 0, fmt.Errorf("%w: missing command", errUsage)
+	}
+
+	command := args[0]
+	switch command {
+	case "up", "status":
+		if len(args) != 1 {
+			return "", 0, fmt.Errorf("%w: %s takes no argument", errUsage, command)
+		}
+		return command, 0, nil
+	case "down", "goto", "force":
+		if len(args) != 2 {
+			return "", 0, fmt.Errorf("%w: %s takes one argument", errUsage, command)
+		}
+		arg, err := strconv.ParseUint(args[1], 10, 63)
+		if err != nil || (command == "down" && arg == 0) {
+			return "", 0, fmt.Errorf("%w: invalid argument %q for %s", errUsage, args[1], command)
+		}
+		return command, arg, nil
+	default:
+		return "", 0, fmt.Errorf("%w: unknown command %q", errUsage, command)
+	}
+}
+
+// printMigrateStatus writes the version followed by one line per migration.
+func printMigrateStatus(out io.Writer, status migrate.Status) error {
+	state := "clean"
+	if status.Dirty {
+		state = "dirty"
+	}
+	if _, err := fmt.Fprintf(out, "version: %d (%s)\n", status.Version, state); err != nil {
+		return err
+	}
+
+	for _, m := range status.Migrations {
+		mark := "pending"
+		if m.Version <= status.Version {
+			mark = "applied"
+		}
+		if _, err := fmt.Fprintf(out, "%-8s %d_%s\n", mark, m.Version, m.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
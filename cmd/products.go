@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/DucTran999/go-clean-archx/internal/cli"
+	"github.com/DucTran999/go-clean-archx/internal/config"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+)
+
+// runProducts manages products through the same usecase the HTTP API uses.
+func runProducts(ctx context.Context, cfg *config.Config, args []string, out io.Writer, appLogger port.Logger) (err error) {
+	store, err := setupStorage(cfg, appLogger)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, store.close()) }()
+
+	productUC, closeCache, err := newProductUsecase(cfg, store, appLogger)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, closeCache()) }()
+
+	return cli.NewProductCommands(productUC, out).Run(ctx, args)
+}
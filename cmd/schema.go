@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/DucTran999/go-clean-archx/internal/config"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+)
+
+const schemaUsage = `usage: main schema check
+
+Compares the GORM tags of the models with the live database and lists missing tables,
+columns, constraints and indexes and mismatched types. It exits with status 1 when
+anything differs, so it can gate a deploy after "migrate up".`
+
+// runSchema implements the schema subcommand.
+func runSchema(ctx context.Context, cfg *config.Config, args []string, out io.Writer, _ port.Logger) error {
+	if len(args) != 1 || args[0] != "check" {
+		return fmt.Errorf("%w: want \"schema check\"", errUsage)
+	}
+
+	conn, err := openPostgres(cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	drifts, err := repository.CheckSchema(ctx, conn.DB(), repository.SchemaModels()...)
+	if err != nil {
+		return err
+	}
+	for _, d := range drifts {
+		if _, err := fmt.Fprintln(out, d); err != nil {
+			return err
+		}
+	}
+	if len(drifts) > 0 {
+		return fmt.Errorf("schema differs from the models in %d place(s)", len(drifts))
+	}
+
+	_, err = fmt.Fprintln(out, "schema matches the models")
+	return err
+}
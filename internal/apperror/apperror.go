@@ -0,0 +1,120 @@
+// Package apperror defines the error kinds shared by every layer of the application.
+//
+// A Kind says what went wrong in domain terms (not found, conflict, ...) without saying
+// how it is reported. Repositories translate storage errors into kinds, usecases keep them
+// in the error chain when wrapping with fmt.Errorf("...: %w"), and the delivery layer maps
+// each kind to a status code in a single place.
+package apperror
+
+import "errors"
+
+// Kind classifies an error. The zero value, Unknown, means an unexpected failure.
+//
+// Kind implements error so it can be used as an errors.Is target:
+//
+//	if errors.Is(err, apperror.NotFound) { ... }
+type Kind uint8
+
+const (
+	Unknown              Kind = iota // unexpected failure, reported as an internal error
+	Invalid                          // the input breaks a validation or business rule
+	NotFound                         // the requested resource does not exist
+	Conflict                         // the request conflicts with the current state, e.g. a duplicate key
+	PreconditionFailed               // a condition set by the caller, e.g. an expected version, does not hold
+	Forbidden                        // the caller may not perform the operation
+	Unavailable                      // a dependency is temporarily unavailable; the call may be retried
+	Unprocessable                    // the request is well-formed but cannot be applied as sent, e.g. a reused idempotency key
+	PreconditionRequired             // the operation requires a condition, e.g. an expected version, that the caller did not set
+)
+
+var kindNames = [...]string{
+	Unknown:              "unknown",
+	Invalid:              "invalid",
+	NotFound:             "not_found",
+	Conflict:             "conflict",
+	PreconditionFailed:   "precondition_failed",
+	Forbidden:            "forbidden",
+	Unavailable:          "unavailable",
+	Unprocessable:        "unprocessable",
+	PreconditionRequired: "precondition_required",
+}
+
+// String returns the snake_case name of the kind, e.g. "not_found".
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+
+	return kindNames[Unknown]
+}
+
+// Error implements error so a Kind can be passed to errors.Is.
+func (k Kind) Error() string {
+	return k.String()
+}
+
+// Error attaches a Kind and an optional client-safe message to an underlying error.
+type Error struct {
+	Kind    Kind
+	Message string // Safe to show to clients; may be empty
+	Err     error  // Underlying cause; may be nil
+}
+
+// New returns an error of the given kind with a message and no cause.
+// It is meant for package-level sentinels such as entity.ErrProductNotFound.
+func New(kind Kind, msg string) error {
+	return &Error{Kind: kind, Message: msg}
+}
+
+// Wrap attaches kind and msg to err. It returns nil when err is nil.
+func Wrap(kind Kind, err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{Kind: kind, Message: msg, Err: err}
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Message
+	case e.Message == "":
+		return e.Err.Error()
+	default:
+		return e.Message + ": " + e.Err.Error()
+	}
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the Kind of e.
+func (e *Error) Is(target error) bool {
+	kind, ok := target.(Kind)
+	return ok && kind == e.Kind
+}
+
+// KindOf returns the kind of the outermost *Error in err's chain, or Unknown.
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+
+	return Unknown
+}
+
+// MessageOf returns the first non-empty client-safe message in err's chain, or "".
+func MessageOf(err error) string {
+	for err != nil {
+		if appErr, ok := err.(*Error); ok && appErr.Message != "" {
+			return appErr.Message
+		}
+		err = errors.Unwrap(err)
+	}
+
+	return ""
+}
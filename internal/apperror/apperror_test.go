@@ -0,0 +1,83 @@
+package apperror_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKindOf(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("duplicate key value violates unique constraint")
+	sentinel := apperror.New(apperror.NotFound, "product not found")
+
+	tests := []struct {
+		name         string
+		err          error
+		expectedKind apperror.Kind
+		expectedMsg  string
+	}{
+		{name: "nil", err: nil, expectedKind: apperror.Unknown},
+		{name: "plain error", err: cause, expectedKind: apperror.Unknown},
+		{name: "sentinel", err: sentinel, expectedKind: apperror.NotFound, expectedMsg: "product not found"},
+		{
+			name:         "sentinel wrapped by usecase",
+			err:          fmt.Errorf("failed to get product: %w", sentinel),
+			expectedKind: apperror.NotFound,
+			expectedMsg:  "product not found",
+		},
+		{
+			name:         "wrapped cause",
+			err:          apperror.Wrap(apperror.Conflict, cause, "resource already exists"),
+			expectedKind: apperror.Conflict,
+			expectedMsg:  "resource already exists",
+		},
+		{
+			name:         "outermost kind wins",
+			err:          apperror.Wrap(apperror.Invalid, sentinel, ""),
+			expectedKind: apperror.Invalid,
+			expectedMsg:  "product not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedKind, apperror.KindOf(tc.err))
+			assert.Equal(t, tc.expectedMsg, apperror.MessageOf(tc.err))
+		})
+	}
+}
+
+func TestError_IsKind(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("record not found")
+	err := fmt.Errorf("failed to delete product: %w", apperror.Wrap(apperror.NotFound, cause, "product not found"))
+
+	require.ErrorIs(t, err, apperror.NotFound)
+	require.ErrorIs(t, err, cause)
+	require.NotErrorIs(t, err, apperror.Conflict)
+	assert.EqualError(t, err, "failed to delete product: product not found: record not found")
+}
+
+func TestWrap_Nil(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, apperror.Wrap(apperror.Conflict, nil, "resource already exists"))
+}
+
+func TestKind_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "precondition_failed", apperror.PreconditionFailed.String())
+	assert.Equal(t, "unprocessable", apperror.Unprocessable.String())
+	assert.Equal(t, "precondition_required", apperror.PreconditionRequired.String())
+	assert.Equal(t, "unknown", apperror.Kind(200).String())
+}
@@ -0,0 +1,111 @@
+// Package cli implements the product subcommands of the binary, such as list and
+// import. Each command parses its flags, calls the product usecase and prints what
+// it got back as a table, JSON or YAML, so an operator can script against the
+// catalog without going through the network APIs.
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUsage is returned for a malformed command line; the caller should print the usage.
+var ErrUsage = errors.New("invalid command line")
+
+// Format selects how results are printed.
+type Format string
+
+// Supported output formats.
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatYAML  Format = "yaml"
+)
+
+// formatFlag is a flag.Value accepting the supported formats only.
+type formatFlag struct {
+	format *Format
+}
+
+func (f formatFlag) String() string {
+	if f.format == nil {
+		return ""
+	}
+	return string(*f.format)
+}
+
+func (f formatFlag) Set(s string) error {
+	switch Format(s) {
+	case FormatTable, FormatJSON, FormatYAML:
+		*f.format = Format(s)
+		return nil
+	default:
+		return fmt.Errorf("must be one of [table json yaml], got %q", s)
+	}
+}
+
+// newFlagSet returns a flag set for the command name with the -o output flag, which
+// reports errors instead of printing them and exiting.
+func newFlagSet(name string, format *Format) *flag.FlagSet {
+	*format = FormatTable
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(formatFlag{format}, "o", "output format: table, json or yaml")
+
+	return fs
+}
+
+// parseFlags parses args with fs and returns the positional arguments. Unlike
+// fs.Parse, flags may follow positional arguments, e.g. "get ID -o json".
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrUsage, fs.Name(), err)
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// isSet reports whether the flag name was given on the command line.
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+
+	return set
+}
+
+// render writes v as JSON or YAML, or calls table to write it as a table.
+func render(out io.Writer, format Format, v any, table func(w io.Writer)) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case FormatYAML:
+		enc := yaml.NewEncoder(out)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		table(tw)
+		return tw.Flush()
+	}
+}
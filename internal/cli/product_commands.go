@@ -0,0 +1,556 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
+)
+
+// ProductUsage describes the products command tree.
+const ProductUsage = `usage: products <command> [flags]
+
+commands:
+  create --name NAME --price PRICE [--qty N] [--currency CODE] [--sku SKU]
+  get ID [--deleted]
+  list [--limit N | --all] [--sort created_at|name|price] [--order asc|desc]
+       [--name-prefix P] [--currency CODE] [--min-price P] [--max-price P]
+       [--min-qty N] [--max-qty N] [--deleted]
+  update ID [--name NAME] [--qty N] [--price PRICE] [--currency CODE] [--sku SKU] [--version V]
+  delete ID
+  restore ID
+  import FILE|- [--format csv|json]
+  help
+
+Prices are decimal amounts, e.g. 12.30. Every command accepts -o table|json|yaml.
+update only changes the fields given; --version rejects the update if the product
+changed since that version was read. import creates or replaces products by SKU,
+from CSV with a header row (sku,name,qty,price,currency) or a JSON array of objects.`
+
+// ProductCommands runs the products command tree against a ProductUsecase.
+type ProductCommands struct {
+	uc  port.ProductUsecase
+	out io.Writer
+}
+
+// NewProductCommands creates the products commands, printing their results to out.
+func NewProductCommands(uc port.ProductUsecase, out io.Writer) *ProductCommands {
+	return &ProductCommands{
+		uc:  uc,
+		out: out,
+	}
+}
+
+// Run executes the command named by args[0] with the remaining arguments.
+// A malformed command line is reported with ErrUsage.
+func (c *ProductCommands) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: missing command", ErrUsage)
+	}
+	if args[0] == "help" {
+		_, err := fmt.Fprintln(c.out, ProductUsage)
+		return err
+	}
+
+	run, ok := map[string]func(context.Context, []string) error{
+		"create":  c.create,
+		"get":     c.get,
+		"list":    c.list,
+		"update":  c.update,
+		"delete":  c.delete,
+		"restore": c.restore,
+		"import":  c.importProducts,
+	}[args[0]]
+	if !ok {
+		return fmt.Errorf("%w: unknown command %q", ErrUsage, args[0])
+	}
+
+	return run(ctx, args[1:])
+}
+
+func (c *ProductCommands) create(ctx context.Context, args []string) error {
+	var (
+		format                     Format
+		sku, name, price, currency string
+		qty                        int
+	)
+	fs := newFlagSet("create", &format)
+	fs.StringVar(&sku, "sku", "", "business identifier")
+	fs.StringVar(&name, "name", "", "product name")
+	fs.IntVar(&qty, "qty", 0, "quantity in stock")
+	fs.StringVar(&price, "price", "", "decimal price, e.g. 12.30")
+	fs.StringVar(&currency, "currency", "", "ISO 4217 code (default "+entity.DefaultCurrency+")")
+	if err := expectArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	product, err := c.uc.CreateProduct(ctx, dto.CreateProductInput{
+		SKU:   sku,
+		Name:  name,
+		Qty:   qty,
+		Price: dto.PriceInput{Amount: price, Currency: currency},
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.renderProduct(format, product)
+}
+
+func (c *ProductCommands) get(ctx context.Context, args []string) error {
+	var (
+		format         Format
+		includeDeleted bool
+	)
+	fs := newFlagSet("get", &format)
+	fs.BoolVar(&includeDeleted, "deleted", false, "also find a soft-deleted product")
+	id, err := expectID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	product, err := c.uc.GetByID(ctx, dto.GetProductQuery{ID: id, IncludeDeleted: includeDeleted})
+	if err != nil {
+		return err
+	}
+
+	return c.renderProduct(format, product)
+}
+
+func (c *ProductCommands) list(ctx context.Context, args []string) error {
+	var (
+		format                            Format
+		all                               bool
+		query                             dto.ListProductsQuery
+		sortBy, order, minPrice, maxPrice string
+		minQty, maxQty                    int
+	)
+	fs := newFlagSet("list", &format)
+	fs.IntVar(&query.Limit, "limit", dto.DefaultListLimit, "page size")
+	fs.BoolVar(&all, "all", false, "fetch every page")
+	fs.StringVar(&sortBy, "sort", string(dto.SortByCreatedAt), "sort field: created_at, name or price")
+	fs.StringVar(&order, "order", string(dto.SortDesc), "sort order: asc or desc")
+	fs.StringVar(&query.NamePrefix, "name-prefix", "", "case-insensitive name prefix")
+	fs.StringVar(&query.Currency, "currency", "", "ISO 4217 code")
+	fs.StringVar(&minPrice, "min-price", "", "lowest decimal price")
+	fs.StringVar(&maxPrice, "max-price", "", "highest decimal price")
+	fs.IntVar(&minQty, "min-qty", 0, "lowest quantity")
+	fs.IntVar(&maxQty, "max-qty", 0, "highest quantity")
+	fs.BoolVar(&query.IncludeDeleted, "deleted", false, "include soft-deleted products")
+	if err := expectArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	query.SortBy = dto.ProductSortField(sortBy)
+	query.Order = dto.SortOrder(order)
+	if isSet(fs, "min-qty") {
+		query.MinQty = &minQty
+	}
+	if isSet(fs, "max-qty") {
+		query.MaxQty = &maxQty
+	}
+	if err := parsePriceRange(&query, minPrice, maxPrice); err != nil {
+		return err
+	}
+	if all {
+		query.Limit = dto.MaxListLimit
+	}
+
+	var products []entity.Product
+	for {
+		page, err := c.uc.List(ctx, query)
+		if err != nil {
+			return err
+		}
+		products = append(products, page.Items...)
+		if !all || !page.HasMore {
+			return c.renderProducts(format, products, page.HasMore)
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+func (c *ProductCommands) update(ctx context.Context, args []string) error {
+	var (
+		format                     Format
+		sku, name, price, currency string
+		qty                        int
+		version                    int64
+	)
+	fs := newFlagSet("update", &format)
+	fs.StringVar(&sku, "sku", "", "business identifier; empty removes it")
+	fs.StringVar(&name, "name", "", "product name")
+	fs.IntVar(&qty, "qty", 0, "quantity in stock")
+	fs.StringVar(&price, "price", "", "decimal price, e.g. 12.30")
+	fs.StringVar(&currency, "currency", "", "ISO 4217 code, changed together with the price")
+	fs.Int64Var(&version, "version", 0, "version the change is based on (0 skips the check)")
+	id, err := expectID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	input := dto.PatchProductInput{ID: id, ExpectedVersion: version}
+	if isSet(fs, "sku") {
+		input.SKU = &sku
+	}
+	if isSet(fs, "name") {
+		input.Name = &name
+	}
+	if isSet(fs, "qty") {
+		input.Qty = &qty
+	}
+	if isSet(fs, "price") || isSet(fs, "currency") {
+		input.Price = &dto.PriceInput{Amount: price, Currency: currency}
+	}
+	if input.SKU == nil && input.Name == nil && input.Qty == nil && input.Price == nil {
+		return fmt.Errorf("%w: update: nothing to update", ErrUsage)
+	}
+
+	product, err := c.uc.PatchProduct(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	return c.renderProduct(format, product)
+}
+
+func (c *ProductCommands) delete(ctx context.Context, args []string) error {
+	var format Format
+	fs := newFlagSet("delete", &format)
+	id, err := expectID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.uc.DeleteProduct(ctx, id); err != nil {
+		return err
+	}
+
+	result := struct {
+		ID      string `json:"id" yaml:"id"`
+		Deleted bool   `json:"deleted" yaml:"deleted"`
+	}{ID: id.String(), Deleted: true}
+
+	return render(c.out, format, result, func(w io.Writer) {
+		fmt.Fprintf(w, "product %s deleted\n", id)
+	})
+}
+
+func (c *ProductCommands) restore(ctx context.Context, args []string) error {
+	var format Format
+	fs := newFlagSet("restore", &format)
+	id, err := expectID(fs, args)
+	if err != nil {
+		return err
+	}
+
+	product, err := c.uc.RestoreProduct(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return c.renderProduct(format, product)
+}
+
+// importRow is a product read from an import file.
+type importRow struct {
+	SKU      string      `json:"sku"`
+	Name     string      `json:"name"`
+	Qty      int         `json:"qty"`
+	Price    json.Number `json:"price"`
+	Currency string      `json:"currency"`
+}
+
+// importResult is the outcome of one imported row.
+type importResult struct {
+	Row    int    `json:"row" yaml:"row"`
+	SKU    string `json:"sku" yaml:"sku"`
+	Result string `json:"result" yaml:"result"` // created, updated or failed
+	ID     string `json:"id,omitempty" yaml:"id,omitempty"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+func (c *ProductCommands) importProducts(ctx context.Context, args []string) error {
+	var (
+		format     Format
+		fileFormat string
+	)
+	fs := newFlagSet("import", &format)
+	fs.StringVar(&fileFormat, "format", "", "file format: csv or json (default from the extension, csv for stdin)")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("%w: import takes one file, or - for stdin", ErrUsage)
+	}
+	path := positional[0]
+	if fileFormat == "" {
+		fileFormat = "csv"
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			fileFormat = "json"
+		}
+	}
+
+	var in io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var rows []importRow
+	switch fileFormat {
+	case "csv":
+		rows, err = readCSVRows(in)
+	case "json":
+		dec := json.NewDecoder(in)
+		dec.UseNumber()
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&rows)
+	default:
+		return fmt.Errorf("%w: import: --format must be csv or json, got %q", ErrUsage, fileFormat)
+	}
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+
+	// Every row is attempted, so one bad row does not hide the others.
+	results := make([]importResult, 0, len(rows))
+	failed := 0
+	for i, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		result := importResult{Row: i + 1, SKU: row.SKU}
+		product, created, err := c.uc.UpsertProductBySKU(ctx, dto.UpsertProductInput{
+			SKU:   row.SKU,
+			Name:  row.Name,
+			Qty:   row.Qty,
+			Price: dto.PriceInput{Amount: row.Price.String(), Currency: row.Currency},
+
+			// Importing replaces whatever is stored, like If-Match: *.
+			ExpectedVersion: new(int64),
+		})
+		switch {
+		case err != nil:
+			result.Result, result.Error = "failed", err.Error()
+			failed++
+		case created:
+			result.Result, result.ID = "created", product.ID.String()
+		default:
+			result.Result, result.ID = "updated", product.ID.String()
+		}
+		results = append(results, result)
+	}
+
+	err = render(c.out, format, results, func(w io.Writer) {
+		fmt.Fprintln(w, "ROW\tSKU\tRESULT\tID\tERROR")
+		for _, r := range results {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", r.Row, r.SKU, r.Result, r.ID, r.Error)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d rows failed to import", failed, len(rows))
+	}
+
+	return nil
+}
+
+// readCSVRows reads rows by the column names of the header; qty and currency are optional.
+func readCSVRows(in io.Reader) ([]importRow, error) {
+	r := csv.NewReader(in)
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"sku", "name", "price"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("header has no %q column", required)
+		}
+	}
+	get := func(record []string, name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []importRow
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := importRow{
+			SKU:      get(record, "sku"),
+			Name:     get(record, "name"),
+			Price:    json.Number(get(record, "price")),
+			Currency: get(record, "currency"),
+		}
+		if qty := get(record, "qty"); qty != "" {
+			line, _ := r.FieldPos(0)
+			if row.Qty, err = strconv.Atoi(qty); err != nil {
+				return nil, fmt.Errorf("line %d: qty %q is not an integer", line, qty)
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// productView is how a product is printed as JSON or YAML; the field names match
+// the HTTP API.
+type productView struct {
+	ID         string     `json:"id" yaml:"id"`
+	SKU        string     `json:"sku,omitempty" yaml:"sku,omitempty"`
+	Name       string     `json:"name" yaml:"name"`
+	Qty        int        `json:"qty" yaml:"qty"`
+	Price      string     `json:"price" yaml:"price"`
+	PriceMinor int64      `json:"priceMinor" yaml:"priceMinor"`
+	Currency   string     `json:"currency" yaml:"currency"`
+	CreatedAt  time.Time  `json:"createdAt" yaml:"createdAt"`
+	UpdatedAt  *time.Time `json:"updatedAt,omitempty" yaml:"updatedAt,omitempty"`
+	DeletedAt  *time.Time `json:"deletedAt,omitempty" yaml:"deletedAt,omitempty"`
+	Version    int64      `json:"version" yaml:"version"`
+}
+
+func newProductView(p *entity.Product) productView {
+	return productView{
+		ID:         p.ID.String(),
+		SKU:        string(p.SKU),
+		Name:       p.Name,
+		Qty:        p.Qty,
+		Price:      p.Price.Decimal(),
+		PriceMinor: p.Price.Amount,
+		Currency:   p.Price.Currency,
+		CreatedAt:  p.CreatedAt,
+		UpdatedAt:  p.UpdatedAt,
+		DeletedAt:  p.DeletedAt,
+		Version:    p.Version,
+	}
+}
+
+// renderProduct prints one product; JSON and YAML get an object rather than a list.
+func (c *ProductCommands) renderProduct(format Format, product *entity.Product) error {
+	view := newProductView(product)
+	return render(c.out, format, view, productTable([]productView{view}, false))
+}
+
+// renderProducts prints a listing; hasMore adds a hint to the table that it was cut short.
+func (c *ProductCommands) renderProducts(format Format, products []entity.Product, hasMore bool) error {
+	views := make([]productView, 0, len(products))
+	for i := range products {
+		views = append(views, newProductView(&products[i]))
+	}
+
+	return render(c.out, format, views, productTable(views, hasMore))
+}
+
+func productTable(views []productView, hasMore bool) func(w io.Writer) {
+	return func(w io.Writer) {
+		fmt.Fprintln(w, "ID\tSKU\tNAME\tQTY\tPRICE\tVERSION\tDELETED")
+		for _, p := range views {
+			deleted := ""
+			if p.DeletedAt != nil {
+				deleted = p.DeletedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s %s\t%d\t%s\n", p.ID, p.SKU, p.Name, p.Qty, p.Price, p.Currency, p.Version, deleted)
+		}
+		if hasMore {
+			fmt.Fprintln(w, "(more products: use --all or a larger --limit)")
+		}
+	}
+}
+
+// expectArgs parses args and checks the number of positional arguments.
+func expectArgs(fs *flag.FlagSet, args []string, n int) error {
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != n {
+		return fmt.Errorf("%w: %s takes %d argument(s), got %d", ErrUsage, fs.Name(), n, len(positional))
+	}
+
+	return nil
+}
+
+// expectID parses args, whose only positional argument must be a product ID.
+func expectID(fs *flag.FlagSet, args []string) (uuid.UUID, error) {
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if len(positional) != 1 {
+		return uuid.Nil, fmt.Errorf("%w: %s takes a product ID", ErrUsage, fs.Name())
+	}
+
+	id, err := uuid.Parse(positional[0])
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %s: %q is not a product ID", ErrUsage, fs.Name(), positional[0])
+	}
+
+	return id, nil
+}
+
+// parsePriceRange converts the decimal price bounds into minor units of the query's
+// currency, entity.DefaultCurrency when none is given, as the HTTP API does.
+func parsePriceRange(query *dto.ListProductsQuery, minPrice, maxPrice string) error {
+	if query.Currency == "" && (minPrice != "" || maxPrice != "" || query.SortBy == dto.SortByPrice) {
+		query.Currency = entity.DefaultCurrency
+	}
+
+	for _, bound := range []struct {
+		flag  string
+		value string
+		dest  **int64
+	}{
+		{"min-price", minPrice, &query.MinPrice},
+		{"max-price", maxPrice, &query.MaxPrice},
+	} {
+		if bound.value == "" {
+			continue
+		}
+		price, err := entity.ParseMoney(bound.value, query.Currency)
+		if err != nil {
+			return fmt.Errorf("%w: list: --%s: %w", ErrUsage, bound.flag, err)
+		}
+		*bound.dest = &price.Amount
+	}
+
+	return nil
+}
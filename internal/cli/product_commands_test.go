@@ -0,0 +1,296 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/cli"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// productJSON is the subset of the printed product the tests look at.
+type productJSON struct {
+	ID         string `json:"id" yaml:"id"`
+	SKU        string `json:"sku" yaml:"sku"`
+	Name       string `json:"name" yaml:"name"`
+	Qty        int    `json:"qty" yaml:"qty"`
+	Price      string `json:"price" yaml:"price"`
+	PriceMinor int64  `json:"priceMinor" yaml:"priceMinor"`
+	Currency   string `json:"currency" yaml:"currency"`
+	Version    int64  `json:"version" yaml:"version"`
+	DeletedAt  string `json:"deletedAt" yaml:"deletedAt"`
+}
+
+// commandRunner runs product commands against the in-memory repositories.
+type commandRunner struct {
+	t   *testing.T
+	cmd *cli.ProductCommands
+	out *bytes.Buffer
+}
+
+func newCommandRunner(t *testing.T) *commandRunner {
+	t.Helper()
+	uc := usecase.NewProductUsecase(
+		repository.NewMemoryProductRepository(), repository.NewMemoryOutboxRepository(),
+		repository.NewMemoryTxManager(), logger.NewNop(),
+	)
+	out := &bytes.Buffer{}
+
+	return &commandRunner{t: t, cmd: cli.NewProductCommands(uc, out), out: out}
+}
+
+// run executes args and returns what the command printed.
+func (r *commandRunner) run(args ...string) (string, error) {
+	r.out.Reset()
+	err := r.cmd.Run(r.t.Context(), args)
+	return r.out.String(), err
+}
+
+// runJSON executes args with -o json and decodes the output into v.
+func (r *commandRunner) runJSON(v any, args ...string) {
+	r.t.Helper()
+	out, err := r.run(append(args, "-o", "json")...)
+	require.NoError(r.t, err)
+	require.NoError(r.t, json.Unmarshal([]byte(out), v), out)
+}
+
+func TestProductCommands_CreateGetUpdateDelete(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	r := newCommandRunner(t)
+
+	// Act & Assert
+	var created productJSON
+	r.runJSON(&created, "create", "--sku", "MUG-1", "--name", "Mug", "--qty", "3", "--price", "12.3")
+	assert.Equal(t, "MUG-1", created.SKU)
+	assert.Equal(t, "12.30", created.Price)
+	assert.Equal(t, int64(1230), created.PriceMinor)
+	assert.Equal(t, entity.DefaultCurrency, created.Currency)
+	assert.Equal(t, int64(1), created.Version)
+
+	var got productJSON
+	r.runJSON(&got, "get", created.ID)
+	assert.Equal(t, created, got)
+
+	var updated productJSON
+	r.runJSON(&updated, "update", created.ID, "--qty", "0", "--version", "1")
+	assert.Equal(t, 0, updated.Qty)
+	assert.Equal(t, "Mug", updated.Name, "fields not given are kept")
+	assert.Equal(t, int64(2), updated.Version)
+
+	_, err := r.run("update", created.ID, "--qty", "5", "--version", "1")
+	require.ErrorIs(t, err, entity.ErrProductVersionMismatch)
+
+	out, err := r.run("delete", created.ID)
+	require.NoError(t, err)
+	assert.Contains(t, out, "deleted")
+
+	_, err = r.run("get", created.ID)
+	require.ErrorIs(t, err, entity.ErrProductNotFound)
+
+	var deleted productJSON
+	r.runJSON(&deleted, "get", created.ID, "--deleted")
+	assert.NotEmpty(t, deleted.DeletedAt)
+
+	var restored productJSON
+	r.runJSON(&restored, "restore", created.ID)
+	assert.Empty(t, restored.DeletedAt)
+}
+
+func TestProductCommands_List(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	r := newCommandRunner(t)
+	for _, args := range [][]string{
+		{"--name", "Cup", "--qty", "1", "--price", "2.50"},
+		{"--name", "Bowl", "--qty", "5", "--price", "4"},
+		{"--name", "Plate", "--qty", "9", "--price", "6"},
+	} {
+		_, err := r.run(append([]string{"create"}, args...)...)
+		require.NoError(t, err)
+	}
+
+	names := func(products []productJSON) []string {
+		var out []string
+		for _, p := range products {
+			out = append(out, p.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"sorted by name", []string{"--sort", "name", "--order", "asc"}, []string{"Bowl", "Cup", "Plate"}},
+		{"price range in decimal", []string{"--sort", "price", "--order", "asc", "--min-price", "3", "--max-price", "6"}, []string{"Bowl", "Plate"}},
+		{"quantity range", []string{"--sort", "name", "--order", "asc", "--max-qty", "5"}, []string{"Bowl", "Cup"}},
+		{"all pages", []string{"--sort", "name", "--order", "desc", "--limit", "1", "--all"}, []string{"Plate", "Cup", "Bowl"}},
+		{"name prefix", []string{"--name-prefix", "pl"}, []string{"Plate"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Act
+			var products []productJSON
+			r.runJSON(&products, append([]string{"list"}, tc.args...)...)
+
+			// Assert
+			assert.Equal(t, tc.want, names(products))
+		})
+	}
+}
+
+func TestProductCommands_OutputFormats(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	r := newCommandRunner(t)
+	_, err := r.run("create", "--name", "Lamp", "--qty", "2", "--price", "19.99", "--currency", "EUR")
+	require.NoError(t, err)
+
+	// Act
+	table, err := r.run("list")
+	require.NoError(t, err)
+	yamlOut, err := r.run("list", "-o", "yaml")
+	require.NoError(t, err)
+	more, err := r.run("list", "--limit", "0")
+	require.NoError(t, err)
+
+	// Assert
+	assert.Contains(t, table, "NAME")
+	assert.Contains(t, table, "Lamp")
+	assert.Contains(t, table, "19.99 EUR")
+
+	var products []productJSON
+	require.NoError(t, yaml.Unmarshal([]byte(yamlOut), &products), yamlOut)
+	require.Len(t, products, 1)
+	assert.Equal(t, "19.99", products[0].Price)
+	assert.Equal(t, "EUR", products[0].Currency)
+
+	assert.NotContains(t, more, "more products", "limit 0 falls back to the default page size")
+}
+
+func TestProductCommands_Import(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Row    int    `json:"row"`
+		SKU    string `json:"sku"`
+		Result string `json:"result"`
+		Error  string `json:"error"`
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "csv",
+			file:    "products.csv",
+			content: "sku,name,qty,price,currency\nA-1,Apple,3,1.20,USD\nB-2,,1,2.00,USD\nA-1,Green apple,4,1.25,\n",
+			want:    []string{"created", "failed", "updated"},
+		},
+		{
+			name:    "json",
+			file:    "products.json",
+			content: `[{"sku":"A-1","name":"Apple","qty":3,"price":"1.20"},{"sku":"B-2","name":"","price":2},{"sku":"A-1","name":"Green apple","qty":4,"price":1.25}]`,
+			want:    []string{"created", "failed", "updated"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			r := newCommandRunner(t)
+			path := filepath.Join(t.TempDir(), tc.file)
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			// Act
+			out, err := r.run("import", path, "-o", "json")
+
+			// Assert
+			require.ErrorContains(t, err, "1 of 3 rows failed")
+			var results []result
+			require.NoError(t, json.Unmarshal([]byte(out), &results), out)
+			var got []string
+			for _, res := range results {
+				got = append(got, res.Result)
+			}
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, 2, results[1].Row)
+			assert.Contains(t, results[1].Error, "name")
+
+			var products []productJSON
+			r.runJSON(&products, "list")
+			require.Len(t, products, 1, "the second A-1 row replaces the first")
+			assert.Equal(t, "Green apple", products[0].Name)
+			assert.Equal(t, "1.25", products[0].Price)
+		})
+	}
+}
+
+func TestProductCommands_Help(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	r := newCommandRunner(t)
+
+	// Act
+	out, err := r.run("help")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, cli.ProductUsage+"\n", out)
+}
+
+func TestProductCommands_UsageErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"no command", nil},
+		{"unknown command", []string{"rename"}},
+		{"unknown flag", []string{"create", "--colour", "red"}},
+		{"bad output format", []string{"list", "-o", "xml"}},
+		{"missing id", []string{"get"}},
+		{"invalid id", []string{"delete", "42"}},
+		{"extra argument", []string{"list", "extra"}},
+		{"nothing to update", []string{"update", "8be42499-6d50-4100-97a0-a8e6471e6e54"}},
+		{"bad price bound", []string{"list", "--min-price", "cheap"}},
+		{"bad import format", []string{"import", "-", "--format", "xml"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			r := newCommandRunner(t)
+
+			// Act
+			_, err := r.run(tc.args...)
+
+			// Assert
+			assert.ErrorIs(t, err, cli.ErrUsage)
+		})
+	}
+}
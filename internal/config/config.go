@@ -0,0 +1,505 @@
+// Package config loads the application configuration into a typed struct.
+//
+// Values are resolved in increasing order of precedence:
+//  1. built-in defaults
+//  2. an optional YAML file
+//  3. an optional .env file (never overrides variables already set in the process)
+//  4. process environment variables
+//
+// Every field is validated after loading and all problems are reported at once,
+// so a misconfigured deployment fails fast with a single, complete error.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidConfig is wrapped by the aggregated error returned by Load and Validate.
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Config is the root configuration of the application.
+type Config struct {
+	Service ServiceConfig `yaml:"service"`
+	HTTP    HTTPConfig    `yaml:"http"`
+	GRPC    GRPCConfig    `yaml:"grpc"`
+	GraphQL GraphQLConfig `yaml:"graphql"`
+	DB      DBConfig      `yaml:"db"`
+	Redis   RedisConfig   `yaml:"redis"`
+	Cache   CacheConfig   `yaml:"cache"`
+
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	Outbox      OutboxConfig      `yaml:"outbox"`
+	Webhook     WebhookConfig     `yaml:"webhook"`
+
+	// Storage is postgres, or memory to keep data in process memory and run without
+	// a database; the DB settings are then ignored.
+	Storage string `yaml:"storage" env:"STORAGE"`
+}
+
+// ServiceConfig identifies the running service.
+type ServiceConfig struct {
+	Name string `yaml:"name" env:"SERVICE_NAME"`
+
+	// Env also selects the log format: text for dev/local/test, JSON otherwise.
+	Env string `yaml:"env" env:"SERVICE_ENV"`
+
+	// LogLevel is one of debug, info, warn or error.
+	LogLevel string `yaml:"logLevel" env:"LOG_LEVEL"`
+}
+
+// Level returns LogLevel as a slog.Level, falling back to info when it cannot be parsed.
+func (c ServiceConfig) Level() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.LogLevel)); err != nil {
+		return slog.LevelInfo
+	}
+
+	return level
+}
+
+// HTTPConfig configures the HTTP server.
+type HTTPConfig struct {
+	Host string `yaml:"host" env:"HOST"`
+	Port int    `yaml:"port" env:"PORT"`
+
+	// ShutdownTimeout bounds how long in-flight requests may drain on SIGINT/SIGTERM.
+	// It should stay below the orchestrator's grace period (30s by default in Kubernetes).
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env:"HTTP_SHUTDOWN_TIMEOUT"`
+
+	// ShutdownDelay keeps serving (while /readyz reports not ready) before the listener closes,
+	// so the load balancer can deregister the instance first.
+	ShutdownDelay time.Duration `yaml:"shutdownDelay" env:"HTTP_SHUTDOWN_DELAY"`
+
+	// HealthCheckTimeout bounds each dependency check run by /readyz.
+	HealthCheckTimeout time.Duration `yaml:"healthCheckTimeout" env:"HEALTH_CHECK_TIMEOUT"`
+
+	// ErrorFormat is the default shape of error responses: "legacy" (APIResponse) or
+	// "problem" (RFC 7807). Clients can always opt into problem details via the Accept header.
+	ErrorFormat string `yaml:"errorFormat" env:"HTTP_ERROR_FORMAT"`
+
+	// PriceFormat is how product prices are written in responses: "string" (exact decimal
+	// string) or "float" (JSON number, for clients of the former float price field).
+	PriceFormat string `yaml:"priceFormat" env:"HTTP_PRICE_FORMAT"`
+}
+
+// Addr returns the host:port address the HTTP server listens on.
+func (c HTTPConfig) Addr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+// GRPCConfig configures the gRPC server, which runs next to the HTTP server on the same host.
+type GRPCConfig struct {
+	// Port is where the gRPC server listens; 0 disables it.
+	Port int `yaml:"port" env:"GRPC_PORT"`
+}
+
+// GRPCAddr returns the host:port address the gRPC server listens on.
+func (c *Config) GRPCAddr() string {
+	return net.JoinHostPort(c.HTTP.Host, strconv.Itoa(c.GRPC.Port))
+}
+
+// GraphQLConfig bounds the queries accepted by the /graphql endpoint, so a single
+// request cannot make it load an unbounded number of products.
+type GraphQLConfig struct {
+	// MaxDepth is the deepest nesting of fields a query may select.
+	MaxDepth int `yaml:"maxDepth" env:"GRAPHQL_MAX_DEPTH"`
+
+	// MaxComplexity bounds the estimated number of fields a query resolves, where the
+	// fields below a connection count once per requested item.
+	MaxComplexity int `yaml:"maxComplexity" env:"GRAPHQL_MAX_COMPLEXITY"`
+}
+
+// DBConfig configures the PostgreSQL connection and its pool.
+// Durations accept Go syntax ("30s", "5m") or a plain integer number of seconds.
+type DBConfig struct {
+	Driver   string `yaml:"driver"   env:"DB_DRIVER"`
+	Host     string `yaml:"host"     env:"DB_HOST"`
+	Port     int    `yaml:"port"     env:"DB_PORT"`
+	Username string `yaml:"username" env:"DB_USERNAME"`
+	Password string `yaml:"password" env:"DB_PASSWORD"`
+	Database string `yaml:"database" env:"DB_DATABASE"`
+	SSLMode  string `yaml:"sslMode"  env:"DB_SSL_MODE"`
+	TimeZone string `yaml:"timeZone" env:"DB_TIMEZONE"`
+
+	MaxOpenConnections    int           `yaml:"maxOpenConnections"    env:"DB_MAX_OPEN_CONNECTIONS"`
+	MaxIdleConnections    int           `yaml:"maxIdleConnections"    env:"DB_MAX_IDLE_CONNECTIONS"`
+	MaxConnectionIdleTime time.Duration `yaml:"maxConnectionIdleTime" env:"DB_MAX_CONNECTION_IDLE_TIME"`
+	MaxConnectionLifetime time.Duration `yaml:"maxConnectionLifetime" env:"DB_MAX_CONNECTION_LIFETIME"`
+}
+
+// RedisConfig configures the Redis client. Redis is optional: an empty host disables it.
+type RedisConfig struct {
+	Host     string `yaml:"host"     env:"REDIS_HOST"`
+	Port     int    `yaml:"port"     env:"REDIS_PORT"`
+	Database int    `yaml:"database" env:"REDIS_DATABASE"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD"`
+}
+
+// CacheConfig configures the cache in front of product reads.
+type CacheConfig struct {
+	// Store is "none" (disabled), "redis" (shared by every instance, needs REDIS_HOST)
+	// or "memory" (an LRU per instance; writes on other instances are only seen after TTL).
+	Store string `yaml:"store" env:"CACHE_STORE"`
+
+	// TTL bounds how long a cached product is served.
+	TTL time.Duration `yaml:"ttl" env:"CACHE_TTL"`
+
+	// Size is the number of products kept by the memory store.
+	Size int `yaml:"size" env:"CACHE_SIZE"`
+}
+
+// IdempotencyConfig configures how requests sent with an Idempotency-Key header are remembered.
+type IdempotencyConfig struct {
+	// Store is "postgres" (shared by every instance) or "memory" (single instance only).
+	Store string `yaml:"store" env:"IDEMPOTENCY_STORE"`
+
+	// TTL is how long a completed response is replayed to retries.
+	TTL time.Duration `yaml:"ttl" env:"IDEMPOTENCY_TTL"`
+
+	// LockTTL is how long a request in progress blocks its duplicates; it must exceed the slowest request.
+	LockTTL time.Duration `yaml:"lockTTL" env:"IDEMPOTENCY_LOCK_TTL"`
+}
+
+// OutboxConfig tunes the relay publishing domain events from the outbox table.
+type OutboxConfig struct {
+	// PollInterval is how often the relay looks for new events once the outbox is drained.
+	PollInterval time.Duration `yaml:"pollInterval" env:"OUTBOX_POLL_INTERVAL"`
+
+	// BatchSize bounds how many events are published per transaction.
+	BatchSize int `yaml:"batchSize" env:"OUTBOX_BATCH_SIZE"`
+
+	// MaxBackoff caps the delay between retries of an event that keeps failing.
+	MaxBackoff time.Duration `yaml:"maxBackoff" env:"OUTBOX_MAX_BACKOFF"`
+
+	// Retention is how long published events are kept before the relay purges them.
+	// Instances read broadcast events back from the outbox, so it is at least
+	// MinOutboxRetention.
+	Retention time.Duration `yaml:"retention" env:"OUTBOX_RETENTION"`
+}
+
+// MinOutboxRetention is the shortest accepted OUTBOX_RETENTION. Listeners of the event
+// broadcast read an event back right after it is sent; an hour leaves ample room for
+// listeners that lag behind.
+const MinOutboxRetention = time.Hour
+
+// WebhookConfig tunes the dispatcher sending webhook deliveries to partner endpoints.
+type WebhookConfig struct {
+	// DispatchInterval is how often the dispatcher looks for due deliveries once none is left.
+	DispatchInterval time.Duration `yaml:"dispatchInterval" env:"WEBHOOK_DISPATCH_INTERVAL"`
+
+	// BatchSize bounds how many deliveries are claimed and sent at once.
+	BatchSize int `yaml:"batchSize" env:"WEBHOOK_BATCH_SIZE"`
+
+	// Timeout bounds a single delivery attempt.
+	Timeout time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT"`
+
+	// MaxAttempts is the number of attempts after which a failing delivery is dead-lettered.
+	MaxAttempts int `yaml:"maxAttempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+
+	// MaxBackoff caps the delay between attempts of a failing delivery.
+	MaxBackoff time.Duration `yaml:"maxBackoff" env:"WEBHOOK_MAX_BACKOFF"`
+
+	// AllowPrivateNetworks lets deliveries reach loopback, private and link-local
+	// addresses. Keep it off in production: subscriptions are created through the API.
+	AllowPrivateNetworks bool `yaml:"allowPrivateNetworks" env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS"`
+}
+
+// Sources lists the optional files configuration is read from. Empty paths are skipped.
+type Sources struct {
+	// YAMLFile is read when set; since it is opted into explicitly, it must exist.
+	YAMLFile string
+
+	// EnvFile is loaded when it exists; a missing .env file is not an error
+	// because containers usually get their variables from the orchestrator.
+	EnvFile string
+
+	// Storage overrides STORAGE when set, e.g. from the --storage flag.
+	Storage string
+}
+
+// Default returns the configuration used when nothing else is provided.
+func Default() Config {
+	return Config{
+		Service: ServiceConfig{
+			Name:     "clean_arch",
+			Env:      "dev",
+			LogLevel: "info",
+		},
+		HTTP: HTTPConfig{
+			Host:               "0.0.0.0",
+			Port:               9420,
+			ShutdownTimeout:    15 * time.Second,
+			HealthCheckTimeout: 2 * time.Second,
+			ErrorFormat:        "legacy",
+			PriceFormat:        "string",
+		},
+		GRPC: GRPCConfig{
+			Port: 9421,
+		},
+		GraphQL: GraphQLConfig{
+			MaxDepth:      8,
+			MaxComplexity: 2000,
+		},
+		DB: DBConfig{
+			Driver:                "postgres",
+			Port:                  5432,
+			SSLMode:               "disable",
+			TimeZone:              "UTC",
+			MaxOpenConnections:    10,
+			MaxIdleConnections:    5,
+			MaxConnectionIdleTime: 5 * time.Minute,
+			MaxConnectionLifetime: time.Hour,
+		},
+		Redis: RedisConfig{
+			Port: 6379,
+		},
+		Cache: CacheConfig{
+			Store: "none",
+			TTL:   5 * time.Minute,
+			Size:  10000,
+		},
+		Idempotency: IdempotencyConfig{
+			Store:   "postgres",
+			TTL:     24 * time.Hour,
+			LockTTL: time.Minute,
+		},
+		Outbox: OutboxConfig{
+			PollInterval: time.Second,
+			BatchSize:    100,
+			MaxBackoff:   5 * time.Minute,
+			Retention:    7 * 24 * time.Hour,
+		},
+		Webhook: WebhookConfig{
+			DispatchInterval: time.Second,
+			BatchSize:        20,
+			Timeout:          10 * time.Second,
+			MaxAttempts:      10,
+			MaxBackoff:       30 * time.Minute,
+		},
+		Storage: "postgres",
+	}
+}
+
+// Load builds and validates the configuration from defaults, the given files and the environment.
+func Load(src Sources) (*Config, error) {
+	return load(src, os.LookupEnv)
+}
+
+func load(src Sources, lookup func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+
+	if src.YAMLFile != "" {
+		if err := loadYAML(src.YAMLFile, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if src.EnvFile != "" {
+		// godotenv.Load never overrides variables that are already set,
+		// which keeps the real environment in charge.
+		if err := godotenv.Load(src.EnvFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("load env file %q: %w", src.EnvFile, err)
+		}
+	}
+
+	if err := bindEnv(&cfg, lookup); err != nil {
+		return nil, err
+	}
+	if src.Storage != "" {
+		cfg.Storage = src.Storage
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func loadYAML(path string, cfg *Config) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config file %q: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(raw, cfg); err != nil {
+		return fmt.Errorf("parse config file %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// Validate checks every field and returns all violations joined into one error.
+func (c *Config) Validate() error {
+	var errs []error
+	add := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if c.Service.Name == "" {
+		add("SERVICE_NAME is required")
+	}
+	if c.Service.Env == "" {
+		add("SERVICE_ENV is required")
+	}
+	if err := new(slog.Level).UnmarshalText([]byte(c.Service.LogLevel)); err != nil {
+		add("LOG_LEVEL must be one of [debug info warn error], got %q", c.Service.LogLevel)
+	}
+
+	if c.HTTP.Host == "" {
+		add("HOST is required")
+	}
+	if !validPort(c.HTTP.Port) {
+		add("PORT must be between 1 and 65535, got %d", c.HTTP.Port)
+	}
+	if c.HTTP.ShutdownTimeout <= 0 {
+		add("HTTP_SHUTDOWN_TIMEOUT must be positive, got %s", c.HTTP.ShutdownTimeout)
+	}
+	if c.HTTP.ShutdownDelay < 0 {
+		add("HTTP_SHUTDOWN_DELAY must not be negative, got %s", c.HTTP.ShutdownDelay)
+	}
+	if c.HTTP.HealthCheckTimeout <= 0 {
+		add("HEALTH_CHECK_TIMEOUT must be positive, got %s", c.HTTP.HealthCheckTimeout)
+	}
+	if c.HTTP.ErrorFormat != "legacy" && c.HTTP.ErrorFormat != "problem" {
+		add("HTTP_ERROR_FORMAT must be one of [legacy problem], got %q", c.HTTP.ErrorFormat)
+	}
+	if c.HTTP.PriceFormat != "string" && c.HTTP.PriceFormat != "float" {
+		add("HTTP_PRICE_FORMAT must be one of [string float], got %q", c.HTTP.PriceFormat)
+	}
+	if c.GRPC.Port != 0 && !validPort(c.GRPC.Port) {
+		add("GRPC_PORT must be between 0 and 65535, got %d", c.GRPC.Port)
+	}
+	if c.GRPC.Port != 0 && c.GRPC.Port == c.HTTP.Port {
+		add("GRPC_PORT must differ from PORT, both are %d", c.GRPC.Port)
+	}
+	if c.GraphQL.MaxDepth < 1 {
+		add("GRAPHQL_MAX_DEPTH must be at least 1, got %d", c.GraphQL.MaxDepth)
+	}
+	if c.GraphQL.MaxComplexity < 1 {
+		add("GRAPHQL_MAX_COMPLEXITY must be at least 1, got %d", c.GraphQL.MaxComplexity)
+	}
+
+	switch c.Storage {
+	case "memory":
+	case "postgres":
+		if c.DB.Driver != "postgres" {
+			add("DB_DRIVER must be %q, got %q", "postgres", c.DB.Driver)
+		}
+		if c.DB.Host == "" {
+			add("DB_HOST is required")
+		}
+		if !validPort(c.DB.Port) {
+			add("DB_PORT must be between 1 and 65535, got %d", c.DB.Port)
+		}
+		if c.DB.Username == "" {
+			add("DB_USERNAME is required")
+		}
+		if c.DB.Database == "" {
+			add("DB_DATABASE is required")
+		}
+		if c.DB.SSLMode != "disable" && c.DB.SSLMode != "verify-full" {
+			add("DB_SSL_MODE must be one of [disable verify-full], got %q", c.DB.SSLMode)
+		}
+		if c.DB.TimeZone != "" {
+			if _, err := time.LoadLocation(c.DB.TimeZone); err != nil {
+				add("DB_TIMEZONE %q is not a valid time zone", c.DB.TimeZone)
+			}
+		}
+		if c.DB.MaxOpenConnections < 1 {
+			add("DB_MAX_OPEN_CONNECTIONS must be at least 1, got %d", c.DB.MaxOpenConnections)
+		}
+		if c.DB.MaxIdleConnections < 0 || c.DB.MaxIdleConnections > c.DB.MaxOpenConnections {
+			add("DB_MAX_IDLE_CONNECTIONS must be between 0 and DB_MAX_OPEN_CONNECTIONS (%d), got %d",
+				c.DB.MaxOpenConnections, c.DB.MaxIdleConnections)
+		}
+		if c.DB.MaxConnectionIdleTime < 0 {
+			add("DB_MAX_CONNECTION_IDLE_TIME must not be negative")
+		}
+		if c.DB.MaxConnectionLifetime < 0 {
+			add("DB_MAX_CONNECTION_LIFETIME must not be negative")
+		}
+	default:
+		add("STORAGE must be one of [postgres memory], got %q", c.Storage)
+	}
+
+	if c.Redis.Host != "" && !validPort(c.Redis.Port) {
+		add("REDIS_PORT must be between 1 and 65535, got %d", c.Redis.Port)
+	}
+	if c.Redis.Database < 0 {
+		add("REDIS_DATABASE must not be negative, got %d", c.Redis.Database)
+	}
+
+	switch c.Cache.Store {
+	case "none", "memory":
+	case "redis":
+		if c.Redis.Host == "" {
+			add("CACHE_STORE redis requires REDIS_HOST")
+		}
+	default:
+		add("CACHE_STORE must be one of [none redis memory], got %q", c.Cache.Store)
+	}
+	if c.Cache.TTL <= 0 {
+		add("CACHE_TTL must be positive, got %s", c.Cache.TTL)
+	}
+	if c.Cache.Size < 1 {
+		add("CACHE_SIZE must be at least 1, got %d", c.Cache.Size)
+	}
+
+	if c.Idempotency.Store != "postgres" && c.Idempotency.Store != "memory" {
+		add("IDEMPOTENCY_STORE must be one of [postgres memory], got %q", c.Idempotency.Store)
+	}
+	if c.Idempotency.TTL <= 0 {
+		add("IDEMPOTENCY_TTL must be positive, got %s", c.Idempotency.TTL)
+	}
+	if c.Idempotency.LockTTL <= 0 {
+		add("IDEMPOTENCY_LOCK_TTL must be positive, got %s", c.Idempotency.LockTTL)
+	}
+
+	if c.Outbox.PollInterval <= 0 {
+		add("OUTBOX_POLL_INTERVAL must be positive, got %s", c.Outbox.PollInterval)
+	}
+	if c.Outbox.BatchSize < 1 {
+		add("OUTBOX_BATCH_SIZE must be at least 1, got %d", c.Outbox.BatchSize)
+	}
+	if c.Outbox.MaxBackoff <= 0 {
+		add("OUTBOX_MAX_BACKOFF must be positive, got %s", c.Outbox.MaxBackoff)
+	}
+	if c.Outbox.Retention < MinOutboxRetention {
+		add("OUTBOX_RETENTION must be at least %s, got %s", MinOutboxRetention, c.Outbox.Retention)
+	}
+	if c.Webhook.DispatchInterval <= 0 {
+		add("WEBHOOK_DISPATCH_INTERVAL must be positive, got %s", c.Webhook.DispatchInterval)
+	}
+	if c.Webhook.BatchSize < 1 {
+		add("WEBHOOK_BATCH_SIZE must be at least 1, got %d", c.Webhook.BatchSize)
+	}
+	if c.Webhook.Timeout <= 0 {
+		add("WEBHOOK_TIMEOUT must be positive, got %s", c.Webhook.Timeout)
+	}
+	if c.Webhook.MaxAttempts < 1 {
+		add("WEBHOOK_MAX_ATTEMPTS must be at least 1, got %d", c.Webhook.MaxAttempts)
+	}
+	if c.Webhook.MaxBackoff <= 0 {
+		add("WEBHOOK_MAX_BACKOFF must be positive, got %s", c.Webhook.MaxBackoff)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
@@ -0,0 +1,247 @@
+package config_test
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// envKeys lists every variable the config package reads, so each test starts
+// from a clean environment regardless of the machine it runs on.
+var envKeys = []string{
+	"SERVICE_NAME", "SERVICE_ENV", "LOG_LEVEL", "HOST", "PORT", "HTTP_SHUTDOWN_TIMEOUT", "HTTP_SHUTDOWN_DELAY", "HEALTH_CHECK_TIMEOUT", "HTTP_ERROR_FORMAT", "HTTP_PRICE_FORMAT",
+	"GRPC_PORT", "GRAPHQL_MAX_DEPTH", "GRAPHQL_MAX_COMPLEXITY",
+	"DB_DRIVER", "DB_HOST", "DB_PORT", "DB_USERNAME", "DB_PASSWORD", "DB_DATABASE",
+	"DB_SSL_MODE", "DB_TIMEZONE", "DB_MAX_OPEN_CONNECTIONS", "DB_MAX_IDLE_CONNECTIONS",
+	"DB_MAX_CONNECTION_IDLE_TIME", "DB_MAX_CONNECTION_LIFETIME",
+	"REDIS_HOST", "REDIS_PORT", "REDIS_DATABASE", "REDIS_PASSWORD",
+	"CACHE_STORE", "CACHE_TTL", "CACHE_SIZE",
+	"IDEMPOTENCY_STORE", "IDEMPOTENCY_TTL", "IDEMPOTENCY_LOCK_TTL",
+	"OUTBOX_POLL_INTERVAL", "OUTBOX_BATCH_SIZE", "OUTBOX_MAX_BACKOFF", "OUTBOX_RETENTION",
+	"WEBHOOK_DISPATCH_INTERVAL", "WEBHOOK_BATCH_SIZE", "WEBHOOK_TIMEOUT", "WEBHOOK_MAX_ATTEMPTS", "WEBHOOK_MAX_BACKOFF",
+	"WEBHOOK_ALLOW_PRIVATE_NETWORKS",
+	"STORAGE",
+}
+
+// clearEnv unsets all config variables for the duration of the test.
+// t.Setenv records the original value so it is restored on cleanup.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range envKeys {
+		t.Setenv(key, "")
+		require.NoError(t, os.Unsetenv(key))
+	}
+}
+
+// setRequiredEnv sets the variables that have no default.
+func setRequiredEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_USERNAME", "app")
+	t.Setenv("DB_DATABASE", "catalog")
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// Tests in this file mutate process environment variables, so they cannot run in parallel.
+
+func TestLoad_FromEnv(t *testing.T) {
+	clearEnv(t)
+	setRequiredEnv(t)
+	t.Setenv("HOST", "127.0.0.1")
+	t.Setenv("PORT", "8080")
+	t.Setenv("DB_SSL_MODE", "verify-full")
+	t.Setenv("DB_MAX_OPEN_CONNECTIONS", "20")
+	t.Setenv("DB_MAX_IDLE_CONNECTIONS", "4")
+	t.Setenv("DB_MAX_CONNECTION_IDLE_TIME", "500")
+	t.Setenv("DB_MAX_CONNECTION_LIFETIME", "30m")
+	t.Setenv("HTTP_SHUTDOWN_TIMEOUT", "20s")
+	t.Setenv("LOG_LEVEL", "debug")
+
+	cfg, err := config.Load(config.Sources{})
+
+	require.NoError(t, err)
+	assert.Equal(t, slog.LevelDebug, cfg.Service.Level())
+	assert.Equal(t, "127.0.0.1:8080", cfg.HTTP.Addr())
+	assert.Equal(t, "verify-full", cfg.DB.SSLMode)
+	assert.Equal(t, 20, cfg.DB.MaxOpenConnections)
+	assert.Equal(t, 4, cfg.DB.MaxIdleConnections)
+	assert.Equal(t, 500*time.Second, cfg.DB.MaxConnectionIdleTime)
+	assert.Equal(t, 30*time.Minute, cfg.DB.MaxConnectionLifetime)
+	assert.Equal(t, 20*time.Second, cfg.HTTP.ShutdownTimeout)
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	clearEnv(t)
+	setRequiredEnv(t)
+
+	cfg, err := config.Load(config.Sources{})
+
+	require.NoError(t, err)
+	def := config.Default()
+	assert.Equal(t, def.HTTP, cfg.HTTP)
+	assert.Equal(t, def.DB.Port, cfg.DB.Port)
+	assert.Equal(t, def.DB.MaxOpenConnections, cfg.DB.MaxOpenConnections)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	clearEnv(t)
+
+	yamlFile := writeFile(t, "config.yaml", `
+http:
+  port: 7000
+db:
+  host: yaml-host
+  username: yaml-user
+  database: yaml-db
+  maxOpenConnections: 30
+`)
+	envFile := writeFile(t, ".env", "DB_HOST=dotenv-host\nDB_DATABASE=dotenv-db\n")
+	t.Setenv("DB_DATABASE", "env-db")
+
+	cfg, err := config.Load(config.Sources{YAMLFile: yamlFile, EnvFile: envFile})
+
+	require.NoError(t, err)
+	assert.Equal(t, 7000, cfg.HTTP.Port, "yaml overrides defaults")
+	assert.Equal(t, 30, cfg.DB.MaxOpenConnections, "yaml overrides defaults")
+	assert.Equal(t, "yaml-user", cfg.DB.Username, "yaml value kept when no env is set")
+	assert.Equal(t, "dotenv-host", cfg.DB.Host, ".env overrides yaml")
+	assert.Equal(t, "env-db", cfg.DB.Database, "process env wins over .env")
+}
+
+func TestLoad_MemoryStorageNeedsNoDatabase(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("STORAGE", "postgres")
+
+	_, err := config.Load(config.Sources{})
+	require.ErrorIs(t, err, config.ErrInvalidConfig, "postgres requires the DB settings")
+
+	// The flag wins over STORAGE.
+	cfg, err := config.Load(config.Sources{Storage: "memory"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "memory", cfg.Storage)
+}
+
+func TestLoad_MissingOptionalEnvFile(t *testing.T) {
+	clearEnv(t)
+	setRequiredEnv(t)
+
+	_, err := config.Load(config.Sources{EnvFile: filepath.Join(t.TempDir(), ".env")})
+
+	assert.NoError(t, err)
+}
+
+func TestLoad_MissingExplicitYAMLFile(t *testing.T) {
+	clearEnv(t)
+	setRequiredEnv(t)
+
+	_, err := config.Load(config.Sources{YAMLFile: filepath.Join(t.TempDir(), "missing.yaml")})
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLoad_AggregatesParseErrors(t *testing.T) {
+	clearEnv(t)
+	setRequiredEnv(t)
+	t.Setenv("PORT", "http")
+	t.Setenv("DB_MAX_CONNECTION_IDLE_TIME", "forever")
+
+	_, err := config.Load(config.Sources{})
+
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "PORT")
+	assert.Contains(t, err.Error(), "DB_MAX_CONNECTION_IDLE_TIME")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.HTTP.Port = 0
+	cfg.DB.SSLMode = "prefer"
+	cfg.DB.MaxOpenConnections = 2
+	cfg.DB.MaxIdleConnections = 5
+	cfg.DB.TimeZone = "Mars/Olympus_Mons"
+	cfg.Service.LogLevel = "verbose"
+	cfg.HTTP.ErrorFormat = "xml"
+	cfg.HTTP.PriceFormat = "double"
+	cfg.Idempotency.Store = "redis"
+	cfg.Idempotency.LockTTL = 0
+	cfg.Outbox.BatchSize = 0
+	cfg.Outbox.Retention = time.Minute
+	cfg.Webhook.MaxAttempts = 0
+	cfg.Cache.Store = "redis"
+	cfg.GraphQL.MaxDepth = 0
+	cfg.GraphQL.MaxComplexity = -1
+
+	err := cfg.Validate()
+
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+	// Every violation is reported at once, not just the first one.
+	for _, field := range []string{
+		"PORT", "DB_HOST", "DB_USERNAME", "DB_DATABASE",
+		"DB_SSL_MODE", "DB_MAX_IDLE_CONNECTIONS", "DB_TIMEZONE", "LOG_LEVEL", "HTTP_ERROR_FORMAT", "HTTP_PRICE_FORMAT",
+		"IDEMPOTENCY_STORE", "IDEMPOTENCY_LOCK_TTL", "OUTBOX_BATCH_SIZE", "OUTBOX_RETENTION",
+		"WEBHOOK_MAX_ATTEMPTS", "CACHE_STORE", "GRAPHQL_MAX_DEPTH", "GRAPHQL_MAX_COMPLEXITY",
+	} {
+		assert.Contains(t, err.Error(), field)
+	}
+}
+
+func TestConfig_ValidateStorage(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Default()
+	cfg.Storage = "sqlite"
+
+	err := cfg.Validate()
+
+	require.ErrorIs(t, err, config.ErrInvalidConfig)
+	assert.Contains(t, err.Error(), "STORAGE")
+}
+
+func TestConfig_ValidateGRPCPort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		port    int
+		wantErr bool
+	}{
+		{name: "default", port: config.Default().GRPC.Port},
+		{name: "disabled", port: 0},
+		{name: "out of range", port: 70000, wantErr: true},
+		{name: "same as http", port: config.Default().HTTP.Port, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := config.Default()
+			cfg.Storage = "memory"
+			cfg.GRPC.Port = tt.port
+
+			err := cfg.Validate()
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, config.ErrInvalidConfig)
+				assert.Contains(t, err.Error(), "GRPC_PORT")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
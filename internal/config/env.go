@@ -0,0 +1,100 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// bindEnv overrides fields tagged with `env:"NAME"` using values from lookup.
+// Unset variables leave the current value untouched; empty values are treated as unset
+// so a blank line in .env does not wipe a default. All parse errors are aggregated.
+func bindEnv(cfg *Config, lookup func(string) (string, bool)) error {
+	var errs []error
+	bindStruct(reflect.ValueOf(cfg).Elem(), lookup, &errs)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func bindStruct(v reflect.Value, lookup func(string) (string, bool), errs *[]error) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Struct {
+			bindStruct(field, lookup, errs)
+			continue
+		}
+
+		name := t.Field(i).Tag.Get("env")
+		if name == "" {
+			continue
+		}
+
+		raw, ok := lookup(name)
+		raw = strings.TrimSpace(raw)
+		if !ok || raw == "" {
+			continue
+		}
+
+		if err := setField(field, raw); err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+}
+
+func setField(field reflect.Value, raw string) error {
+	if field.Type() == durationType {
+		d, err := parseDuration(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", raw)
+		}
+		field.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", raw)
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field kind %s", field.Kind())
+	}
+
+	return nil
+}
+
+// parseDuration accepts Go duration syntax or a bare integer number of seconds,
+// which is how the pool timeouts in .env.example are expressed.
+func parseDuration(raw string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a duration", raw)
+	}
+
+	return d, nil
+}
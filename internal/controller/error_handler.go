@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// StatusForKind maps an apperror kind to its HTTP status code.
+// This is the only place where domain errors meet HTTP.
+func StatusForKind(kind apperror.Kind) int {
+	switch kind {
+	case apperror.Invalid:
+		return http.StatusBadRequest
+	case apperror.NotFound:
+		return http.StatusNotFound
+	case apperror.Conflict:
+		return http.StatusConflict
+	case apperror.PreconditionFailed:
+		return http.StatusPreconditionFailed
+	case apperror.PreconditionRequired:
+		return http.StatusPreconditionRequired
+	case apperror.Forbidden:
+		return http.StatusForbidden
+	case apperror.Unavailable:
+		return http.StatusServiceUnavailable
+	case apperror.Unprocessable:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ErrorHandler renders the last error a handler attached with ctx.Error,
+// using StatusForKind for the status code and the format chosen by ErrorFormatSelector.
+//
+// Only the client-safe messages carried by apperror values reach the response;
+// details of invalid input are included when hasClientDetail allows it, so clients can
+// fix their request, while server errors are logged with the request context and
+// answered generically.
+func ErrorHandler(logger port.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+
+		err := ctx.Errors.Last().Err
+		kind := apperror.KindOf(err)
+		status := StatusForKind(kind)
+
+		msg := apperror.MessageOf(err)
+		if msg == "" || kind == apperror.Unknown {
+			msg = "internal server error"
+		}
+
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.Error(ctx.Request.Context(), "request failed", "kind", kind.String(), "error", err)
+			JSONErrorResponse(ctx, status, msg, nil)
+		case kind == apperror.Invalid && hasClientDetail(err):
+			JSONErrorResponse(ctx, status, msg, err)
+		case kind == apperror.Invalid:
+			// The cause may come from the database, e.g. a check constraint, and name
+			// parts of the schema: the client only gets the message.
+			logger.Warn(ctx.Request.Context(), "request rejected", "error", err)
+			JSONErrorResponse(ctx, status, msg, nil)
+		default:
+			JSONErrorResponse(ctx, status, msg, nil)
+		}
+	}
+}
+
+// hasClientDetail reports whether the cause of an invalid-input error may be shown to
+// the client: the field violations of entity validation, and the errors of binding or
+// decoding the request, which only describe what the client sent.
+func hasClientDetail(err error) bool {
+	var (
+		validationErr *entity.ValidationError
+		bindingErrs   validator.ValidationErrors
+		typeErr       *json.UnmarshalTypeError
+		syntaxErr     *json.SyntaxError
+		numErr        *strconv.NumError
+	)
+
+	return errors.As(err, &validationErr) || errors.As(err, &bindingErrs) || errors.As(err, &typeErr) ||
+		errors.As(err, &syntaxErr) || errors.As(err, &numErr) || errors.Is(err, errInvalidCursor)
+}
+
+// causeText returns the message of err without the client-safe prefix added by
+// apperror.Wrap, e.g. the validator message behind "invalid request payload".
+func causeText(err error) string {
+	if appErr, ok := err.(*apperror.Error); ok && appErr.Err != nil { //nolint:errorlint // only the outermost wrapper is stripped
+		return appErr.Err.Error()
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,169 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/controller"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorHandler(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	dbErr := errors.New(`pq: duplicate key value violates unique constraint "products_pkey"`)
+	checkErr := &pgconn.PgError{
+		Code: "23514", ConstraintName: "products_qty_check",
+		Message: `new row for relation "products" violates check constraint "products_qty_check"`,
+	}
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedMsg    string
+		expectedError  string
+		expectedLog    string // logged, and kept out of the response
+	}{
+		{
+			name: "invalid exposes the validation failures",
+			err: fmt.Errorf("product validation failed: %w", &entity.ValidationError{
+				Fields: []entity.FieldError{{Field: "qty", Code: entity.CodeNonNegative, Message: "qty must not be negative"}},
+			}),
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    entity.ErrProductInvalid.Error(),
+			expectedError:  "product validation failed: invalid product: qty must not be negative",
+		},
+		{
+			name:           "invalid hides other causes",
+			err:            apperror.Wrap(apperror.Invalid, checkErr, "value violates a database constraint"),
+			expectedStatus: http.StatusBadRequest,
+			expectedMsg:    "value violates a database constraint",
+			expectedError:  "Bad Request",
+			expectedLog:    "products_qty_check",
+		},
+		{
+			name:           "not found",
+			err:            fmt.Errorf("failed to get product: %w", apperror.New(apperror.NotFound, "product not found")),
+			expectedStatus: http.StatusNotFound,
+			expectedMsg:    "product not found",
+			expectedError:  "Not Found",
+		},
+		{
+			name:           "conflict hides the database error",
+			err:            apperror.Wrap(apperror.Conflict, dbErr, "resource already exists"),
+			expectedStatus: http.StatusConflict,
+			expectedMsg:    "resource already exists",
+			expectedError:  "Conflict",
+		},
+		{
+			name:           "precondition failed",
+			err:            apperror.New(apperror.PreconditionFailed, "version mismatch"),
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedMsg:    "version mismatch",
+			expectedError:  "Precondition Failed",
+		},
+		{
+			name:           "precondition required",
+			err:            apperror.New(apperror.PreconditionRequired, "If-Match header is required"),
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedMsg:    "If-Match header is required",
+			expectedError:  "Precondition Required",
+		},
+		{
+			name:           "forbidden",
+			err:            apperror.New(apperror.Forbidden, "not allowed"),
+			expectedStatus: http.StatusForbidden,
+			expectedMsg:    "not allowed",
+			expectedError:  "Forbidden",
+		},
+		{
+			name:           "unprocessable",
+			err:            apperror.New(apperror.Unprocessable, "key reused"),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedMsg:    "key reused",
+			expectedError:  "Unprocessable Entity",
+		},
+		{
+			name:           "unavailable",
+			err:            apperror.Wrap(apperror.Unavailable, dbErr, "database unavailable"),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedMsg:    "database unavailable",
+			expectedError:  "Service Unavailable",
+			expectedLog:    "duplicate key",
+		},
+		{
+			name:           "unknown",
+			err:            dbErr,
+			expectedStatus: http.StatusInternalServerError,
+			expectedMsg:    "internal server error",
+			expectedError:  "Internal Server Error",
+			expectedLog:    "duplicate key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			var logs bytes.Buffer
+			r := gin.New()
+			r.Use(controller.ErrorHandler(logger.New(&logs, logger.Options{Format: logger.FormatJSON})))
+			r.GET("/", func(ctx *gin.Context) {
+				ctx.Error(tt.err)
+			})
+
+			resp := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			// Assert
+			require.Equal(t, tt.expectedStatus, resp.Code)
+
+			var body controller.APIResponse
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedMsg, body.Message)
+			assert.Equal(t, tt.expectedError, body.Error)
+			assert.NotContains(t, resp.Body.String(), "duplicate key")
+
+			if tt.expectedLog != "" {
+				assert.Contains(t, logs.String(), tt.expectedLog)
+				assert.NotContains(t, resp.Body.String(), tt.expectedLog)
+			} else {
+				assert.Empty(t, logs.String())
+			}
+		})
+	}
+}
+
+func TestErrorHandler_KeepsWrittenResponse(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(controller.ErrorHandler(logger.NewNop()))
+	r.GET("/", func(ctx *gin.Context) {
+		ctx.Error(errors.New("already handled"))
+		ctx.JSON(http.StatusAccepted, controller.APIResponse{Message: "accepted"})
+	})
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	assert.Contains(t, resp.Body.String(), "accepted")
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/gin-gonic/gin"
+)
+
+// Conditional request headers (RFC 9110, section 13).
+const (
+	HeaderETag        = "ETag"
+	HeaderIfMatch     = "If-Match"
+	HeaderIfNoneMatch = "If-None-Match"
+)
+
+var (
+	// errIfMatchRequired is returned when a write does not say which version it is based on.
+	errIfMatchRequired = apperror.New(apperror.PreconditionRequired, "If-Match header is required")
+
+	// errInvalidIfMatch is returned when If-Match is not a single entity tag or "*".
+	errInvalidIfMatch = apperror.New(apperror.Invalid, "If-Match must be a single entity tag or \"*\"")
+)
+
+// productETag returns the strong entity tag of a product version, e.g. `"3"`.
+// The version changes on every write, so it identifies the representation.
+func productETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// expectedVersion reads the If-Match header of a write request and returns the
+// product version the client last read, or 0 for "*" (whatever the current version).
+//
+// If-Match uses the strong comparison, so a weak tag or a tag that is not a product
+// version can never match and fails with entity.ErrProductVersionMismatch, like a
+// stale version would.
+func expectedVersion(ctx *gin.Context) (int64, error) {
+	header := strings.TrimSpace(ctx.GetHeader(HeaderIfMatch))
+	switch {
+	case header == "":
+		return 0, errIfMatchRequired
+	case header == "*":
+		return 0, nil
+	case strings.Contains(header, ","):
+		return 0, errInvalidIfMatch
+	}
+
+	weak, opaque, ok := parseETag(header)
+	if !ok {
+		return 0, errInvalidIfMatch
+	}
+
+	version, err := strconv.ParseInt(opaque, 10, 64)
+	if weak || err != nil || version <= 0 {
+		return 0, entity.ErrProductVersionMismatch
+	}
+
+	return version, nil
+}
+
+// notModified reports whether the If-None-Match header of a read request matches
+// etag, in which case the client's cached copy is current. If-None-Match uses the
+// weak comparison: W/"3" matches "3".
+func notModified(ctx *gin.Context, etag string) bool {
+	header := strings.TrimSpace(ctx.GetHeader(HeaderIfNoneMatch))
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+
+	_, want, _ := parseETag(etag)
+	for _, tag := range strings.Split(header, ",") {
+		if _, opaque, ok := parseETag(strings.TrimSpace(tag)); ok && opaque == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseETag splits an entity tag such as W/"3" into its weakness and opaque value.
+func parseETag(tag string) (weak bool, opaque string, ok bool) {
+	if rest, found := strings.CutPrefix(tag, "W/"); found {
+		weak, tag = true, rest
+	}
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return false, "", false
+	}
+
+	opaque = tag[1 : len(tag)-1]
+	if strings.Contains(opaque, `"`) {
+		return false, "", false
+	}
+
+	return weak, opaque, true
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/gin-gonic/gin"
+)
+
+// Health statuses reported by the probe endpoints.
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusReady    = "ready"
+	HealthStatusNotReady = "not_ready"
+	HealthStatusUp       = "up"
+	HealthStatusDown     = "down"
+)
+
+// HealthResponse is the JSON body returned by the probe endpoints.
+type HealthResponse struct {
+	Status string                 `json:"status"`
+	Reason string                 `json:"reason,omitempty"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// CheckResult is the outcome of a single dependency check.
+type CheckResult struct {
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+// HealthController serves the liveness (/healthz) and readiness (/readyz) probes.
+type HealthController struct {
+	timeout      time.Duration
+	mu           sync.RWMutex
+	checkers     []port.HealthChecker
+	shuttingDown atomic.Bool
+}
+
+// NewHealthController creates a HealthController that bounds each dependency check by timeout.
+func NewHealthController(timeout time.Duration, checkers ...port.HealthChecker) *HealthController {
+	return &HealthController{
+		timeout:  timeout,
+		checkers: checkers,
+	}
+}
+
+// Register adds a dependency check to the readiness probe.
+func (hdl *HealthController) Register(checker port.HealthChecker) {
+	hdl.mu.Lock()
+	defer hdl.mu.Unlock()
+
+	hdl.checkers = append(hdl.checkers, checker)
+}
+
+// MarkShuttingDown makes the readiness probe fail from now on, so the orchestrator
+// stops routing traffic to this instance while it drains.
+func (hdl *HealthController) MarkShuttingDown() {
+	hdl.shuttingDown.Store(true)
+}
+
+// Liveness handles GET /healthz requests.
+// It only reports that the process is able to serve HTTP; dependencies are not checked,
+// otherwise a database outage would make the orchestrator restart every instance.
+func (hdl *HealthController) Liveness(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, HealthResponse{Status: HealthStatusOK})
+}
+
+// Readiness handles GET /readyz requests.
+// All dependencies are checked concurrently; the instance is ready only if every check passes.
+func (hdl *HealthController) Readiness(ctx *gin.Context) {
+	if hdl.shuttingDown.Load() {
+		ctx.JSON(http.StatusServiceUnavailable, HealthResponse{
+			Status: HealthStatusNotReady,
+			Reason: "shutting down",
+		})
+		return
+	}
+
+	hdl.mu.RLock()
+	checkers := append([]port.HealthChecker(nil), hdl.checkers...)
+	hdl.mu.RUnlock()
+
+	results := make(map[string]CheckResult, len(checkers))
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, checker := range checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result := hdl.runCheck(ctx.Request.Context(), checker)
+
+			mu.Lock()
+			results[checker.Name()] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	status, code := HealthStatusReady, http.StatusOK
+	for _, result := range results {
+		if result.Status != HealthStatusUp {
+			status, code = HealthStatusNotReady, http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	ctx.JSON(code, HealthResponse{
+		Status: status,
+		Checks: results,
+	})
+}
+
+// runCheck executes a single check bounded by the controller timeout.
+func (hdl *HealthController) runCheck(ctx context.Context, checker port.HealthChecker) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, hdl.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.Check(ctx)
+	result := CheckResult{
+		Status:    HealthStatusUp,
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = HealthStatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,126 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/controller"
+	"github.com/DucTran999/go-clean-archx/test/mockbuilder"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthController_Liveness(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	// Arrange: liveness must not depend on dependencies being up
+	hdl := controller.NewHealthController(time.Second,
+		mockbuilder.NewHealthCheckerBuilder(t, "postgres").Build(),
+	)
+	r := gin.Default()
+	r.GET("/healthz", hdl.Liveness)
+
+	// Act
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestHealthController_Readiness(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		setupUT        func(t *testing.T) *controller.HealthController
+		expectedStatus int
+		expectedBody   string
+		expectedChecks map[string]string
+	}{
+		{
+			name: "all dependencies up",
+			setupUT: func(t *testing.T) *controller.HealthController {
+				t.Helper()
+				return controller.NewHealthController(time.Second,
+					mockbuilder.NewHealthCheckerBuilder(t, "postgres").Healthy().Build(),
+				)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   controller.HealthStatusReady,
+			expectedChecks: map[string]string{"postgres": controller.HealthStatusUp},
+		},
+		{
+			name: "registered dependency down",
+			setupUT: func(t *testing.T) *controller.HealthController {
+				t.Helper()
+				hdl := controller.NewHealthController(time.Second,
+					mockbuilder.NewHealthCheckerBuilder(t, "postgres").Healthy().Build(),
+				)
+				hdl.Register(mockbuilder.NewHealthCheckerBuilder(t, "redis").Unhealthy().Build())
+				return hdl
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   controller.HealthStatusNotReady,
+			expectedChecks: map[string]string{
+				"postgres": controller.HealthStatusUp,
+				"redis":    controller.HealthStatusDown,
+			},
+		},
+		{
+			name: "dependency times out",
+			setupUT: func(t *testing.T) *controller.HealthController {
+				t.Helper()
+				return controller.NewHealthController(50*time.Millisecond,
+					mockbuilder.NewHealthCheckerBuilder(t, "postgres").Hanging().Build(),
+				)
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   controller.HealthStatusNotReady,
+			expectedChecks: map[string]string{"postgres": controller.HealthStatusDown},
+		},
+		{
+			name: "shutting down",
+			setupUT: func(t *testing.T) *controller.HealthController {
+				t.Helper()
+				hdl := controller.NewHealthController(time.Second,
+					mockbuilder.NewHealthCheckerBuilder(t, "postgres").Build(),
+				)
+				hdl.MarkShuttingDown()
+				return hdl
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   controller.HealthStatusNotReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			hdl := tt.setupUT(t)
+			r := gin.Default()
+			r.GET("/readyz", hdl.Readiness)
+
+			// Act
+			resp := httptest.NewRecorder()
+			r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+
+			var body controller.HealthResponse
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedBody, body.Status)
+			for name, status := range tt.expectedChecks {
+				assert.Equal(t, status, body.Checks[name].Status, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/google/uuid"
+)
+
+// errInvalidCursor is returned when a client sends a cursor that was not issued by this API.
+var errInvalidCursor = apperror.New(apperror.Invalid, "invalid cursor")
+
+// cursorToken is the wire format of a product cursor.
+// Clients must treat the encoded value as opaque; field names are kept short
+// because the token travels in query strings.
+type cursorToken struct {
+	SortBy    string    `json:"s"`
+	Order     string    `json:"o"`
+	Name      string    `json:"n,omitempty"`
+	Price     int64     `json:"m,omitempty"` // minor units
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"i"`
+}
+
+// encodeCursor turns a product cursor into an opaque URL-safe token.
+// A nil cursor yields an empty string, meaning there is no next page.
+func encodeCursor(cursor *dto.ProductCursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	raw, err := json.Marshal(cursorToken{
+		SortBy:    string(cursor.SortBy),
+		Order:     string(cursor.Order),
+		Name:      cursor.Name,
+		Price:     cursor.Price,
+		CreatedAt: cursor.CreatedAt,
+		ID:        cursor.ID,
+	})
+	if err != nil {
+		// Marshalling a struct of plain values cannot fail.
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor parses an opaque token produced by encodeCursor.
+// An empty token yields a nil cursor (first page).
+func decodeCursor(token string) (*dto.ProductCursor, error) {
+	if token == "" {
+		return nil, nil //nolint:nilnil // no cursor means "start from the first page"
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var decoded cursorToken
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, errInvalidCursor
+	}
+	if decoded.ID == uuid.Nil || decoded.CreatedAt.IsZero() {
+		return nil, errInvalidCursor
+	}
+
+	return &dto.ProductCursor{
+		SortBy:    dto.ProductSortField(decoded.SortBy),
+		Order:     dto.SortOrder(decoded.Order),
+		Name:      decoded.Name,
+		Price:     decoded.Price,
+		CreatedAt: decoded.CreatedAt,
+		ID:        decoded.ID,
+	}, nil
+}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Binding failures name the field as the client sent it; see wireName.
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(wireName)
+	}
+}
+
+// ErrorFormat selects how error responses are rendered.
+type ErrorFormat string
+
+const (
+	// ErrorFormatLegacy renders errors as APIResponse{message, error}, the original shape.
+	ErrorFormatLegacy ErrorFormat = "legacy"
+
+	// ErrorFormatProblem renders errors as RFC 7807 application/problem+json documents.
+	ErrorFormatProblem ErrorFormat = "problem"
+)
+
+// ContentTypeProblemJSON is the media type of RFC 7807 problem details.
+const ContentTypeProblemJSON = "application/problem+json"
+
+// ProblemTypeValidation identifies problems caused by invalid input; the errors array says which fields.
+const ProblemTypeValidation = "urn:problem-type:validation-error"
+
+// errorFormatKey stores the per-request ErrorFormat in the gin context.
+const errorFormatKey = "controller.error_format"
+
+// ProblemDetails is an RFC 7807 problem document.
+type ProblemDetails struct {
+	Type      string              `json:"type"`                 // URI identifying the problem type; "about:blank" when only the status matters
+	Title     string              `json:"title"`                // Short summary of the problem type
+	Status    int                 `json:"status"`               // HTTP status code
+	Detail    string              `json:"detail,omitempty"`     // Explanation specific to this occurrence
+	Instance  string              `json:"instance,omitempty"`   // Request path that produced the problem
+	RequestID string              `json:"request_id,omitempty"` // Correlation ID of the request
+	Errors    []ProblemFieldError `json:"errors,omitempty"`     // Field-level validation failures
+}
+
+// ProblemFieldError describes why a single input field was rejected.
+type ProblemFieldError struct {
+	Field   string `json:"field"`   // Name of the field as sent by the client, e.g. "price" or "min_qty"
+	Code    string `json:"code"`    // Machine-readable rule, e.g. "required" or "positive"
+	Message string `json:"message"` // Human-readable explanation
+}
+
+// ErrorFormatSelector returns middleware that picks the error format of each request.
+// Clients asking for application/problem+json in Accept always get problem details;
+// everyone else gets defaultFormat, so existing clients keep the APIResponse shape.
+func ErrorFormatSelector(defaultFormat ErrorFormat) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		format := defaultFormat
+		if strings.Contains(ctx.GetHeader("Accept"), ContentTypeProblemJSON) {
+			format = ErrorFormatProblem
+		}
+		ctx.Set(errorFormatKey, format)
+
+		ctx.Next()
+	}
+}
+
+// wantsProblem reports whether errors for this request are rendered as problem details.
+func wantsProblem(ctx *gin.Context) bool {
+	format, _ := ctx.Get(errorFormatKey)
+	return format == ErrorFormatProblem
+}
+
+// JSONProblemResponse sends problem as application/problem+json.
+func JSONProblemResponse(ctx *gin.Context, problem ProblemDetails) {
+	// gin only sets Content-Type when it is missing, so this survives ctx.JSON.
+	ctx.Header("Content-Type", ContentTypeProblemJSON)
+	ctx.JSON(problem.Status, problem)
+}
+
+// newProblem builds the problem document for an error response.
+// Validation failures found in err are listed in Errors; other client errors
+// have their message appended to detail, while server errors never leak it.
+func newProblem(ctx *gin.Context, status int, detail string, err error) ProblemDetails {
+	problem := ProblemDetails{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  ctx.Request.URL.Path,
+		RequestID: requestid.FromContext(ctx.Request.Context()),
+	}
+
+	if err == nil || status >= http.StatusInternalServerError {
+		return problem
+	}
+
+	if fields := problemFieldErrors(err); len(fields) > 0 {
+		problem.Type = ProblemTypeValidation
+		problem.Title = "Validation failed"
+		problem.Errors = fields
+	} else {
+		problem.Detail = detail + ": " + causeText(err)
+	}
+
+	return problem
+}
+
+// problemFieldErrors extracts field-level failures from binding, decoding and domain errors.
+func problemFieldErrors(err error) []ProblemFieldError {
+	var (
+		bindingErrs validator.ValidationErrors
+		typeErr     *json.UnmarshalTypeError
+	)
+
+	switch {
+	case errors.As(err, &bindingErrs):
+		fields := make([]ProblemFieldError, 0, len(bindingErrs))
+		for _, fe := range bindingErrs {
+			name := fe.Field()
+			fields = append(fields, ProblemFieldError{
+				Field:   name,
+				Code:    fe.Tag(),
+				Message: bindingMessage(name, fe),
+			})
+		}
+		return fields
+
+	case errors.As(err, &typeErr):
+		return []ProblemFieldError{{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type),
+		}}
+
+	case errors.Is(err, errInvalidCursor):
+		return []ProblemFieldError{{
+			Field:   "cursor",
+			Code:    "invalid",
+			Message: "cursor was not issued by this API",
+		}}
+	}
+
+	domainErrs := entity.FieldErrors(err)
+	fields := make([]ProblemFieldError, 0, len(domainErrs))
+	for _, fe := range domainErrs {
+		fields = append(fields, ProblemFieldError{Field: fe.Field, Code: fe.Code, Message: fe.Message})
+	}
+
+	return fields
+}
+
+// bindingMessage turns a validator failure into a sentence a client can act on.
+func bindingMessage(field string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return field + " is required"
+	case "min":
+		return fmt.Sprintf("%s must be at least %s", field, fe.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s", field, fe.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", field, fe.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of [%s]", field, fe.Param())
+	default:
+		return fmt.Sprintf("%s failed the %q rule", field, fe.Tag())
+	}
+}
+
+// wireName is the name of a request field on the wire: its json tag in a body,
+// its form tag in a query string, e.g. "eventTypes" or "min_price".
+func wireName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(key), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
@@ -0,0 +1,226 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/controller"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/middleware"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mockbuilder"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newProblemRouter wires every product route behind the request ID and error format middleware.
+func newProblemRouter(ctrl *controller.ProductController, format controller.ErrorFormat) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.RequestID(), controller.ErrorFormatSelector(format), controller.ErrorHandler(logger.NewNop()))
+	r.POST("/products", ctrl.CreateProduct)
+	r.GET("/products", ctrl.ListProducts)
+	r.GET("/products/:id", ctrl.GetProduct)
+	r.PUT("/products/:id", ctrl.UpdateProduct)
+
+	return r
+}
+
+func TestProductController_ProblemDetails(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	productPath := "/products/" + datatest.FakeProductID.String()
+
+	tests := []struct {
+		name           string
+		format         controller.ErrorFormat
+		accept         string
+		ifMatch        string
+		method         string
+		path           string
+		body           string
+		setupUC        func(b *mockbuilder.ProductUsecaseBuilder)
+		expectedStatus int
+		expectedType   string
+		expectedTitle  string
+		expectedDetail string
+		expectedErrors []controller.ProblemFieldError
+	}{
+		{
+			name:           "binding error selected by Accept header",
+			format:         controller.ErrorFormatLegacy,
+			accept:         controller.ContentTypeProblemJSON,
+			method:         http.MethodPost,
+			path:           "/products",
+			body:           `{"qty":1,"price":1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   controller.ProblemTypeValidation,
+			expectedTitle:  "Validation failed",
+			expectedDetail: "invalid request payload",
+			expectedErrors: []controller.ProblemFieldError{
+				{Field: "name", Code: "required", Message: "name is required"},
+			},
+		},
+		{
+			name:           "json type mismatch",
+			format:         controller.ErrorFormatProblem,
+			method:         http.MethodPost,
+			path:           "/products",
+			body:           `{"name":"Laptop","qty":"many","price":1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   controller.ProblemTypeValidation,
+			expectedTitle:  "Validation failed",
+			expectedDetail: "invalid request payload",
+			expectedErrors: []controller.ProblemFieldError{
+				{Field: "qty", Code: "type", Message: "qty must be of type int"},
+			},
+		},
+		{
+			name:           "query binding error",
+			format:         controller.ErrorFormatProblem,
+			method:         http.MethodGet,
+			path:           "/products?limit=500&min_qty=-1",
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   controller.ProblemTypeValidation,
+			expectedTitle:  "Validation failed",
+			expectedDetail: "invalid query parameters",
+			expectedErrors: []controller.ProblemFieldError{
+				{Field: "limit", Code: "max", Message: "limit must be at most 100"},
+				{Field: "min_qty", Code: "gte", Message: "min_qty must be greater than or equal to 0"},
+			},
+		},
+		{
+			name:           "domain validation error",
+			format:         controller.ErrorFormatProblem,
+			ifMatch:        `"1"`,
+			method:         http.MethodPut,
+			path:           productPath,
+			body:           `{"name":"Laptop","qty":1,"price":0}`,
+			setupUC:        func(b *mockbuilder.ProductUsecaseBuilder) { b.UpdateProductReturnsInvalidPrice() },
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   controller.ProblemTypeValidation,
+			expectedTitle:  "Validation failed",
+			expectedDetail: "invalid product",
+			expectedErrors: []controller.ProblemFieldError{
+				{Field: "price", Code: "positive", Message: "price must be greater than zero"},
+			},
+		},
+		{
+			name:           "precondition required",
+			format:         controller.ErrorFormatProblem,
+			method:         http.MethodPut,
+			path:           productPath,
+			body:           `{"name":"Laptop","qty":1,"price":1}`,
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedType:   "about:blank",
+			expectedTitle:  "Precondition Required",
+			expectedDetail: "If-Match header is required",
+		},
+		{
+			name:           "precondition failed",
+			format:         controller.ErrorFormatProblem,
+			ifMatch:        `"1"`,
+			method:         http.MethodPut,
+			path:           productPath,
+			body:           `{"name":"Laptop","qty":1,"price":1}`,
+			setupUC:        func(b *mockbuilder.ProductUsecaseBuilder) { b.UpdateProductReturnsVersionMismatch() },
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedType:   "about:blank",
+			expectedTitle:  "Precondition Failed",
+			expectedDetail: "product has been modified by another request",
+		},
+		{
+			name:           "not found",
+			format:         controller.ErrorFormatProblem,
+			method:         http.MethodGet,
+			path:           productPath,
+			setupUC:        func(b *mockbuilder.ProductUsecaseBuilder) { b.GetByIDNotFound() },
+			expectedStatus: http.StatusNotFound,
+			expectedType:   "about:blank",
+			expectedTitle:  "Not Found",
+			expectedDetail: "product not found",
+		},
+		{
+			name:           "internal error hides cause",
+			format:         controller.ErrorFormatProblem,
+			method:         http.MethodPost,
+			path:           "/products",
+			body:           `{"name":"Laptop","qty":1,"price":1}`,
+			setupUC:        func(b *mockbuilder.ProductUsecaseBuilder) { b.CreateProductReturnErrDB() },
+			expectedStatus: http.StatusInternalServerError,
+			expectedType:   "about:blank",
+			expectedTitle:  "Internal Server Error",
+			expectedDetail: "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			builder := mockbuilder.NewProductUsecaseBuilder(t)
+			if tt.setupUC != nil {
+				tt.setupUC(builder)
+			}
+			r := newProblemRouter(controller.NewProductController(builder.Build(), controller.PriceFormatString), tt.format)
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set(controller.HeaderIfMatch, tt.ifMatch)
+			}
+			resp := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			require.Equal(t, tt.expectedStatus, resp.Code)
+			assert.Equal(t, controller.ContentTypeProblemJSON, resp.Header().Get("Content-Type"))
+
+			var problem controller.ProblemDetails
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &problem))
+			assert.Equal(t, tt.expectedType, problem.Type)
+			assert.Equal(t, tt.expectedTitle, problem.Title)
+			assert.Equal(t, tt.expectedStatus, problem.Status)
+			assert.Equal(t, tt.expectedDetail, problem.Detail)
+			assert.Equal(t, req.URL.Path, problem.Instance)
+			assert.Equal(t, resp.Header().Get(middleware.HeaderRequestID), problem.RequestID)
+			assert.Equal(t, tt.expectedErrors, problem.Errors)
+		})
+	}
+}
+
+func TestProductController_LegacyErrorFormat(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	// Arrange
+	ctrl := controller.NewProductController(mockbuilder.NewProductUsecaseBuilder(t).Build(), controller.PriceFormatString)
+	r := newProblemRouter(ctrl, controller.ErrorFormatLegacy)
+
+	req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewBufferString(`{"qty":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	// Act
+	r.ServeHTTP(resp, req)
+
+	// Assert: clients that did not opt in keep the APIResponse shape.
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Header().Get("Content-Type"), "application/json")
+
+	var body controller.APIResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, "invalid request payload", body.Message)
+	assert.NotEmpty(t, body.Error)
+	assert.NotEmpty(t, body.RequestID)
+}
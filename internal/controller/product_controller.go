@@ -1,26 +1,31 @@
 package controller
 
 import (
-	"errors"
-	"log"
 	"net/http"
 
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
 	"github.com/DucTran999/go-clean-archx/internal/dto"
-	"github.com/DucTran999/go-clean-archx/internal/entity"
 	"github.com/DucTran999/go-clean-archx/internal/port"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // ProductController handles incoming HTTP requests and sends appropriate responses.
 // It acts as the delivery layer in Clean Architecture, connecting HTTP routes to usecases.
+//
+// Handlers report failures with ctx.Error and return; ErrorHandler turns the error
+// into a response based on its apperror kind.
 type ProductController struct {
-	productUC port.ProductUsecase
+	productUC   port.ProductUsecase
+	priceFormat PriceFormat
 }
 
 // NewProductController creates a new ProductController instance.
-func NewProductController(productUC port.ProductUsecase) *ProductController {
+// priceFormat selects how prices are written in responses.
+func NewProductController(productUC port.ProductUsecase, priceFormat PriceFormat) *ProductController {
 	return &ProductController{
-		productUC: productUC,
+		productUC:   productUC,
+		priceFormat: priceFormat,
 	}
 }
 
@@ -29,24 +34,20 @@ func (hdl *ProductController) CreateProduct(ctx *gin.Context) {
 	var payload CreateProductRequest
 
 	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		JSONBadRequestResponse(ctx, "invalid request payload", err)
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid request payload"))
 		return
 	}
 
 	input := dto.CreateProductInput{
+		SKU:   payload.SKU,
 		Name:  payload.Name,
 		Qty:   payload.Qty,
-		Price: payload.Price,
+		Price: priceInput(payload.Price, payload.PriceMinor, payload.Currency),
 	}
 
 	created, err := hdl.productUC.CreateProduct(ctx.Request.Context(), input)
 	if err != nil {
-		if errors.Is(err, entity.ErrProductInvalid) {
-			JSONBadRequestResponse(ctx, "validation failed", err)
-		} else {
-			log.Printf("[ERROR] op=create_product, err=%v", err)
-			JSONInternalErrorResponse(ctx, "failed to create product")
-		}
+		ctx.Error(err)
 		return
 	}
 
@@ -55,3 +56,257 @@ func (hdl *ProductController) CreateProduct(ctx *gin.Context) {
 		Data:    gin.H{"id": created.ID.String()},
 	})
 }
+
+// UpsertProductBySKU handles PUT /products/by-sku/:sku requests.
+// It answers 201 Created when the product was created and 200 OK when it was replaced.
+// Replacing an existing product requires If-Match, like UpdateProduct: without it the
+// request may only create the product and gets 428 Precondition Required otherwise.
+func (hdl *ProductController) UpsertProductBySKU(ctx *gin.Context) {
+	var payload UpsertProductRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid request payload"))
+		return
+	}
+
+	input := dto.UpsertProductInput{
+		SKU:   ctx.Param("sku"),
+		Name:  payload.Name,
+		Qty:   payload.Qty,
+		Price: priceInput(payload.Price, payload.PriceMinor, payload.Currency),
+	}
+	if ctx.GetHeader(HeaderIfMatch) != "" {
+		version, err := expectedVersion(ctx)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		input.ExpectedVersion = &version
+	}
+
+	product, created, err := hdl.productUC.UpsertProductBySKU(ctx.Request.Context(), input)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	status, msg := http.StatusOK, "product updated successfully"
+	if created {
+		status, msg = http.StatusCreated, "product created successfully"
+	}
+
+	ctx.Header(HeaderETag, productETag(product.Version))
+	JSONResponse(ctx, status, APIResponse{
+		Message: msg,
+		Data:    newProductResponse(product, hdl.priceFormat),
+	})
+}
+
+// GetProduct handles GET /products/:id requests.
+// The product's version is sent as its ETag; a matching If-None-Match gets 304 Not Modified.
+func (hdl *ProductController) GetProduct(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid product id"))
+		return
+	}
+
+	var query ReadProductsQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid query parameters"))
+		return
+	}
+
+	product, err := hdl.productUC.GetByID(ctx.Request.Context(), dto.GetProductQuery{
+		ID:             id,
+		IncludeDeleted: query.IncludeDeleted,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	etag := productETag(product.Version)
+	ctx.Header(HeaderETag, etag)
+	if notModified(ctx, etag) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	JSONResponse(ctx, http.StatusOK, APIResponse{
+		Data: newProductResponse(product, hdl.priceFormat),
+	})
+}
+
+// ListProducts handles GET /products requests.
+// Results are cursor-paginated; pass the returned next_cursor as ?cursor= to fetch the next page.
+func (hdl *ProductController) ListProducts(ctx *gin.Context) {
+	var params ListProductsRequest
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid query parameters"))
+		return
+	}
+
+	cursor, err := decodeCursor(params.Cursor)
+	if err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid query parameters"))
+		return
+	}
+
+	currency, minPrice, maxPrice, err := parsePriceRange(params)
+	if err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid query parameters"))
+		return
+	}
+
+	page, err := hdl.productUC.List(ctx.Request.Context(), dto.ListProductsQuery{
+		Cursor:         cursor,
+		Limit:          params.Limit,
+		SortBy:         dto.ProductSortField(params.Sort),
+		Order:          dto.SortOrder(params.Order),
+		NamePrefix:     params.NamePrefix,
+		Currency:       currency,
+		MinPrice:       minPrice,
+		MaxPrice:       maxPrice,
+		MinQty:         params.MinQty,
+		MaxQty:         params.MaxQty,
+		IncludeDeleted: params.IncludeDeleted,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	JSONResponse(ctx, http.StatusOK, APIResponse{
+		Data: newProductResponses(page.Items, hdl.priceFormat),
+		Pagination: &Pagination{
+			NextCursor: encodeCursor(page.NextCursor),
+			HasMore:    page.HasMore,
+		},
+	})
+}
+
+// UpdateProduct handles PUT /products/:id requests.
+// If-Match must carry the ETag the change is based on, or "*" to overwrite any version.
+func (hdl *ProductController) UpdateProduct(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid product id"))
+		return
+	}
+
+	var payload UpdateProductRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid request payload"))
+		return
+	}
+
+	version, err := expectedVersion(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	input := dto.UpdateProductInput{
+		ID:    id,
+		SKU:   payload.SKU,
+		Name:  payload.Name,
+		Qty:   payload.Qty,
+		Price: priceInput(payload.Price, payload.PriceMinor, payload.Currency),
+
+		ExpectedVersion: version,
+	}
+
+	updated, err := hdl.productUC.UpdateProduct(ctx.Request.Context(), input)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header(HeaderETag, productETag(updated.Version))
+	JSONResponse(ctx, http.StatusOK, APIResponse{
+		Message: "product updated successfully",
+		Data:    newProductResponse(updated, hdl.priceFormat),
+	})
+}
+
+// PatchProduct handles PATCH /products/:id requests.
+// If-Match must carry the ETag the change is based on, or "*" to overwrite any version.
+func (hdl *ProductController) PatchProduct(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid product id"))
+		return
+	}
+
+	var payload PatchProductRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid request payload"))
+		return
+	}
+
+	version, err := expectedVersion(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	input := dto.PatchProductInput{
+		ID:    id,
+		SKU:   payload.SKU,
+		Name:  payload.Name,
+		Qty:   payload.Qty,
+		Price: patchPriceInput(payload),
+
+		ExpectedVersion: version,
+	}
+
+	updated, err := hdl.productUC.PatchProduct(ctx.Request.Context(), input)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header(HeaderETag, productETag(updated.Version))
+	JSONResponse(ctx, http.StatusOK, APIResponse{
+		Message: "product updated successfully",
+		Data:    newProductResponse(updated, hdl.priceFormat),
+	})
+}
+
+// DeleteProduct handles DELETE /products/:id requests.
+// The product is soft-deleted, so it can be brought back with RestoreProduct.
+func (hdl *ProductController) DeleteProduct(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid product id"))
+		return
+	}
+
+	if err := hdl.productUC.DeleteProduct(ctx.Request.Context(), id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RestoreProduct handles POST /products/:id/restore requests.
+func (hdl *ProductController) RestoreProduct(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid product id"))
+		return
+	}
+
+	restored, err := hdl.productUC.RestoreProduct(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header(HeaderETag, productETag(restored.Version))
+	JSONResponse(ctx, http.StatusOK, APIResponse{
+		Message: "product restored successfully",
+		Data:    newProductResponse(restored, hdl.priceFormat),
+	})
+}
@@ -8,12 +8,25 @@ import (
 	"testing"
 
 	"github.com/DucTran999/go-clean-archx/internal/controller"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/middleware"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
 	"github.com/DucTran999/go-clean-archx/test/mockbuilder"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// newTestRouter returns a router with the error middleware every product route relies on.
+func newTestRouter() *gin.Engine {
+	r := gin.Default()
+	r.Use(controller.ErrorHandler(logger.NewNop()))
+
+	return r
+}
+
 func TestProductController_CreateProduct(t *testing.T) {
 	t.Parallel()
 	gin.SetMode(gin.TestMode)
@@ -40,7 +53,7 @@ func TestProductController_CreateProduct(t *testing.T) {
 			setupUT: func(t *testing.T) *controller.ProductController {
 				t.Helper()
 				productUC := mockbuilder.NewProductUsecaseBuilder(t).CreateProductSuccess().Build()
-				return controller.NewProductController(productUC)
+				return controller.NewProductController(productUC, controller.PriceFormatString)
 			},
 			expectedStatus: http.StatusCreated,
 		},
@@ -60,10 +73,31 @@ func TestProductController_CreateProduct(t *testing.T) {
 			setupUT: func(t *testing.T) *controller.ProductController {
 				t.Helper()
 				productUC := mockbuilder.NewProductUsecaseBuilder(t).CreateProductReturnsInvalidPrice().Build()
-				return controller.NewProductController(productUC)
+				return controller.NewProductController(productUC, controller.PriceFormatString)
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name: "duplicate sku",
+			setupPayload: func(t *testing.T) []byte {
+				t.Helper()
+				body := map[string]any{
+					"sku":   "HAT-01",
+					"name":  "Test Product",
+					"qty":   10,
+					"price": "12.30",
+				}
+				payload, err := json.Marshal(body)
+				require.NoError(t, err)
+				return payload
+			},
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).CreateProductReturnsSKUTaken().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusConflict,
+		},
 		{
 			name: "invalid request payload",
 			setupPayload: func(t *testing.T) []byte {
@@ -79,7 +113,7 @@ func TestProductController_CreateProduct(t *testing.T) {
 			setupUT: func(t *testing.T) *controller.ProductController {
 				t.Helper()
 				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
-				return controller.NewProductController(productUC)
+				return controller.NewProductController(productUC, controller.PriceFormatString)
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
@@ -99,7 +133,7 @@ func TestProductController_CreateProduct(t *testing.T) {
 			setupUT: func(t *testing.T) *controller.ProductController {
 				t.Helper()
 				productUC := mockbuilder.NewProductUsecaseBuilder(t).CreateProductReturnErrDB().Build()
-				return controller.NewProductController(productUC)
+				return controller.NewProductController(productUC, controller.PriceFormatString)
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
@@ -112,7 +146,7 @@ func TestProductController_CreateProduct(t *testing.T) {
 			controller := tt.setupUT(t)
 
 			// Setup Gin router
-			r := gin.Default()
+			r := newTestRouter()
 			r.POST("/products", controller.CreateProduct)
 			reqBody := tt.setupPayload(t)
 
@@ -128,3 +162,930 @@ func TestProductController_CreateProduct(t *testing.T) {
 		})
 	}
 }
+
+func TestProductController_ErrorResponseIncludesRequestID(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	productUC := mockbuilder.NewProductUsecaseBuilder(t).CreateProductReturnErrDB().Build()
+	ctrl := controller.NewProductController(productUC, controller.PriceFormatString)
+
+	r := gin.New()
+	r.Use(middleware.RequestID(), controller.ErrorHandler(logger.NewNop()))
+	r.POST("/products", ctrl.CreateProduct)
+
+	req := httptest.NewRequest(http.MethodPost, "/products",
+		bytes.NewBufferString(`{"name":"Test Product","qty":10,"price":99.99}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.HeaderRequestID, "req-500")
+	resp := httptest.NewRecorder()
+
+	// Act
+	r.ServeHTTP(resp, req)
+
+	// Assert
+	require.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, "req-500", resp.Header().Get(middleware.HeaderRequestID))
+
+	var body controller.APIResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, "req-500", body.RequestID)
+}
+
+func TestProductController_UpsertProductBySKU(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		body           string
+		ifMatch        string
+		setupUT        func(t *testing.T) *controller.ProductController
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name: "created",
+			body: `{"name":"Hat","qty":2,"price":"49.50"}`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).UpsertProductBySKUSuccess(true).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:    "replaced at the expected version",
+			body:    `{"name":"Hat","qty":2,"price":"49.50"}`,
+			ifMatch: `"3"`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).UpsertProductBySKUSuccess(false).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"4"`,
+		},
+		{
+			name:    "replaced whatever its version",
+			body:    `{"name":"Hat","qty":2,"price":"49.50"}`,
+			ifMatch: "*",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).UpsertProductBySKUSuccess(false).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"1"`,
+		},
+		{
+			name: "existing sku without If-Match",
+			body: `{"name":"Hat","qty":2,"price":"49.50"}`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).UpsertProductBySKUReturnsVersionRequired().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:    "stale version",
+			body:    `{"name":"Hat","qty":2,"price":"49.50"}`,
+			ifMatch: `"1"`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).UpsertProductBySKUReturnsVersionMismatch().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "malformed If-Match",
+			body:    `{"name":"Hat","qty":2,"price":"49.50"}`,
+			ifMatch: "1",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "invalid request payload",
+			body: `{"qty":2,"price":"49.50"}`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "validation error from usecase",
+			body: `{"name":"Hat","qty":2,"price":"0"}`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).UpsertProductBySKUReturnsInvalid().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			r := newTestRouter()
+			r.PUT("/products/by-sku/:sku", tt.setupUT(t).UpsertProductBySKU)
+
+			req := httptest.NewRequest(http.MethodPut, "/products/by-sku/hat-01", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			resp := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+			assert.Equal(t, tt.expectedETag, resp.Header().Get("ETag"))
+			if resp.Code < http.StatusBadRequest {
+				assert.Contains(t, resp.Body.String(), `"sku":"HAT-01"`)
+			}
+		})
+	}
+}
+
+func TestProductController_GetProduct(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		productID      string
+		ifNoneMatch    string
+		setupUT        func(t *testing.T) *controller.ProductController
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name:      "success",
+			productID: datatest.FakeProductID.String(),
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).GetByIDSuccess().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:        "cached copy is current",
+			productID:   datatest.FakeProductID.String(),
+			ifNoneMatch: `"1"`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).GetByIDSuccess().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:        "weak tag in a list matches",
+			productID:   datatest.FakeProductID.String(),
+			ifNoneMatch: `"7", W/"1"`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).GetByIDSuccess().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusNotModified,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:        "cached copy is stale",
+			productID:   datatest.FakeProductID.String(),
+			ifNoneMatch: `"0"`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).GetByIDSuccess().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:      "invalid product id",
+			productID: "not-a-uuid",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "include deleted",
+			productID: datatest.FakeProductID.String() + "?include_deleted=true",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).GetByIDSuccess().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:      "invalid include_deleted",
+			productID: datatest.FakeProductID.String() + "?include_deleted=maybe",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "product not found",
+			productID: datatest.FakeProductID.String(),
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).GetByIDNotFound().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "unexpected error",
+			productID: datatest.FakeProductID.String(),
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).GetByIDReturnErrDB().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			controller := tt.setupUT(t)
+
+			r := newTestRouter()
+			r.GET("/products/:id", controller.GetProduct)
+
+			req := httptest.NewRequest(http.MethodGet, "/products/"+tt.productID, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			resp := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+			assert.Equal(t, tt.expectedETag, resp.Header().Get("ETag"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestProductController_ListProducts(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		query          string
+		setupUT        func(t *testing.T) *controller.ProductController
+		expectedStatus int
+	}{
+		{
+			name:  "success",
+			query: "?limit=1&sort=price&order=asc&name_prefix=Sto&min_price=1&max_price=100&min_qty=0&max_qty=10",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).ListSuccess().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "price sort defaults the currency",
+			query: "?sort=price",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).ListExpectsCurrency(entity.DefaultCurrency).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "limit out of range",
+			query: "?limit=1000",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "unsupported sort field",
+			query: "?sort=qty",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "non numeric price filter",
+			query: "?min_price=cheap",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "malformed cursor",
+			query: "?cursor=not-a-cursor",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "query rejected by usecase",
+			query: "?min_price=50&max_price=10",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).ListReturnsInvalidQuery().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unexpected error",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).ListReturnErrDB().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			controller := tt.setupUT(t)
+
+			r := newTestRouter()
+			r.GET("/products", controller.ListProducts)
+
+			req := httptest.NewRequest(http.MethodGet, "/products"+tt.query, nil)
+			resp := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+		})
+	}
+}
+
+func TestProductController_ListProductsCursorRoundTrip(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	// Arrange
+	productUC := mockbuilder.NewProductUsecaseBuilder(t).ListSuccess().Build()
+	r := newTestRouter()
+	r.GET("/products", controller.NewProductController(productUC, controller.PriceFormatString).ListProducts)
+
+	// Act: fetch the first page
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	// Assert: the envelope carries an opaque cursor
+	require.Equal(t, http.StatusOK, resp.Code)
+	var body controller.APIResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	require.NotNil(t, body.Pagination)
+	assert.True(t, body.Pagination.HasMore)
+	require.NotEmpty(t, body.Pagination.NextCursor)
+
+	// Act: the cursor is accepted for the next page
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/products?cursor="+body.Pagination.NextCursor, nil))
+
+	// Assert
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestProductController_UpdateProduct(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		productID      string
+		ifMatch        string
+		body           map[string]any
+		setupUT        func(t *testing.T) *controller.ProductController
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name:      "success",
+			productID: datatest.FakeProductID.String(),
+			ifMatch:   `"1"`,
+			body:      map[string]any{"name": "Notebook", "qty": 8, "price": 12.5},
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).UpdateProductSuccess().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+		},
+		{
+			name:      "overwrite any version",
+			productID: datatest.FakeProductID.String(),
+			ifMatch:   "*",
+			body:      map[string]any{"name": "Notebook", "qty": 8, "price": 12.5},
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).UpdateProductSuccess().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"1"`,
+		},
+		{
+			name:      "missing If-Match",
+			productID: datatest.FakeProductID.String(),
+			body:      map[string]any{"name": "Notebook", "qty": 8, "price": 12.5},
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:      "stale version",
+			productID: datatest.FakeProductID.String(),
+			ifMatch:   `"1"`,
+			body:      map[string]any{"name": "Notebook", "qty": 8, "price": 12.5},
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).UpdateProductReturnsVersionMismatch().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:      "weak tag never matches",
+			productID: datatest.FakeProductID.String(),
+			ifMatch:   `W/"1"`,
+			body:      map[string]any{"name": "Notebook", "qty": 8, "price": 12.5},
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:      "malformed If-Match",
+			productID: datatest.FakeProductID.String(),
+			ifMatch:   "1",
+			body:      map[string]any{"name": "Notebook", "qty": 8, "price": 12.5},
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "invalid product id",
+			productID: "not-a-uuid",
+			ifMatch:   `"1"`,
+			body:      map[string]any{"name": "Notebook", "qty": 8, "price": 12.5},
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "invalid request payload",
+			productID: datatest.FakeProductID.String(),
+			ifMatch:   `"1"`,
+			body:      map[string]any{"qty": 8, "price": 12.5},
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "validation error from usecase",
+			productID: datatest.FakeProductID.String(),
+			ifMatch:   `"1"`,
+			body:      map[string]any{"name": "Notebook", "qty": 8, "price": 0},
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).UpdateProductReturnsInvalidPrice().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "product not found",
+			productID: datatest.FakeProductID.String(),
+			ifMatch:   `"1"`,
+			body:      map[string]any{"name": "Notebook", "qty": 8, "price": 12.5},
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).UpdateProductNotFound().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			controller := tt.setupUT(t)
+
+			r := newTestRouter()
+			r.PUT("/products/:id", controller.UpdateProduct)
+
+			payload, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPut, "/products/"+tt.productID, bytes.NewBuffer(payload))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			resp := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+			assert.Equal(t, tt.expectedETag, resp.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestProductController_PatchProduct(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		ifMatch        string
+		body           string
+		setupUT        func(t *testing.T) *controller.ProductController
+		expectedStatus int
+	}{
+		{
+			name:    "success",
+			ifMatch: `"1"`,
+			body:    `{"qty": 0}`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).PatchProductSuccess().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "malformed json",
+			ifMatch: `"1"`,
+			body:    `{"qty": "many"}`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "missing If-Match",
+			body: `{"qty": 0}`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:    "unexpected error",
+			ifMatch: `"1"`,
+			body:    `{"name": "Renamed"}`,
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).PatchProductReturnErrDB().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			controller := tt.setupUT(t)
+
+			r := newTestRouter()
+			r.PATCH("/products/:id", controller.PatchProduct)
+
+			req := httptest.NewRequest(http.MethodPatch, "/products/"+datatest.FakeProductID.String(), bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			resp := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+		})
+	}
+}
+
+func TestProductController_DeleteProduct(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		productID      string
+		setupUT        func(t *testing.T) *controller.ProductController
+		expectedStatus int
+	}{
+		{
+			name:      "success",
+			productID: datatest.FakeProductID.String(),
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).DeleteProductSuccess().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:      "invalid product id",
+			productID: "not-a-uuid",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "product not found",
+			productID: datatest.FakeProductID.String(),
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).DeleteProductNotFound().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			controller := tt.setupUT(t)
+
+			r := newTestRouter()
+			r.DELETE("/products/:id", controller.DeleteProduct)
+
+			req := httptest.NewRequest(http.MethodDelete, "/products/"+tt.productID, nil)
+			resp := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+		})
+	}
+}
+
+func TestProductController_RestoreProduct(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		setupUT        func(t *testing.T) *controller.ProductController
+		expectedStatus int
+	}{
+		{
+			name: "success",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).RestoreProductSuccess().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "no deleted product",
+			setupUT: func(t *testing.T) *controller.ProductController {
+				t.Helper()
+				productUC := mockbuilder.NewProductUsecaseBuilder(t).RestoreProductNotFound().Build()
+				return controller.NewProductController(productUC, controller.PriceFormatString)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			controller := tt.setupUT(t)
+
+			r := newTestRouter()
+			r.POST("/products/:id/restore", controller.RestoreProduct)
+
+			req := httptest.NewRequest(http.MethodPost, "/products/"+datatest.FakeProductID.String()+"/restore", nil)
+			resp := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+		})
+	}
+}
+
+func TestProductController_CreateProductPriceInput(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	minor := int64(1230)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedPrice  *dto.PriceInput // nil when the usecase must not be called
+		expectedStatus int
+	}{
+		{
+			name:           "decimal string",
+			body:           `{"name":"Hat","qty":1,"price":"12.30"}`,
+			expectedPrice:  &dto.PriceInput{Amount: "12.30"},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "legacy JSON number keeps its digits",
+			body:           `{"name":"Hat","qty":1,"price":12.30}`,
+			expectedPrice:  &dto.PriceInput{Amount: "12.30"},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "minor units with currency",
+			body:           `{"name":"Hat","qty":1,"priceMinor":1230,"currency":"EUR"}`,
+			expectedPrice:  &dto.PriceInput{Minor: &minor, Currency: "EUR"},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "price of the wrong type",
+			body:           `{"name":"Hat","qty":1,"price":true}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "lowercase currency",
+			body:           `{"name":"Hat","qty":1,"price":"1","currency":"eur"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			builder := mockbuilder.NewProductUsecaseBuilder(t)
+			if tt.expectedPrice != nil {
+				builder.CreateProductExpectsPrice(*tt.expectedPrice)
+			}
+
+			r := newTestRouter()
+			r.POST("/products", controller.NewProductController(builder.Build(), controller.PriceFormatString).CreateProduct)
+
+			req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(resp, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, resp.Code)
+		})
+	}
+}
+
+func TestProductController_PriceFormat(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name          string
+		format        controller.PriceFormat
+		expectedPrice string
+	}{
+		{name: "string", format: controller.PriceFormatString, expectedPrice: `"49.50"`},
+		{name: "float", format: controller.PriceFormatFloat, expectedPrice: `49.50`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			productUC := mockbuilder.NewProductUsecaseBuilder(t).GetByIDSuccess().Build()
+			r := newTestRouter()
+			r.GET("/products/:id", controller.NewProductController(productUC, tt.format).GetProduct)
+
+			resp := httptest.NewRecorder()
+
+			// Act
+			r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/products/"+datatest.FakeProductID.String(), nil))
+
+			// Assert
+			require.Equal(t, http.StatusOK, resp.Code)
+
+			var body struct {
+				Data map[string]json.RawMessage `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedPrice, string(body.Data["price"]))
+			assert.Equal(t, "4950", string(body.Data["priceMinor"]))
+			assert.Equal(t, `"USD"`, string(body.Data["currency"]))
+		})
+	}
+}
+
+func TestProductController_ListProductsPriceRange(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	// Arrange
+	productUC := mockbuilder.NewProductUsecaseBuilder(t).Build()
+	r := newProblemRouter(controller.NewProductController(productUC, controller.PriceFormatString), controller.ErrorFormatProblem)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/products?min_price=0.105", nil)
+
+	// Act
+	r.ServeHTTP(resp, req)
+
+	// Assert
+	require.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"field":"min_price"`)
+	assert.Contains(t, resp.Body.String(), `"code":"precision"`)
+}
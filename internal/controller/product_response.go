@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/google/uuid"
+)
+
+// PriceFormat selects how the "price" field of a product is written in responses.
+type PriceFormat string
+
+const (
+	// PriceFormatString writes the price as an exact decimal string, e.g. "price": "12.30".
+	PriceFormatString PriceFormat = "string"
+
+	// PriceFormatFloat writes the price as a JSON number, e.g. "price": 12.30, the shape
+	// clients of the former float64 field expect. The digits are still exact.
+	PriceFormatFloat PriceFormat = "float"
+)
+
+// ProductResponse is the JSON representation of a product.
+// priceMinor and currency are always present, whatever the PriceFormat.
+type ProductResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	SKU        string     `json:"sku,omitempty"`
+	Name       string     `json:"name"`
+	Qty        int        `json:"qty"`
+	Price      any        `json:"price"`      // string or json.Number, see PriceFormat
+	PriceMinor int64      `json:"priceMinor"` // Amount in minor units, e.g. cents
+	Currency   string     `json:"currency"`   // ISO 4217 code
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
+	DeletedAt  *time.Time `json:"deletedAt"`
+	Version    int64      `json:"version"` // Also sent as the ETag of single-product responses
+}
+
+// newProductResponse converts a product for the wire.
+func newProductResponse(product *entity.Product, format PriceFormat) ProductResponse {
+	var price any = product.Price.Decimal()
+	if format == PriceFormatFloat {
+		// json.Number is written verbatim, so the legacy number keeps the exact digits.
+		price = json.Number(product.Price.Decimal())
+	}
+
+	return ProductResponse{
+		ID:         product.ID,
+		SKU:        string(product.SKU),
+		Name:       product.Name,
+		Qty:        product.Qty,
+		Price:      price,
+		PriceMinor: product.Price.Amount,
+		Currency:   product.Price.Currency,
+		CreatedAt:  product.CreatedAt,
+		UpdatedAt:  product.UpdatedAt,
+		DeletedAt:  product.DeletedAt,
+		Version:    product.Version,
+	}
+}
+
+// newProductResponses converts a list of products for the wire.
+func newProductResponses(products []entity.Product, format PriceFormat) []ProductResponse {
+	res := make([]ProductResponse, 0, len(products))
+	for i := range products {
+		res = append(res, newProductResponse(&products[i], format))
+	}
+
+	return res
+}
@@ -2,11 +2,186 @@
 // It maps incoming requests to use case calls and formats appropriate responses.
 package controller
 
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+)
+
 // CreateProductRequest defines the expected JSON structure for creating a new product.
 // It includes validation rules using Gin's binding tags to enforce input correctness
 // at the HTTP layer before data enters the application core.
+//
+// The price is sent either as "price" (a decimal string such as "12.34", or a JSON
+// number for older clients) or as "priceMinor" (integer minor units such as 1234).
 type CreateProductRequest struct {
-	Name  string  `json:"name" binding:"required"`
-	Qty   int     `json:"qty"`
-	Price float64 `json:"price"`
+	SKU        string     `json:"sku" binding:"omitempty,max=64"`
+	Name       string     `json:"name" binding:"required"`
+	Qty        int        `json:"qty"`
+	Price      PriceValue `json:"price"`
+	PriceMinor *int64     `json:"priceMinor"`
+	Currency   string     `json:"currency" binding:"omitempty,len=3,uppercase"`
+}
+
+// UpdateProductRequest defines the expected JSON structure for a full product update (PUT).
+// All fields are replaced, so omitted numeric fields are treated as zero.
+// An omitted currency or SKU keeps the product's current one.
+type UpdateProductRequest struct {
+	SKU        string     `json:"sku" binding:"omitempty,max=64"`
+	Name       string     `json:"name" binding:"required"`
+	Qty        int        `json:"qty"`
+	Price      PriceValue `json:"price"`
+	PriceMinor *int64     `json:"priceMinor"`
+	Currency   string     `json:"currency" binding:"omitempty,len=3,uppercase"`
+}
+
+// PatchProductRequest defines the expected JSON structure for a partial product update (PATCH).
+// Pointer fields distinguish "not sent" (nil) from an explicit zero value.
+// A currency can only be changed together with the price.
+type PatchProductRequest struct {
+	SKU        *string    `json:"sku" binding:"omitempty,max=64"`
+	Name       *string    `json:"name"`
+	Qty        *int       `json:"qty"`
+	Price      PriceValue `json:"price"`
+	PriceMinor *int64     `json:"priceMinor"`
+	Currency   string     `json:"currency" binding:"omitempty,len=3,uppercase"`
+}
+
+// UpsertProductRequest defines the expected JSON structure for PUT /products/by-sku/:sku.
+// The SKU comes from the path; the body fully describes the product, so an omitted
+// currency means entity.DefaultCurrency whether the product is created or replaced.
+type UpsertProductRequest struct {
+	Name       string     `json:"name" binding:"required"`
+	Qty        int        `json:"qty"`
+	Price      PriceValue `json:"price"`
+	PriceMinor *int64     `json:"priceMinor"`
+	Currency   string     `json:"currency" binding:"omitempty,len=3,uppercase"`
+}
+
+// ReadProductsQuery defines the query parameters for reading a single product.
+// Soft-deleted products are hidden unless include_deleted=true is passed explicitly.
+type ReadProductsQuery struct {
+	IncludeDeleted bool `form:"include_deleted"`
+}
+
+// ListProductsRequest defines the query parameters accepted by GET /products.
+// Binding tags reject malformed values before they reach the usecase, which
+// additionally checks cross-field rules such as min <= max.
+type ListProductsRequest struct {
+	Cursor         string `form:"cursor"`
+	Limit          int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Sort           string `form:"sort" binding:"omitempty,oneof=name price created_at"`
+	Order          string `form:"order" binding:"omitempty,oneof=asc desc"`
+	NamePrefix     string `form:"name_prefix" binding:"omitempty,max=255"`
+	Currency       string `form:"currency" binding:"omitempty,len=3,uppercase"`
+	MinPrice       string `form:"min_price"`
+	MaxPrice       string `form:"max_price"`
+	MinQty         *int   `form:"min_qty" binding:"omitempty,gte=0"`
+	MaxQty         *int   `form:"max_qty" binding:"omitempty,gte=0"`
+	IncludeDeleted bool   `form:"include_deleted"`
+}
+
+// PriceValue is a decimal amount accepted either as a JSON string ("12.34") or,
+// for clients of the former float field, as a JSON number (12.34). Both are kept
+// as text, so the amount never passes through float64.
+type PriceValue string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PriceValue) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*p = ""
+		return nil
+	}
+
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*p = PriceValue(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return &json.UnmarshalTypeError{Value: string(data), Type: reflect.TypeOf(""), Field: "price"}
+	}
+	*p = PriceValue(n.String())
+
+	return nil
+}
+
+// priceInput builds the usecase input from the price fields of a request.
+func priceInput(price PriceValue, minor *int64, currency string) dto.PriceInput {
+	return dto.PriceInput{
+		Amount:   string(price),
+		Minor:    minor,
+		Currency: currency,
+	}
+}
+
+// patchPriceInput returns the price part of a PATCH, or nil when no price field was sent.
+func patchPriceInput(payload PatchProductRequest) *dto.PriceInput {
+	if payload.Price == "" && payload.PriceMinor == nil && payload.Currency == "" {
+		return nil
+	}
+
+	input := priceInput(payload.Price, payload.PriceMinor, payload.Currency)
+	return &input
+}
+
+// parsePriceRange converts the decimal min_price/max_price bounds into minor units of
+// the requested currency. Older clients filter and sort by price without a currency;
+// they get entity.DefaultCurrency, the currency of all pre-existing prices.
+func parsePriceRange(params ListProductsRequest) (currency string, minPrice, maxPrice *int64, err error) {
+	currency = params.Currency
+	if currency == "" && (params.MinPrice != "" || params.MaxPrice != "" || params.Sort == string(dto.SortByPrice)) {
+		currency = entity.DefaultCurrency
+	}
+
+	var fields []entity.FieldError
+	parse := func(field, value string) *int64 {
+		if value == "" {
+			return nil
+		}
+		price, err := entity.ParseMoney(value, currency)
+		if err != nil {
+			fields = append(fields, entity.MoneyFieldError(field, err))
+			return nil
+		}
+		return &price.Amount
+	}
+
+	minPrice = parse("min_price", params.MinPrice)
+	maxPrice = parse("max_price", params.MaxPrice)
+	if len(fields) > 0 {
+		return "", nil, nil, &entity.ValidationError{Fields: fields}
+	}
+
+	return currency, minPrice, maxPrice, nil
+}
+
+// CreateWebhookRequest defines the expected JSON structure for subscribing a webhook.
+// When no secret is sent, one is generated and returned once, in the response.
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,max=2048"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1"`
+	Secret     string   `json:"secret" binding:"omitempty,max=256"`
+}
+
+// UpdateWebhookRequest defines the expected JSON structure for replacing a webhook (PUT).
+// An omitted secret keeps the current one.
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required,max=2048"`
+	EventTypes []string `json:"eventTypes" binding:"required,min=1"`
+	Secret     string   `json:"secret" binding:"omitempty,max=256"`
+	Active     *bool    `json:"active" binding:"required"`
+}
+
+// ListWebhookDeliveriesRequest defines the query parameters accepted by GET /webhooks/:id/deliveries.
+type ListWebhookDeliveriesRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending succeeded dead"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=200"`
 }
@@ -5,14 +5,23 @@ package controller
 import (
 	"net/http"
 
+	"github.com/DucTran999/go-clean-archx/internal/requestid"
 	"github.com/gin-gonic/gin"
 )
 
 // APIResponse defines the standard structure for API responses.
 type APIResponse struct {
-	Message string `json:"message,omitempty"` // Optional success or general message
-	Error   string `json:"error,omitempty"`   // Optional error message
-	Data    any    `json:"data,omitempty"`    // Optional payload data
+	Message    string      `json:"message,omitempty"`    // Optional success or general message
+	Error      string      `json:"error,omitempty"`      // Optional error message
+	Data       any         `json:"data,omitempty"`       // Optional payload data
+	Pagination *Pagination `json:"pagination,omitempty"` // Optional paging info for list endpoints
+	RequestID  string      `json:"request_id,omitempty"` // Correlation ID, set on error responses
+}
+
+// Pagination describes how to fetch the next page of a cursor-paginated list.
+type Pagination struct {
+	NextCursor string `json:"next_cursor,omitempty"` // Opaque token to pass as ?cursor= for the next page
+	HasMore    bool   `json:"has_more"`              // Whether another page exists
 }
 
 // JSONResponse sends a structured JSON response with the given status code.
@@ -20,18 +29,26 @@ func JSONResponse(ctx *gin.Context, status int, res APIResponse) {
 	ctx.JSON(status, res)
 }
 
-// JSONInternalErrorResponse sends a 500 Internal Server Error response with a safe message.
-func JSONInternalErrorResponse(ctx *gin.Context, msg string) {
-	ctx.JSON(http.StatusInternalServerError, APIResponse{
-		Message: msg,
-		Error:   http.StatusText(http.StatusInternalServerError),
-	})
-}
+// JSONErrorResponse sends an error response in the format selected for the request.
+// err adds details for client errors (4xx) and is never exposed for server errors.
+func JSONErrorResponse(ctx *gin.Context, status int, msg string, err error) {
+	if status >= http.StatusInternalServerError {
+		err = nil
+	}
+
+	if wantsProblem(ctx) {
+		JSONProblemResponse(ctx, newProblem(ctx, status, msg, err))
+		return
+	}
+
+	errText := http.StatusText(status)
+	if err != nil {
+		errText = causeText(err)
+	}
 
-// JSONBadRequestResponse sends a 400 Bad Request response with the given error details.
-func JSONBadRequestResponse(ctx *gin.Context, msg string, err error) {
-	ctx.JSON(http.StatusBadRequest, APIResponse{
-		Message: msg,
-		Error:   err.Error(),
+	ctx.JSON(status, APIResponse{
+		Message:   msg,
+		Error:     errText,
+		RequestID: requestid.FromContext(ctx.Request.Context()),
 	})
 }
@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WebhookController handles the HTTP endpoints managing webhook subscriptions.
+// Like ProductController, it reports failures through ctx.Error.
+type WebhookController struct {
+	webhookUC port.WebhookUsecase
+}
+
+// NewWebhookController creates a new WebhookController instance.
+func NewWebhookController(webhookUC port.WebhookUsecase) *WebhookController {
+	return &WebhookController{
+		webhookUC: webhookUC,
+	}
+}
+
+// CreateWebhook handles POST /webhooks requests.
+// The response is the only one carrying the secret, which partners need to verify signatures.
+func (hdl *WebhookController) CreateWebhook(ctx *gin.Context) {
+	var payload CreateWebhookRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid request payload"))
+		return
+	}
+
+	sub, err := hdl.webhookUC.CreateWebhook(ctx.Request.Context(), dto.CreateWebhookInput{
+		URL:        payload.URL,
+		EventTypes: payload.EventTypes,
+		Secret:     payload.Secret,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	res := newWebhookResponse(sub)
+	res.Secret = sub.Secret
+	JSONResponse(ctx, http.StatusCreated, APIResponse{
+		Message: "webhook created successfully",
+		Data:    res,
+	})
+}
+
+// ListWebhooks handles GET /webhooks requests.
+func (hdl *WebhookController) ListWebhooks(ctx *gin.Context) {
+	subs, err := hdl.webhookUC.ListWebhooks(ctx.Request.Context())
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	res := make([]WebhookResponse, 0, len(subs))
+	for i := range subs {
+		res = append(res, newWebhookResponse(&subs[i]))
+	}
+	JSONResponse(ctx, http.StatusOK, APIResponse{
+		Data: res,
+	})
+}
+
+// GetWebhook handles GET /webhooks/:id requests.
+func (hdl *WebhookController) GetWebhook(ctx *gin.Context) {
+	id, ok := webhookID(ctx)
+	if !ok {
+		return
+	}
+
+	sub, err := hdl.webhookUC.GetWebhook(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	JSONResponse(ctx, http.StatusOK, APIResponse{
+		Data: newWebhookResponse(sub),
+	})
+}
+
+// UpdateWebhook handles PUT /webhooks/:id requests.
+func (hdl *WebhookController) UpdateWebhook(ctx *gin.Context) {
+	id, ok := webhookID(ctx)
+	if !ok {
+		return
+	}
+
+	var payload UpdateWebhookRequest
+	if err := ctx.ShouldBindJSON(&payload); err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid request payload"))
+		return
+	}
+
+	sub, err := hdl.webhookUC.UpdateWebhook(ctx.Request.Context(), dto.UpdateWebhookInput{
+		ID:         id,
+		URL:        payload.URL,
+		EventTypes: payload.EventTypes,
+		Secret:     payload.Secret,
+		Active:     *payload.Active,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	JSONResponse(ctx, http.StatusOK, APIResponse{
+		Message: "webhook updated successfully",
+		Data:    newWebhookResponse(sub),
+	})
+}
+
+// DeleteWebhook handles DELETE /webhooks/:id requests.
+func (hdl *WebhookController) DeleteWebhook(ctx *gin.Context) {
+	id, ok := webhookID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := hdl.webhookUC.DeleteWebhook(ctx.Request.Context(), id); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /webhooks/:id/deliveries requests.
+// It shows the latest deliveries with the outcome of their last attempt, for debugging.
+func (hdl *WebhookController) ListDeliveries(ctx *gin.Context) {
+	id, ok := webhookID(ctx)
+	if !ok {
+		return
+	}
+
+	var params ListWebhookDeliveriesRequest
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid query parameters"))
+		return
+	}
+
+	deliveries, err := hdl.webhookUC.ListDeliveries(ctx.Request.Context(), dto.ListWebhookDeliveriesQuery{
+		SubscriptionID: id,
+		Status:         entity.WebhookDeliveryStatus(params.Status),
+		Limit:          params.Limit,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	JSONResponse(ctx, http.StatusOK, APIResponse{
+		Data: newWebhookDeliveryResponses(deliveries),
+	})
+}
+
+// webhookID parses the :id path parameter, reporting an invalid one through ctx.Error.
+func webhookID(ctx *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.Error(apperror.Wrap(apperror.Invalid, err, "invalid webhook id"))
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
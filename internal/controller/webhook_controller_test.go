@@ -0,0 +1,250 @@
+package controller_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/controller"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mockbuilder"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWebhookRouter registers the webhook routes as main does.
+func newWebhookRouter(ctrl *controller.WebhookController) *gin.Engine {
+	r := newTestRouter()
+	r.POST("/webhooks", ctrl.CreateWebhook)
+	r.GET("/webhooks", ctrl.ListWebhooks)
+	r.GET("/webhooks/:id", ctrl.GetWebhook)
+	r.PUT("/webhooks/:id", ctrl.UpdateWebhook)
+	r.DELETE("/webhooks/:id", ctrl.DeleteWebhook)
+	r.GET("/webhooks/:id/deliveries", ctrl.ListDeliveries)
+
+	return r
+}
+
+func TestWebhookController(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	webhookPath := "/webhooks/" + datatest.FakeWebhookID.String()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           map[string]any
+		setupUC        func(b *mockbuilder.WebhookUsecaseBuilder)
+		expectedStatus int
+		checkData      func(t *testing.T, data json.RawMessage)
+	}{
+		{
+			name:   "create returns the secret once",
+			method: http.MethodPost,
+			path:   "/webhooks",
+			body: map[string]any{
+				"url":        "https://partner.example.com/hooks",
+				"eventTypes": []string{"product.created", "product.deleted"},
+			},
+			setupUC:        func(b *mockbuilder.WebhookUsecaseBuilder) { b.CreateWebhookSuccess() },
+			expectedStatus: http.StatusCreated,
+			checkData: func(t *testing.T, data json.RawMessage) {
+				t.Helper()
+				var res controller.WebhookResponse
+				require.NoError(t, json.Unmarshal(data, &res))
+				assert.Equal(t, datatest.FakeWebhookSecret, res.Secret)
+				assert.True(t, res.Active)
+			},
+		},
+		{
+			name:           "create without event types",
+			method:         http.MethodPost,
+			path:           "/webhooks",
+			body:           map[string]any{"url": "https://partner.example.com/hooks", "eventTypes": []string{}},
+			setupUC:        func(*mockbuilder.WebhookUsecaseBuilder) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "create rejected by domain validation",
+			method:         http.MethodPost,
+			path:           "/webhooks",
+			body:           map[string]any{"url": "ftp://partner.example.com", "eventTypes": []string{"product.created"}},
+			setupUC:        func(b *mockbuilder.WebhookUsecaseBuilder) { b.CreateWebhookReturnsInvalid() },
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "list hides secrets",
+			method:         http.MethodGet,
+			path:           "/webhooks",
+			setupUC:        func(b *mockbuilder.WebhookUsecaseBuilder) { b.ListWebhooksSuccess() },
+			expectedStatus: http.StatusOK,
+			checkData: func(t *testing.T, data json.RawMessage) {
+				t.Helper()
+				assert.NotContains(t, string(data), datatest.FakeWebhookSecret)
+				assert.Contains(t, string(data), `"eventTypes":["product.created","product.deleted"]`)
+			},
+		},
+		{
+			name:           "get hides the secret",
+			method:         http.MethodGet,
+			path:           webhookPath,
+			setupUC:        func(b *mockbuilder.WebhookUsecaseBuilder) { b.GetWebhookSuccess() },
+			expectedStatus: http.StatusOK,
+			checkData: func(t *testing.T, data json.RawMessage) {
+				t.Helper()
+				assert.NotContains(t, string(data), "secret")
+			},
+		},
+		{
+			name:           "get missing webhook",
+			method:         http.MethodGet,
+			path:           webhookPath,
+			setupUC:        func(b *mockbuilder.WebhookUsecaseBuilder) { b.GetWebhookNotFound() },
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "get with malformed id",
+			method:         http.MethodGet,
+			path:           "/webhooks/not-a-uuid",
+			setupUC:        func(*mockbuilder.WebhookUsecaseBuilder) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "update",
+			method: http.MethodPut,
+			path:   webhookPath,
+			body: map[string]any{
+				"url":        "https://partner.example.com/v2",
+				"eventTypes": []string{"product.updated"},
+				"active":     false,
+			},
+			setupUC:        func(b *mockbuilder.WebhookUsecaseBuilder) { b.UpdateWebhookSuccess() },
+			expectedStatus: http.StatusOK,
+			checkData: func(t *testing.T, data json.RawMessage) {
+				t.Helper()
+				var res controller.WebhookResponse
+				require.NoError(t, json.Unmarshal(data, &res))
+				assert.Equal(t, "https://partner.example.com/v2", res.URL)
+				assert.False(t, res.Active)
+				assert.Empty(t, res.Secret)
+			},
+		},
+		{
+			name:           "update requires active",
+			method:         http.MethodPut,
+			path:           webhookPath,
+			body:           map[string]any{"url": "https://partner.example.com/v2", "eventTypes": []string{"product.updated"}},
+			setupUC:        func(*mockbuilder.WebhookUsecaseBuilder) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "delete",
+			method:         http.MethodDelete,
+			path:           webhookPath,
+			setupUC:        func(b *mockbuilder.WebhookUsecaseBuilder) { b.DeleteWebhookSuccess() },
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "delivery log",
+			method:         http.MethodGet,
+			path:           webhookPath + "/deliveries?status=pending&limit=10",
+			setupUC:        func(b *mockbuilder.WebhookUsecaseBuilder) { b.ListDeliveriesSuccess() },
+			expectedStatus: http.StatusOK,
+			checkData: func(t *testing.T, data json.RawMessage) {
+				t.Helper()
+				var res []controller.WebhookDeliveryResponse
+				require.NoError(t, json.Unmarshal(data, &res))
+				require.Len(t, res, 2)
+				assert.Equal(t, 503, res[0].LastStatusCode)
+				assert.NotNil(t, res[0].NextAttemptAt)
+				assert.Equal(t, "dead", string(res[1].Status))
+				assert.Nil(t, res[1].NextAttemptAt)
+				assert.Equal(t, "connection refused", res[1].LastError)
+				assert.Contains(t, string(data), `"eventId":"`)
+				assert.Contains(t, string(data), `"eventType":"product.deleted"`)
+				assert.Contains(t, string(data), `"lastStatusCode":503`)
+				assert.Contains(t, string(data), `"lastError":"connection refused"`)
+			},
+		},
+		{
+			name:           "delivery log with unknown status",
+			method:         http.MethodGet,
+			path:           webhookPath + "/deliveries?status=lost",
+			setupUC:        func(*mockbuilder.WebhookUsecaseBuilder) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "delivery log of missing webhook",
+			method:         http.MethodGet,
+			path:           webhookPath + "/deliveries",
+			setupUC:        func(b *mockbuilder.WebhookUsecaseBuilder) { b.ListDeliveriesNotFound() },
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			b := mockbuilder.NewWebhookUsecaseBuilder(t)
+			tt.setupUC(b)
+			router := newWebhookRouter(controller.NewWebhookController(b.Build()))
+
+			var body []byte
+			if tt.body != nil {
+				var err error
+				body, err = json.Marshal(tt.body)
+				require.NoError(t, err)
+			}
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(rec, req)
+
+			// Assert
+			require.Equal(t, tt.expectedStatus, rec.Code, rec.Body.String())
+			if tt.checkData != nil {
+				var res struct {
+					Data json.RawMessage `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				tt.checkData(t, res.Data)
+			}
+		})
+	}
+}
+
+// Binding failures name body fields as the client spells them, not as Go does.
+func TestWebhookController_BindingErrorNamesWireField(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	// Arrange
+	r := gin.New()
+	r.Use(controller.ErrorFormatSelector(controller.ErrorFormatProblem), controller.ErrorHandler(logger.NewNop()))
+	r.POST("/webhooks", controller.NewWebhookController(mockbuilder.NewWebhookUsecaseBuilder(t).Build()).CreateWebhook)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(`{"url":"https://partner.example.com/hooks"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// Act
+	r.ServeHTTP(rec, req)
+
+	// Assert
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	var problem controller.ProblemDetails
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, []controller.ProblemFieldError{
+		{Field: "eventTypes", Code: "required", Message: "eventTypes is required"},
+	}, problem.Errors)
+}
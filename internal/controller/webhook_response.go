@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/google/uuid"
+)
+
+// WebhookResponse is the JSON representation of a webhook subscription.
+// The secret is only included in the response to its creation.
+type WebhookResponse struct {
+	ID         uuid.UUID          `json:"id"`
+	URL        string             `json:"url"`
+	EventTypes []entity.EventType `json:"eventTypes"`
+	Active     bool               `json:"active"`
+	Secret     string             `json:"secret,omitempty"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  *time.Time         `json:"updatedAt,omitempty"`
+}
+
+// newWebhookResponse converts a subscription for the wire, without its secret.
+func newWebhookResponse(sub *entity.WebhookSubscription) WebhookResponse {
+	return WebhookResponse{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: sub.EventTypes,
+		Active:     sub.Active,
+		CreatedAt:  sub.CreatedAt,
+		UpdatedAt:  sub.UpdatedAt,
+	}
+}
+
+// WebhookDeliveryResponse is the JSON representation of a delivery in the delivery log.
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID                    `json:"id"`
+	EventID        uuid.UUID                    `json:"eventId"`
+	EventType      entity.EventType             `json:"eventType"`
+	Status         entity.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                          `json:"attempts"`
+	LastStatusCode int                          `json:"lastStatusCode,omitempty"` // Absent when no response was received
+	LastError      string                       `json:"lastError,omitempty"`
+	Payload        json.RawMessage              `json:"payload"` // Body sent to the endpoint
+	CreatedAt      time.Time                    `json:"createdAt"`
+	NextAttemptAt  *time.Time                   `json:"nextAttemptAt,omitempty"` // Only for pending deliveries
+	DeliveredAt    *time.Time                   `json:"deliveredAt,omitempty"`
+}
+
+// newWebhookDeliveryResponses converts a delivery log for the wire.
+func newWebhookDeliveryResponses(deliveries []entity.WebhookDelivery) []WebhookDeliveryResponse {
+	res := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		item := WebhookDeliveryResponse{
+			ID:             d.ID,
+			EventID:        d.EventID,
+			EventType:      d.EventType,
+			Status:         d.Status,
+			Attempts:       d.Attempts,
+			LastStatusCode: d.LastStatusCode,
+			LastError:      d.LastError,
+			Payload:        json.RawMessage(d.Payload),
+			CreatedAt:      d.CreatedAt,
+			DeliveredAt:    d.DeliveredAt,
+		}
+		if d.Status == entity.DeliveryPending {
+			nextAttemptAt := d.NextAttemptAt
+			item.NextAttemptAt = &nextAttemptAt
+		}
+		res = append(res, item)
+	}
+
+	return res
+}
@@ -0,0 +1,32 @@
+package dto
+
+import "time"
+
+// IdempotencyRecord is what is remembered about a request sent with an idempotency key.
+// While the first request is being processed the record only holds the key and the
+// request fingerprint; once it completes, the response is stored so retries can replay it.
+type IdempotencyRecord struct {
+	Key string
+
+	// Fingerprint identifies the request payload; a retry must send the same one.
+	Fingerprint string
+
+	// Response is nil while the original request is still in progress.
+	Response *IdempotentResponse
+
+	// ExpiresAt is when the record may be forgotten: the end of the lock for an
+	// in-progress request, the end of the replay window for a completed one.
+	ExpiresAt time.Time
+}
+
+// InProgress reports whether the original request has not completed yet.
+func (r *IdempotencyRecord) InProgress() bool {
+	return r.Response == nil
+}
+
+// IdempotentResponse is a stored response replayed to retries of the same request.
+type IdempotentResponse struct {
+	Status      int
+	ContentType string
+	Body        []byte
+}
@@ -0,0 +1,11 @@
+package dto
+
+import "github.com/DucTran999/go-clean-archx/internal/entity"
+
+// OutboxMessage is an event waiting in the outbox to be published.
+type OutboxMessage struct {
+	Event entity.Event
+
+	// Attempts counts the failed deliveries so far; the relay backs off accordingly.
+	Attempts int
+}
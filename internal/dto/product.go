@@ -2,10 +2,167 @@
 // between the delivery layer (e.g., HTTP handlers) and the usecase layer.
 package dto
 
+import (
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/google/uuid"
+)
+
 // CreateProductInput represents the input data required to create a new product.
 // It is typically populated from a request payload and passed into the usecase.
 type CreateProductInput struct {
+	SKU   string // Optional business identifier, normalized with entity.NormalizeSKU
+	Name  string
+	Qty   int
+	Price PriceInput
+}
+
+// UpsertProductInput represents a create-or-replace of the product identified by SKU.
+// An omitted currency means entity.DefaultCurrency, as on create, because the
+// request must have the same effect whether or not the product already exists.
+type UpsertProductInput struct {
+	SKU   string
+	Name  string
+	Qty   int
+	Price PriceInput
+
+	// ExpectedVersion is the version an existing product must have to be replaced, or 0
+	// to replace whatever version is stored. When nil, the product may only be created:
+	// an existing SKU fails with entity.ErrProductVersionRequired.
+	ExpectedVersion *int64
+}
+
+// PriceInput is a price as sent by a client, before it is turned into entity.Money.
+// Exactly one of Amount and Minor is expected; the usecase reports anything else
+// as a validation failure.
+type PriceInput struct {
+	// Amount is a decimal number of major units, e.g. "12.34".
+	// It is kept as text so it never passes through floating point.
+	Amount string
+
+	// Minor is an integer number of minor units, e.g. 1234 for 12.34 USD.
+	Minor *int64
+
+	// Currency is an ISO 4217 code. When empty, the product's current currency is kept,
+	// or entity.DefaultCurrency is used for a new product.
+	Currency string
+}
+
+// UpdateProductInput represents a full replacement of a product's mutable fields.
+// Every field is required; zero values are applied as-is, except that an empty SKU
+// keeps the current one so clients unaware of SKUs do not erase it.
+type UpdateProductInput struct {
+	ID    uuid.UUID
+	SKU   string
 	Name  string
 	Qty   int
-	Price float64
+	Price PriceInput
+
+	// ExpectedVersion is the version the caller last read; the update fails with
+	// entity.ErrProductVersionMismatch if the product has changed since. Zero skips the check.
+	ExpectedVersion int64
+}
+
+// PatchProductInput represents a partial update of a product.
+// A nil field means "not sent" and leaves the stored value untouched,
+// which lets callers explicitly set a field to its zero value (e.g. Qty = 0).
+type PatchProductInput struct {
+	ID    uuid.UUID
+	SKU   *string
+	Name  *string
+	Qty   *int
+	Price *PriceInput
+
+	// ExpectedVersion is the version the caller last read; see UpdateProductInput.
+	ExpectedVersion int64
+}
+
+// GetProductQuery describes how a single product should be looked up.
+type GetProductQuery struct {
+	ID uuid.UUID
+
+	// IncludeDeleted also returns the product when it has been soft-deleted.
+	IncludeDeleted bool
+
+	// ForUpdate reads the stored product, never a cached copy, and locks it until the
+	// surrounding transaction ends. Writes that check the version they read need it.
+	ForUpdate bool
+}
+
+// ErrInvalidListQuery is returned when a ListProductsQuery violates its constraints
+// (e.g. an inverted price range or a cursor issued for another sort order).
+var ErrInvalidListQuery = apperror.New(apperror.Invalid, "invalid list query")
+
+// Page size bounds for product listing.
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// ProductSortField is a column products can be sorted by.
+type ProductSortField string
+
+// Supported sort fields.
+const (
+	SortByCreatedAt ProductSortField = "created_at"
+	SortByName      ProductSortField = "name"
+	SortByPrice     ProductSortField = "price"
+)
+
+// SortOrder is the direction of a sort.
+type SortOrder string
+
+// Supported sort orders.
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// ProductCursor identifies the last product of a page for keyset pagination.
+// It always carries (CreatedAt, ID), which is a unique and stable tie-breaker,
+// plus the value of the sort column so the next page can resume right after it.
+// The delivery layer is responsible for turning it into an opaque token.
+type ProductCursor struct {
+	SortBy    ProductSortField
+	Order     SortOrder
+	Name      string
+	Price     int64 // minor units
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// ListProductsQuery describes which products should be listed and how they are paged.
+type ListProductsQuery struct {
+	// Cursor resumes listing after the given product; nil starts from the first page.
+	Cursor *ProductCursor
+
+	// Limit is the maximum number of products returned.
+	Limit int
+
+	SortBy ProductSortField
+	Order  SortOrder
+
+	// Filters; nil pointers and empty strings mean "no filter".
+	// Prices are minor units of Currency; comparing amounts across currencies is
+	// meaningless, so a price range or SortByPrice requires Currency to be set.
+	NamePrefix string
+	Currency   string
+	MinPrice   *int64
+	MaxPrice   *int64
+	MinQty     *int
+	MaxQty     *int
+
+	// IncludeDeleted also returns soft-deleted products.
+	IncludeDeleted bool
+}
+
+// ProductPage is a single page of products returned by a cursor-based listing.
+type ProductPage struct {
+	Items []entity.Product
+
+	// NextCursor points at the last item of this page; it is nil when HasMore is false.
+	NextCursor *ProductCursor
+	HasMore    bool
 }
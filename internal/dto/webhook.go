@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/google/uuid"
+)
+
+// CreateWebhookInput represents the input data required to subscribe a partner endpoint.
+type CreateWebhookInput struct {
+	URL        string
+	EventTypes []string
+
+	// Secret signs the deliveries. When empty, a random one is generated; it is
+	// returned once, in the created subscription, and never shown again.
+	Secret string
+}
+
+// UpdateWebhookInput represents a full replacement of a subscription's settings.
+// An empty Secret keeps the current one, since clients cannot read it back.
+type UpdateWebhookInput struct {
+	ID         uuid.UUID
+	URL        string
+	EventTypes []string
+	Secret     string
+	Active     bool
+}
+
+// Page size bounds for the webhook delivery log.
+const (
+	DefaultDeliveryListLimit = 50
+	MaxDeliveryListLimit     = 200
+)
+
+// ListWebhookDeliveriesQuery describes which deliveries of a subscription should be listed.
+// Deliveries are returned newest first.
+type ListWebhookDeliveriesQuery struct {
+	SubscriptionID uuid.UUID
+
+	// Status filters on the delivery state; empty means any.
+	Status entity.WebhookDeliveryStatus
+
+	// Limit is the maximum number of deliveries returned.
+	Limit int
+}
+
+// WebhookDispatch is a due delivery together with the subscription settings needed to send it.
+type WebhookDispatch struct {
+	Delivery entity.WebhookDelivery
+	URL      string
+	Secret   string
+}
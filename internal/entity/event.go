@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType names a domain event. Values are part of the contract with the services
+// consuming events, so they must never change once published.
+type EventType string
+
+// Product lifecycle events.
+const (
+	EventProductCreated EventType = "product.created"
+	EventProductUpdated EventType = "product.updated"
+	EventProductDeleted EventType = "product.deleted"
+)
+
+// Event is a fact about a change in the domain, recorded so other services can react to it.
+type Event struct {
+	ID          uuid.UUID
+	Type        EventType
+	AggregateID uuid.UUID // ID of the entity the event is about
+	OccurredAt  time.Time
+
+	// Payload is the JSON body delivered to consumers.
+	Payload json.RawMessage
+}
+
+// ProductDeletedPayload is the payload of EventProductDeleted.
+type ProductDeletedPayload struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// NewProductCreated returns the event raised when p is created. Its payload is p
+// itself, Version included, so consumers can discard events that arrive out of order.
+func NewProductCreated(p *Product) Event {
+	return newEvent(EventProductCreated, p.ID, p)
+}
+
+// NewProductUpdated returns the event raised when p is changed or restored.
+// Like NewProductCreated, it carries the whole product.
+func NewProductUpdated(p *Product) Event {
+	return newEvent(EventProductUpdated, p.ID, p)
+}
+
+// NewProductDeleted returns the event raised when the product with the given ID is deleted.
+func NewProductDeleted(id uuid.UUID) Event {
+	return newEvent(EventProductDeleted, id, ProductDeletedPayload{ID: id})
+}
+
+func newEvent(typ EventType, aggregateID uuid.UUID, payload any) Event {
+	// Payloads are plain structs of JSON-friendly fields, so marshalling cannot fail.
+	raw, _ := json.Marshal(payload)
+
+	return Event{
+		ID:          uuid.New(),
+		Type:        typ,
+		AggregateID: aggregateID,
+		OccurredAt:  time.Now().UTC(),
+		Payload:     raw,
+	}
+}
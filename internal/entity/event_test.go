@@ -0,0 +1,55 @@
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductEvents(t *testing.T) {
+	t.Parallel()
+
+	product := &entity.Product{ID: datatest.FakeProductID, Name: "Hat", Qty: 2, Price: datatest.FakePrice, Version: 3}
+
+	tests := []struct {
+		name            string
+		event           entity.Event
+		expectedType    entity.EventType
+		expectedPayload string
+	}{
+		{
+			name:         "created carries the product",
+			event:        entity.NewProductCreated(product),
+			expectedType: entity.EventProductCreated,
+			expectedPayload: `{"id":"` + datatest.FakeProductID.String() + `","name":"Hat","qty":2,` +
+				`"price":{"amount":4950,"currency":"USD"},"createdAt":"0001-01-01T00:00:00Z","deletedAt":null,"version":3}`,
+		},
+		{
+			name:         "updated carries the product",
+			event:        entity.NewProductUpdated(product),
+			expectedType: entity.EventProductUpdated,
+			expectedPayload: `{"id":"` + datatest.FakeProductID.String() + `","name":"Hat","qty":2,` +
+				`"price":{"amount":4950,"currency":"USD"},"createdAt":"0001-01-01T00:00:00Z","deletedAt":null,"version":3}`,
+		},
+		{
+			name:            "deleted carries the id",
+			event:           entity.NewProductDeleted(datatest.FakeProductID),
+			expectedType:    entity.EventProductDeleted,
+			expectedPayload: `{"id":"` + datatest.FakeProductID.String() + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expectedType, tt.event.Type)
+			assert.Equal(t, datatest.FakeProductID, tt.event.AggregateID)
+			assert.NotZero(t, tt.event.ID)
+			assert.False(t, tt.event.OccurredAt.IsZero())
+			assert.JSONEq(t, tt.expectedPayload, string(tt.event.Payload))
+		})
+	}
+}
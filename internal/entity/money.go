@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is assumed when a client does not send a currency.
+// Prices stored before currencies were introduced were migrated as USD.
+const DefaultCurrency = "USD"
+
+// Errors returned by ParseMoney. They describe malformed input rather than a product,
+// so callers report them as validation failures of the field being parsed.
+var (
+	ErrUnknownCurrency = errors.New("unknown currency")
+	ErrInvalidAmount   = errors.New("invalid amount")
+	ErrAmountPrecision = errors.New("too many decimal places for currency")
+)
+
+// currencyExponents maps supported ISO 4217 codes to their number of minor-unit digits.
+var currencyExponents = map[string]int{
+	"AUD": 2, "BHD": 3, "BRL": 2, "CAD": 2, "CHF": 2, "CNY": 2, "CZK": 2, "DKK": 2,
+	"EUR": 2, "GBP": 2, "HKD": 2, "IDR": 2, "INR": 2, "JOD": 3, "JPY": 0, "KRW": 0,
+	"KWD": 3, "MXN": 2, "MYR": 2, "NOK": 2, "NZD": 2, "OMR": 3, "PHP": 2, "PLN": 2,
+	"SEK": 2, "SGD": 2, "THB": 2, "TND": 3, "TWD": 2, "USD": 2, "VND": 0, "ZAR": 2,
+}
+
+// CurrencyExponent returns the number of decimal places of an ISO 4217 currency,
+// e.g. 2 for USD and 0 for JPY. ok is false for unsupported codes.
+func CurrencyExponent(currency string) (exponent int, ok bool) {
+	exponent, ok = currencyExponents[currency]
+	return exponent, ok
+}
+
+// Money is an exact monetary amount: an integer number of minor units (cents for USD)
+// in an ISO 4217 currency. Unlike float64 it never drifts (0.10 + 0.20 is exactly 0.30).
+type Money struct {
+	Amount   int64  `gorm:"column:price;not null;check:price > 0" json:"amount"`
+	Currency string `gorm:"column:currency;type:char(3);not null" json:"currency"`
+}
+
+// NewMoney returns amount minor units of currency.
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney parses a decimal string such as "12.34" into Money without going through
+// floating point. The amount may not have more decimal places than the currency allows.
+func ParseMoney(decimal, currency string) (Money, error) {
+	exponent, ok := CurrencyExponent(currency)
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, currency)
+	}
+
+	s := strings.TrimSpace(decimal)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidAmount, decimal)
+	}
+
+	frac = strings.TrimRight(frac, "0")
+	if len(frac) > exponent {
+		return Money{}, fmt.Errorf("%w: %s allows %d", ErrAmountPrecision, currency, exponent)
+	}
+	frac += strings.Repeat("0", exponent-len(frac))
+
+	amount, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, decimal)
+	}
+	if negative {
+		amount = -amount
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Decimal formats the amount with the currency's number of decimal places, e.g. "12.30".
+func (m Money) Decimal() string {
+	exponent, ok := CurrencyExponent(m.Currency)
+	if !ok || exponent == 0 {
+		return strconv.FormatInt(m.Amount, 10)
+	}
+
+	sign := ""
+	abs := uint64(m.Amount)
+	if m.Amount < 0 {
+		sign = "-"
+		abs = uint64(-(m.Amount + 1)) + 1 // avoids overflow for math.MinInt64
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	cut := len(digits) - exponent
+
+	return sign + digits[:cut] + "." + digits[cut:]
+}
+
+// Float64 converts the amount to major units as a float. It is only meant for clients
+// of the legacy float price field; never use the result for arithmetic.
+func (m Money) Float64() float64 {
+	exponent, _ := CurrencyExponent(m.Currency)
+	return float64(m.Amount) / math.Pow10(exponent)
+}
+
+// String formats the amount with its currency, e.g. "12.30 USD".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
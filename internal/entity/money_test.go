@@ -0,0 +1,96 @@
+package entity_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		decimal     string
+		currency    string
+		expected    entity.Money
+		expectedErr error
+	}{
+		{name: "whole amount", decimal: "12", currency: "USD", expected: entity.NewMoney(1200, "USD")},
+		{name: "cents", decimal: "12.34", currency: "USD", expected: entity.NewMoney(1234, "USD")},
+		{name: "single decimal", decimal: "12.5", currency: "USD", expected: entity.NewMoney(1250, "USD")},
+		{name: "trailing zeros beyond exponent", decimal: "12.3400", currency: "USD", expected: entity.NewMoney(1234, "USD")},
+		{name: "zero exponent currency", decimal: "1500", currency: "JPY", expected: entity.NewMoney(1500, "JPY")},
+		{name: "three decimal currency", decimal: "1.005", currency: "KWD", expected: entity.NewMoney(1005, "KWD")},
+		{name: "negative", decimal: "-0.99", currency: "EUR", expected: entity.NewMoney(-99, "EUR")},
+		{name: "too many decimals", decimal: "0.105", currency: "USD", expectedErr: entity.ErrAmountPrecision},
+		{name: "decimals for zero exponent currency", decimal: "1500.5", currency: "JPY", expectedErr: entity.ErrAmountPrecision},
+		{name: "not a number", decimal: "12,34", currency: "USD", expectedErr: entity.ErrInvalidAmount},
+		{name: "exponent notation", decimal: "1e3", currency: "USD", expectedErr: entity.ErrInvalidAmount},
+		{name: "missing whole part", decimal: ".5", currency: "USD", expectedErr: entity.ErrInvalidAmount},
+		{name: "empty", decimal: "", currency: "USD", expectedErr: entity.ErrInvalidAmount},
+		{name: "out of range", decimal: "99999999999999999999", currency: "USD", expectedErr: entity.ErrInvalidAmount},
+		{name: "unknown currency", decimal: "1", currency: "XYZ", expectedErr: entity.ErrUnknownCurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := entity.ParseMoney(tt.decimal, tt.currency)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestMoney_Decimal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		money    entity.Money
+		expected string
+	}{
+		{name: "cents", money: entity.NewMoney(1234, "USD"), expected: "12.34"},
+		{name: "keeps trailing zero", money: entity.NewMoney(1230, "USD"), expected: "12.30"},
+		{name: "below one", money: entity.NewMoney(5, "USD"), expected: "0.05"},
+		{name: "zero", money: entity.NewMoney(0, "USD"), expected: "0.00"},
+		{name: "negative", money: entity.NewMoney(-99, "EUR"), expected: "-0.99"},
+		{name: "zero exponent currency", money: entity.NewMoney(1500, "JPY"), expected: "1500"},
+		{name: "three decimal currency", money: entity.NewMoney(1005, "KWD"), expected: "1.005"},
+		{name: "minimum int64", money: entity.NewMoney(math.MinInt64, "USD"), expected: "-92233720368547758.08"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.money.Decimal())
+		})
+	}
+}
+
+func TestMoney_IsExact(t *testing.T) {
+	t.Parallel()
+
+	// 0.1 + 0.2 is 0.30000000000000004 as float64; in minor units it is exact.
+	a, err := entity.ParseMoney("0.1", "USD")
+	require.NoError(t, err)
+	b, err := entity.ParseMoney("0.2", "USD")
+	require.NoError(t, err)
+
+	sum := entity.NewMoney(a.Amount+b.Amount, "USD")
+
+	assert.Equal(t, "0.30", sum.Decimal())
+	assert.Equal(t, "0.30 USD", sum.String())
+	assert.InDelta(t, 0.3, sum.Float64(), 0)
+}
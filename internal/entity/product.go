@@ -4,10 +4,9 @@
 package entity
 
 import (
-	"errors"
-	"fmt"
 	"time"
 
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
 	"github.com/google/uuid"
 )
 
@@ -17,29 +16,69 @@ import (
 // This keeps the error surface small and maintainable,
 // supports errors.Is() checks,
 // and helps make tests more robust and less brittle.
-var ErrProductInvalid = errors.New("invalid product")
+// Its kind, apperror.Invalid, lets the delivery layer map it without knowing the entity.
+var ErrProductInvalid = apperror.New(apperror.Invalid, "invalid product")
+
+// ErrProductNotFound is returned when a product cannot be found by its identifier.
+// Repositories translate their storage-specific "no rows" errors into this value
+// so upper layers can react without knowing about the database driver.
+var ErrProductNotFound = apperror.New(apperror.NotFound, "product not found")
+
+// ErrProductVersionMismatch is returned when a product was modified since the version
+// the caller based its update on, so applying it would overwrite someone else's change.
+var ErrProductVersionMismatch = apperror.New(apperror.PreconditionFailed, "product has been modified by another request")
+
+// ErrProductVersionRequired is returned when a write would replace an existing product
+// without saying which version it is based on, which could silently undo someone else's change.
+var ErrProductVersionRequired = apperror.New(apperror.PreconditionRequired, "product version is required to replace an existing product")
+
+// ErrProductSKUTaken is returned when a product would get a SKU that another product already has.
+var ErrProductSKUTaken = apperror.New(apperror.Conflict, "product sku already exists")
 
 // Product represents a product in the system with its attributes.
 // It is a core domain entity and should be free of infrastructure-specific concerns.
 type Product struct {
 	ID        uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	SKU       SKU        `gorm:"column:sku;type:varchar(64);uniqueIndex:idx_products_sku" json:"sku,omitempty"`
 	Name      string     `gorm:"type:varchar(255);not null" json:"name"`
 	Qty       int        `gorm:"not null;default:0;check:qty >= 0" json:"qty"`
-	Price     float64    `gorm:"type:double precision;not null;check:price > 0" json:"price"`
+	Price     Money      `gorm:"embedded" json:"price"`
 	CreatedAt time.Time  `gorm:"not null;default:now()" json:"createdAt"`
 	UpdatedAt *time.Time `gorm:"type:timestamp with time zone;autoUpdateTime" json:"updatedAt,omitempty"`
+
+	// DeletedAt marks the product as soft-deleted; it is nil for a live product.
+	// Repositories hide deleted products unless they are explicitly asked for.
+	DeletedAt *time.Time `gorm:"type:timestamp with time zone;index" json:"deletedAt"`
+
+	// Version starts at 1 and is incremented by the repository on every write,
+	// which lets callers detect concurrent modifications (optimistic locking).
+	Version int64 `gorm:"not null;default:1" json:"version"`
 }
 
 // IsValid validates the product fields against business rules.
+// Every violated rule is reported in the returned *ValidationError, not just the first one.
 func (p *Product) IsValid() error {
+	var fields []FieldError
+	if p.SKU != "" && !p.SKU.IsValid() {
+		fields = append(fields, FieldError{
+			Field: "sku", Code: CodeFormat, Message: "sku must be 3 to 64 characters of A-Z, 0-9 and hyphens",
+		})
+	}
 	if p.Name == "" {
-		return fmt.Errorf("%w: name cannot be empty", ErrProductInvalid)
+		fields = append(fields, FieldError{Field: "name", Code: CodeRequired, Message: "name cannot be empty"})
 	}
 	if p.Qty < 0 {
-		return fmt.Errorf("%w: quantity must be non-negative", ErrProductInvalid)
+		fields = append(fields, FieldError{Field: "qty", Code: CodeNonNegative, Message: "quantity must be non-negative"})
+	}
+	if p.Price.Amount <= 0 {
+		fields = append(fields, FieldError{Field: "price", Code: CodePositive, Message: "price must be greater than zero"})
 	}
-	if p.Price <= 0 {
-		return fmt.Errorf("%w: price must be greater than zero", ErrProductInvalid)
+	if _, ok := CurrencyExponent(p.Price.Currency); !ok {
+		fields = append(fields, FieldError{Field: "currency", Code: CodeCurrency, Message: "currency must be a supported ISO 4217 code"})
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields}
 	}
 
 	return nil
@@ -29,27 +29,42 @@ func TestProduct_IsValid(t *testing.T) {
 	testTable := []testcase{
 		{
 			name:        "valid product",
-			product:     entity.Product{Name: "Laptop", Qty: 10, Price: 1200.5},
+			product:     entity.Product{Name: "Laptop", Qty: 10, Price: entity.NewMoney(120050, "USD")},
 			expectedErr: nil,
 		},
 		{
 			name:        "empty name",
-			product:     entity.Product{Name: "", Qty: 10, Price: 1000},
+			product:     entity.Product{Name: "", Qty: 10, Price: entity.NewMoney(100000, "USD")},
 			expectedErr: entity.ErrProductInvalid,
 		},
 		{
 			name:        "negative quantity",
-			product:     entity.Product{Name: "Mouse", Qty: -5, Price: 25},
+			product:     entity.Product{Name: "Mouse", Qty: -5, Price: entity.NewMoney(2500, "USD")},
 			expectedErr: entity.ErrProductInvalid,
 		},
 		{
 			name:        "zero price",
-			product:     entity.Product{Name: "Keyboard", Qty: 5, Price: 0},
+			product:     entity.Product{Name: "Keyboard", Qty: 5, Price: entity.NewMoney(0, "USD")},
+			expectedErr: entity.ErrProductInvalid,
+		},
+		{
+			name:        "unknown currency",
+			product:     entity.Product{Name: "Cable", Qty: 3, Price: entity.NewMoney(500, "XYZ")},
+			expectedErr: entity.ErrProductInvalid,
+		},
+		{
+			name:        "valid product with sku",
+			product:     entity.Product{SKU: "LAPTOP-15", Name: "Laptop", Qty: 1, Price: entity.NewMoney(120050, "USD")},
+			expectedErr: nil,
+		},
+		{
+			name:        "malformed sku",
+			product:     entity.Product{SKU: "LAPTOP 15", Name: "Laptop", Qty: 1, Price: entity.NewMoney(120050, "USD")},
 			expectedErr: entity.ErrProductInvalid,
 		},
 		{
 			name:        "negative price",
-			product:     entity.Product{Name: "Monitor", Qty: 3, Price: -200},
+			product:     entity.Product{Name: "Monitor", Qty: 3, Price: entity.NewMoney(-20000, "USD")},
 			expectedErr: entity.ErrProductInvalid,
 		},
 	}
@@ -67,3 +82,21 @@ func TestProduct_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestProduct_IsValid_ReportsEveryField(t *testing.T) {
+	t.Parallel()
+
+	product := entity.Product{Name: "", Qty: -1, Price: entity.NewMoney(0, "usd")}
+
+	err := product.IsValid()
+
+	require.ErrorIs(t, err, entity.ErrProductInvalid)
+	require.Equal(t, []entity.FieldError{
+		{Field: "name", Code: entity.CodeRequired, Message: "name cannot be empty"},
+		{Field: "qty", Code: entity.CodeNonNegative, Message: "quantity must be non-negative"},
+		{Field: "price", Code: entity.CodePositive, Message: "price must be greater than zero"},
+		{Field: "currency", Code: entity.CodeCurrency, Message: "currency must be a supported ISO 4217 code"},
+	}, entity.FieldErrors(err))
+	require.EqualError(t, err, "invalid product: name cannot be empty; quantity must be non-negative; "+
+		"price must be greater than zero; currency must be a supported ISO 4217 code")
+}
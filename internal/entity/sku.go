@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// SKU length bounds.
+const (
+	MinSKULength = 3
+	MaxSKULength = 64
+)
+
+// SKU is a product's business identifier (stock keeping unit), e.g. "TSHIRT-RED-XL".
+// It is optional for products created before SKUs existed; the empty SKU is stored
+// as NULL so those products do not collide on the unique index.
+type SKU string
+
+// NormalizeSKU trims surrounding spaces and upper-cases s, so "tshirt-red " and
+// "TSHIRT-RED" identify the same product.
+func NormalizeSKU(s string) SKU {
+	return SKU(strings.ToUpper(strings.TrimSpace(s)))
+}
+
+// IsValid reports whether s is MinSKULength to MaxSKULength characters of A-Z, 0-9
+// and hyphens, neither starting nor ending with a hyphen.
+func (s SKU) IsValid() bool {
+	if len(s) < MinSKULength || len(s) > MaxSKULength {
+		return false
+	}
+	if s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Value implements driver.Valuer, writing the empty SKU as NULL.
+func (s SKU) Value() (driver.Value, error) {
+	if s == "" {
+		return nil, nil //nolint:nilnil // NULL is a valid value
+	}
+
+	return string(s), nil
+}
+
+// Scan implements sql.Scanner, reading NULL as the empty SKU.
+func (s *SKU) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*s = ""
+	case string:
+		*s = SKU(v)
+	case []byte:
+		*s = SKU(v)
+	default:
+		return fmt.Errorf("cannot scan %T into SKU", src)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package entity_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSKU_IsValid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		sku      entity.SKU
+		expected bool
+	}{
+		{name: "letters digits and hyphens", sku: "TSHIRT-RED-XL", expected: true},
+		{name: "shortest", sku: "A1B", expected: true},
+		{name: "longest", sku: entity.SKU(strings.Repeat("A", entity.MaxSKULength)), expected: true},
+		{name: "too short", sku: "AB", expected: false},
+		{name: "too long", sku: entity.SKU(strings.Repeat("A", entity.MaxSKULength+1)), expected: false},
+		{name: "lowercase", sku: "tshirt", expected: false},
+		{name: "space", sku: "TSHIRT RED", expected: false},
+		{name: "leading hyphen", sku: "-TSHIRT", expected: false},
+		{name: "trailing hyphen", sku: "TSHIRT-", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.sku.IsValid())
+		})
+	}
+}
+
+func TestNormalizeSKU(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, entity.SKU("TSHIRT-RED"), entity.NormalizeSKU("  tshirt-Red "))
+	assert.Equal(t, entity.SKU(""), entity.NormalizeSKU("   "))
+}
+
+func TestSKU_ValueAndScan(t *testing.T) {
+	t.Parallel()
+
+	// The empty SKU round-trips through NULL.
+	value, err := entity.SKU("").Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	var sku entity.SKU
+	require.NoError(t, sku.Scan(nil))
+	assert.Equal(t, entity.SKU(""), sku)
+
+	value, err = entity.SKU("HAT-001").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "HAT-001", value)
+
+	require.NoError(t, sku.Scan([]byte("HAT-002")))
+	assert.Equal(t, entity.SKU("HAT-002"), sku)
+
+	assert.Error(t, sku.Scan(42))
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+// Validation codes reported in FieldError.Code.
+const (
+	CodeRequired    = "required"
+	CodeNonNegative = "non_negative"
+	CodePositive    = "positive"
+	CodeCurrency    = "currency"
+	CodePrecision   = "precision"
+	CodeFormat      = "format"
+)
+
+// FieldError describes a single business rule violated by one field.
+type FieldError struct {
+	Field   string // JSON name of the offending field, e.g. "price"
+	Code    string // Stable, machine-readable rule identifier, e.g. "positive"
+	Message string // Human-readable description
+}
+
+// ValidationError lists every rule an entity violates.
+// It wraps the invalid sentinel of the entity (ErrProductInvalid by default), so
+// errors.Is(err, ErrProductInvalid) keeps working, while the delivery layer can use
+// errors.As to report each field separately.
+type ValidationError struct {
+	Fields []FieldError
+
+	// Err is the sentinel of the invalid entity; nil means ErrProductInvalid.
+	Err error
+}
+
+// Error joins the field messages after the wrapped sentinel,
+// e.g. "invalid product: price must be greater than zero".
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Message)
+	}
+
+	return e.Unwrap().Error() + ": " + strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes the entity's invalid sentinel to errors.Is.
+func (e *ValidationError) Unwrap() error {
+	if e.Err == nil {
+		return ErrProductInvalid
+	}
+
+	return e.Err
+}
+
+// FieldErrors returns the field violations carried by err, if it is or wraps a ValidationError.
+func FieldErrors(err error) []FieldError {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return verr.Fields
+	}
+
+	return nil
+}
+
+// MoneyFieldError turns an error returned by ParseMoney into a FieldError for field,
+// so a malformed or over-precise amount is reported like any other invalid field.
+func MoneyFieldError(field string, err error) FieldError {
+	switch {
+	case errors.Is(err, ErrUnknownCurrency):
+		return FieldError{Field: "currency", Code: CodeCurrency, Message: "currency must be a supported ISO 4217 code"}
+	case errors.Is(err, ErrAmountPrecision):
+		return FieldError{Field: field, Code: CodePrecision, Message: field + " has " + err.Error()}
+	default:
+		return FieldError{Field: field, Code: CodeFormat, Message: field + " must be a decimal number"}
+	}
+}
@@ -0,0 +1,187 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/google/uuid"
+)
+
+// ErrWebhookInvalid is wrapped by the *ValidationError of an invalid webhook subscription.
+var ErrWebhookInvalid = apperror.New(apperror.Invalid, "invalid webhook subscription")
+
+// ErrWebhookNotFound is returned when a webhook subscription cannot be found by its identifier.
+var ErrWebhookNotFound = apperror.New(apperror.NotFound, "webhook subscription not found")
+
+// MinWebhookSecretLength is the shortest secret accepted for signing deliveries.
+const MinWebhookSecretLength = 16
+
+// IsKnown reports whether t is one of the events this service publishes.
+func (t EventType) IsKnown() bool {
+	switch t {
+	case EventProductCreated, EventProductUpdated, EventProductDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// EventTypes is a set of event types, stored as a JSON array.
+type EventTypes []EventType
+
+// Contains reports whether t is in the set.
+func (ts EventTypes) Contains(t EventType) bool {
+	return slices.Contains(ts, t)
+}
+
+// Value implements driver.Valuer.
+func (ts EventTypes) Value() (driver.Value, error) {
+	raw, err := json.Marshal(ts)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(raw), nil
+}
+
+// Scan implements sql.Scanner.
+func (ts *EventTypes) Scan(src any) error {
+	switch v := src.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), ts)
+	case []byte:
+		return json.Unmarshal(v, ts)
+	default:
+		return fmt.Errorf("cannot scan %T into EventTypes", src)
+	}
+}
+
+// WebhookSubscription is a partner endpoint that receives catalog events over HTTP.
+// Every delivery is signed with Secret, so the partner can check it came from us.
+type WebhookSubscription struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	URL        string     `gorm:"type:text;not null"`
+	EventTypes EventTypes `gorm:"type:jsonb;not null"`
+	Secret     string     `gorm:"type:text;not null"`
+
+	// Active subscriptions receive new events; existing deliveries of an inactive one still run.
+	Active bool `gorm:"not null"`
+
+	CreatedAt time.Time  `gorm:"not null;default:now()"`
+	UpdatedAt *time.Time `gorm:"type:timestamp with time zone;autoUpdateTime"`
+}
+
+// IsValid validates the subscription against business rules, reporting every violation.
+func (s *WebhookSubscription) IsValid() error {
+	var fields []FieldError
+	if u, err := url.Parse(s.URL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		fields = append(fields, FieldError{Field: "url", Code: CodeFormat, Message: "url must be an absolute http or https URL"})
+	}
+	if len(s.EventTypes) == 0 {
+		fields = append(fields, FieldError{Field: "eventTypes", Code: CodeRequired, Message: "eventTypes cannot be empty"})
+	}
+	for _, t := range s.EventTypes {
+		if !t.IsKnown() {
+			fields = append(fields, FieldError{
+				Field: "eventTypes", Code: CodeFormat, Message: fmt.Sprintf("event type %q is not supported", t),
+			})
+		}
+	}
+	if len(s.Secret) < MinWebhookSecretLength {
+		fields = append(fields, FieldError{
+			Field: "secret", Code: CodeFormat, Message: fmt.Sprintf("secret must be at least %d characters", MinWebhookSecretLength),
+		})
+	}
+
+	if len(fields) > 0 {
+		return &ValidationError{Fields: fields, Err: ErrWebhookInvalid}
+	}
+
+	return nil
+}
+
+// WebhookDeliveryStatus is the state of a webhook delivery.
+type WebhookDeliveryStatus string
+
+// Delivery states. A pending delivery is retried until it succeeds or runs out of
+// attempts, at which point it is dead-lettered and kept for inspection.
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	DeliveryDead      WebhookDeliveryStatus = "dead"
+)
+
+// WebhookDelivery is one event to be sent to one subscription, with the log of its attempts.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	SubscriptionID uuid.UUID             `gorm:"type:uuid;not null"`
+	EventID        uuid.UUID             `gorm:"type:uuid;not null"`
+	EventType      EventType             `gorm:"type:varchar(100);not null"`
+	Payload        string                `gorm:"type:jsonb;not null"` // Request body, identical for every attempt
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(20);not null"`
+	Attempts       int                   `gorm:"not null"`
+	NextAttemptAt  time.Time             `gorm:"not null"`
+
+	// Outcome of the last attempt: the response status (0 when none was received) and the failure.
+	LastStatusCode int    `gorm:"not null"`
+	LastError      string `gorm:"type:text;not null"`
+
+	CreatedAt   time.Time `gorm:"not null;default:now()"`
+	DeliveredAt *time.Time
+}
+
+// webhookEnvelope is the body partners receive.
+type webhookEnvelope struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       EventType       `json:"type"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// NewWebhookDelivery prepares the delivery of event to sub, due right away.
+// The event ID is sent as the envelope ID, so partners can drop duplicates.
+func NewWebhookDelivery(sub *WebhookSubscription, event Event, now time.Time) WebhookDelivery {
+	// The envelope only holds JSON-friendly values, so marshalling cannot fail.
+	body, _ := json.Marshal(webhookEnvelope{
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Payload,
+	})
+
+	return WebhookDelivery{
+		SubscriptionID: sub.ID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Payload:        string(body),
+		Status:         DeliveryPending,
+		NextAttemptAt:  now,
+	}
+}
+
+// Succeed records a successful attempt answered with statusCode.
+func (d *WebhookDelivery) Succeed(statusCode int, now time.Time) {
+	d.Attempts++
+	d.Status = DeliverySucceeded
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = &now
+}
+
+// Fail records a failed attempt. The delivery is retried after retryIn, unless this
+// was attempt maxAttempts, in which case it is dead-lettered.
+func (d *WebhookDelivery) Fail(statusCode int, cause string, now time.Time, retryIn time.Duration, maxAttempts int) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = cause
+	if d.Attempts >= maxAttempts {
+		d.Status = DeliveryDead
+		return
+	}
+	d.NextAttemptAt = now.Add(retryIn)
+}
@@ -0,0 +1,108 @@
+package entity_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSubscription_IsValid(t *testing.T) {
+	t.Parallel()
+
+	valid := func() entity.WebhookSubscription {
+		return entity.WebhookSubscription{
+			URL:        "https://partner.example.com/hooks",
+			EventTypes: entity.EventTypes{entity.EventProductCreated},
+			Secret:     "0123456789abcdef",
+		}
+	}
+
+	tests := []struct {
+		name           string
+		mutate         func(s *entity.WebhookSubscription)
+		expectedFields []string
+	}{
+		{name: "valid", mutate: func(*entity.WebhookSubscription) {}},
+		{name: "relative url", mutate: func(s *entity.WebhookSubscription) { s.URL = "/hooks" }, expectedFields: []string{"url"}},
+		{name: "unsupported scheme", mutate: func(s *entity.WebhookSubscription) { s.URL = "ftp://example.com" }, expectedFields: []string{"url"}},
+		{name: "no event types", mutate: func(s *entity.WebhookSubscription) { s.EventTypes = nil }, expectedFields: []string{"eventTypes"}},
+		{
+			name:           "unknown event type",
+			mutate:         func(s *entity.WebhookSubscription) { s.EventTypes = entity.EventTypes{"product.sold"} },
+			expectedFields: []string{"eventTypes"},
+		},
+		{name: "short secret", mutate: func(s *entity.WebhookSubscription) { s.Secret = "short" }, expectedFields: []string{"secret"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sub := valid()
+			tt.mutate(&sub)
+
+			err := sub.IsValid()
+
+			if tt.expectedFields == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, entity.ErrWebhookInvalid)
+			assert.NotErrorIs(t, err, entity.ErrProductInvalid)
+
+			var fields []string
+			for _, f := range entity.FieldErrors(err) {
+				fields = append(fields, f.Field)
+			}
+			assert.Equal(t, tt.expectedFields, fields)
+		})
+	}
+}
+
+func TestNewWebhookDelivery(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	event := entity.NewProductDeleted(datatest.FakeProductID)
+	sub := &entity.WebhookSubscription{ID: datatest.FakeProductID}
+
+	d := entity.NewWebhookDelivery(sub, event, now)
+
+	assert.Equal(t, entity.DeliveryPending, d.Status)
+	assert.Equal(t, now, d.NextAttemptAt)
+	assert.Equal(t, event.ID, d.EventID)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal([]byte(d.Payload), &body))
+	assert.Equal(t, event.ID.String(), body["id"])
+	assert.Equal(t, "product.deleted", body["type"])
+	assert.Equal(t, map[string]any{"id": datatest.FakeProductID.String()}, body["data"])
+}
+
+func TestWebhookDelivery_Attempts(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	d := entity.WebhookDelivery{Status: entity.DeliveryPending}
+
+	d.Fail(500, "server error", now, time.Minute, 3)
+	assert.Equal(t, entity.DeliveryPending, d.Status)
+	assert.Equal(t, now.Add(time.Minute), d.NextAttemptAt)
+
+	d.Fail(0, "timeout", now, time.Minute, 3)
+	d.Fail(503, "unavailable", now, time.Minute, 3)
+	assert.Equal(t, entity.DeliveryDead, d.Status)
+	assert.Equal(t, 3, d.Attempts)
+	assert.Equal(t, 503, d.LastStatusCode)
+
+	ok := entity.WebhookDelivery{Status: entity.DeliveryPending, LastError: "timeout"}
+	ok.Succeed(204, now)
+	assert.Equal(t, entity.DeliverySucceeded, ok.Status)
+	assert.Empty(t, ok.LastError)
+	assert.Equal(t, &now, ok.DeliveredAt)
+}
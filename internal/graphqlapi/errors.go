@@ -0,0 +1,112 @@
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/publicerr"
+)
+
+// Error codes reported in the "code" extension of GraphQL errors.
+const (
+	CodeBadUserInput         = "BAD_USER_INPUT"
+	CodeNotFound             = "NOT_FOUND"
+	CodeConflict             = "CONFLICT"
+	CodePreconditionFailed   = "PRECONDITION_FAILED"
+	CodePreconditionRequired = "PRECONDITION_REQUIRED"
+	CodeForbidden            = "FORBIDDEN"
+	CodeUnavailable          = "UNAVAILABLE"
+	CodeUnprocessable        = "UNPROCESSABLE"
+	CodeInternal             = "INTERNAL_SERVER_ERROR"
+
+	// CodeQueryTooComplex rejects a query over the depth or complexity limit.
+	CodeQueryTooComplex = "QUERY_TOO_COMPLEX"
+)
+
+// CodeForKind maps an apperror kind to its GraphQL error code.
+// This is the only place where domain errors meet GraphQL, like controller.StatusForKind for HTTP.
+func CodeForKind(kind apperror.Kind) string {
+	switch kind {
+	case apperror.Invalid:
+		return CodeBadUserInput
+	case apperror.NotFound:
+		return CodeNotFound
+	case apperror.Conflict:
+		return CodeConflict
+	case apperror.PreconditionFailed:
+		return CodePreconditionFailed
+	case apperror.PreconditionRequired:
+		return CodePreconditionRequired
+	case apperror.Forbidden:
+		return CodeForbidden
+	case apperror.Unavailable:
+		return CodeUnavailable
+	case apperror.Unprocessable:
+		return CodeUnprocessable
+	default:
+		return CodeInternal
+	}
+}
+
+// fieldViolation is an entity.FieldError as reported in the "fields" extension.
+type fieldViolation struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// resolverError is an error returned by a resolver. graphql-go copies its Extensions
+// into the error of the response.
+type resolverError struct {
+	message string
+	code    string
+	fields  []fieldViolation
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+// Extensions implements gqlerrors.ExtendedError.
+func (e *resolverError) Extensions() map[string]any {
+	ext := map[string]any{"code": e.code}
+	if len(e.fields) > 0 {
+		ext["fields"] = e.fields
+	}
+
+	return ext
+}
+
+// toResolverError converts an error returned by a usecase into a GraphQL error carrying
+// what publicerr lets the client see, with the offending fields of invalid input in the
+// "fields" extension. Unexpected errors are logged here.
+func toResolverError(ctx context.Context, log port.Logger, err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return &resolverError{message: err.Error(), code: CodeUnavailable}
+	}
+
+	public := publicerr.Of(err)
+	if public.Internal {
+		log.Error(ctx, "graphql resolver failed", "kind", public.Kind.String(), "error", err)
+		return &resolverError{message: public.Message, code: CodeInternal}
+	}
+
+	resolved := &resolverError{message: public.Message, code: CodeForKind(public.Kind)}
+	for _, f := range public.Fields {
+		resolved.fields = append(resolved.fields, fieldViolation{Field: f.Field, Code: f.Code, Message: f.Message})
+	}
+
+	return resolved
+}
+
+// badUserInput reports a malformed argument, e.g. an ID that is not a UUID.
+func badUserInput(field, msg string) error {
+	return &resolverError{
+		message: field + ": " + msg,
+		code:    CodeBadUserInput,
+		fields:  []fieldViolation{{Field: field, Code: entity.CodeFormat, Message: msg}},
+	}
+}
@@ -0,0 +1,163 @@
+// Package graphqlapi exposes the product catalog as a GraphQL schema served on the
+// HTTP router. Resolvers call the product usecase, queries are bounded in depth and
+// complexity, and failures carry a machine-readable code in their extensions.
+package graphqlapi
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Options tunes the handler.
+type Options struct {
+	// Limits bound the cost of the queries that are executed.
+	Limits Limits
+
+	// Logger receives unexpected resolver errors. It defaults to slog.Default.
+	Logger port.Logger
+}
+
+// Handler serves GraphQL requests for the product schema.
+type Handler struct {
+	schema graphql.Schema
+	opts   Options
+}
+
+// NewHandler creates a Handler resolving the product schema with productUC.
+func NewHandler(productUC port.ProductUsecase, opts Options) (*Handler, error) {
+	if opts.Logger == nil {
+		opts.Logger = logger.FromSlog(slog.Default())
+	}
+
+	schema, err := NewSchema(productUC, opts.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{schema: schema, opts: opts}, nil
+}
+
+// Request is the body of a GraphQL request, as sent in a POST body or as the
+// query, operationName and variables (JSON-encoded) parameters of a GET.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Response is the body of every GraphQL response.
+type Response struct {
+	Data   any                        `json:"data,omitempty"`
+	Errors []gqlerrors.FormattedError `json:"errors,omitempty"`
+}
+
+// ServeGraphQL handles GET and POST /graphql requests.
+//
+// A request that is not GraphQL, such as a malformed body, is answered with 400.
+// Anything else is answered with 200 and the errors listed in the body, as GraphQL
+// clients expect: syntax and validation errors, queries over the limits and resolver
+// errors, whose "code" extension tells them apart. Mutations are only accepted over POST.
+func (h *Handler) ServeGraphQL(ctx *gin.Context) {
+	req, err := readRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, Response{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, Response{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+
+	if result := graphql.ValidateDocument(&h.schema, doc, nil); !result.IsValid {
+		ctx.JSON(http.StatusOK, Response{Errors: result.Errors})
+		return
+	}
+
+	if ctx.Request.Method == http.MethodGet && hasMutation(doc, req.OperationName) {
+		ctx.Header("Allow", http.MethodPost)
+		ctx.JSON(http.StatusMethodNotAllowed, Response{
+			Errors: gqlerrors.FormatErrors(errors.New("mutations must be sent with POST")),
+		})
+		return
+	}
+
+	if err := h.opts.Limits.check(doc, req.Variables); err != nil {
+		ctx.JSON(http.StatusOK, Response{Errors: []gqlerrors.FormattedError{formatError(err)}})
+		return
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx.Request.Context(),
+	})
+
+	ctx.JSON(http.StatusOK, Response{Data: result.Data, Errors: result.Errors})
+}
+
+// readRequest reads the GraphQL request from the query string of a GET or the JSON body of a POST.
+func readRequest(ctx *gin.Context) (Request, error) {
+	var req Request
+
+	if ctx.Request.Method == http.MethodGet {
+		req.Query = ctx.Query("query")
+		req.OperationName = ctx.Query("operationName")
+		if raw := ctx.Query("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				return req, errors.New("variables must be a JSON object")
+			}
+		}
+	} else if err := json.NewDecoder(ctx.Request.Body).Decode(&req); err != nil {
+		return req, errors.New("request body must be a JSON object with a query")
+	}
+
+	if req.Query == "" {
+		return req, errors.New("query is required")
+	}
+
+	return req, nil
+}
+
+// hasMutation reports whether the operation to execute is a mutation. The document
+// has been validated, so an unnamed operation is the only one.
+func hasMutation(doc *ast.Document, operationName string) bool {
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" || (op.Name != nil && op.Name.Value == operationName) {
+			return op.Operation == ast.OperationTypeMutation
+		}
+	}
+
+	return false
+}
+
+// formatError formats an error raised outside of execution, keeping its extensions.
+func formatError(err error) gqlerrors.FormattedError {
+	formatted := gqlerrors.FormatError(err)
+	var extended gqlerrors.ExtendedError
+	if errors.As(err, &extended) {
+		formatted.Extensions = extended.Extensions()
+	}
+
+	return formatted
+}
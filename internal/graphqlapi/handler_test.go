@@ -0,0 +1,454 @@
+package graphqlapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/graphqlapi"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/internal/usecase"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mockbuilder"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gqlResponse is a decoded GraphQL response.
+type gqlResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+// code returns the "code" extension of the first error, or "" when there is none.
+func (r gqlResponse) code() string {
+	if len(r.Errors) == 0 {
+		return ""
+	}
+	code, _ := r.Errors[0].Extensions["code"].(string)
+
+	return code
+}
+
+// newTestRouter serves productUC on /graphql.
+func newTestRouter(t *testing.T, productUC port.ProductUsecase, limits graphqlapi.Limits) *gin.Engine {
+	t.Helper()
+
+	hdl, err := graphqlapi.NewHandler(productUC, graphqlapi.Options{Limits: limits, Logger: logger.NewNop()})
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.GET("/graphql", hdl.ServeGraphQL)
+	r.POST("/graphql", hdl.ServeGraphQL)
+
+	return r
+}
+
+// newMemoryUsecase returns a product usecase backed by the in-memory repositories.
+func newMemoryUsecase() port.ProductUsecase {
+	return usecase.NewProductUsecase(
+		repository.NewMemoryProductRepository(), repository.NewMemoryOutboxRepository(),
+		repository.NewMemoryTxManager(), logger.NewNop(),
+	)
+}
+
+// post sends a GraphQL request as a JSON POST and decodes the response.
+func post(t *testing.T, r http.Handler, query string, variables map[string]any) (int, gqlResponse) {
+	t.Helper()
+
+	body, err := json.Marshal(graphqlapi.Request{Query: query, Variables: variables})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	return serve(t, r, req)
+}
+
+func serve(t *testing.T, r http.Handler, req *http.Request) (int, gqlResponse) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var resp gqlResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp), w.Body.String())
+
+	return w.Code, resp
+}
+
+const createMutation = `mutation Create($input: CreateProductInput!) {
+	createProduct(input: $input) { id sku name qty price { amount currency } version }
+}`
+
+func TestHandler_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	r := newTestRouter(t, newMemoryUsecase(), graphqlapi.Limits{})
+
+	// Act & Assert
+	status, resp := post(t, r, createMutation, map[string]any{
+		"input": map[string]any{"sku": "mug-1", "name": "Mug", "qty": 3, "price": map[string]any{"amount": "12.3"}},
+	})
+	require.Equal(t, http.StatusOK, status)
+	require.Empty(t, resp.Errors)
+	created, _ := resp.Data["createProduct"].(map[string]any)
+	assert.Equal(t, "MUG-1", created["sku"])
+	assert.Equal(t, map[string]any{"amount": "12.30", "currency": entity.DefaultCurrency}, created["price"])
+	assert.InDelta(t, 1, created["version"], 0)
+	id, _ := created["id"].(string)
+
+	// Only the selected fields are returned.
+	_, resp = post(t, r, `query($id: ID!) { product(id: $id) { name } }`, map[string]any{"id": id})
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, map[string]any{"product": map[string]any{"name": "Mug"}}, resp.Data)
+
+	_, resp = post(t, r, `mutation($id: ID!) {
+		updateProduct(id: $id, input: {qty: 0, expectedVersion: 1}) { name qty version updatedAt }
+	}`, map[string]any{"id": id})
+	require.Empty(t, resp.Errors)
+	updated, _ := resp.Data["updateProduct"].(map[string]any)
+	assert.Equal(t, "Mug", updated["name"], "fields left out are unchanged")
+	assert.InDelta(t, 0, updated["qty"], 0)
+	assert.InDelta(t, 2, updated["version"], 0)
+	assert.NotNil(t, updated["updatedAt"])
+
+	_, resp = post(t, r, `mutation($id: ID!) {
+		updateProduct(id: $id, input: {name: "Cup", expectedVersion: 1}) { version }
+	}`, map[string]any{"id": id})
+	assert.Equal(t, graphqlapi.CodePreconditionFailed, resp.code())
+}
+
+func TestHandler_ProductsPagination(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	productUC := newMemoryUsecase()
+	for i, price := range []string{"5", "15", "25"} {
+		_, err := productUC.CreateProduct(t.Context(), dto.CreateProductInput{
+			Name: fmt.Sprintf("Mug %d", i), Qty: 1, Price: dto.PriceInput{Amount: price},
+		})
+		require.NoError(t, err)
+	}
+	r := newTestRouter(t, productUC, graphqlapi.Limits{})
+
+	const query = `query($after: String, $filter: ProductFilter) {
+		products(first: 2, after: $after, sortBy: PRICE, order: ASC, filter: $filter) {
+			edges { cursor node { price { amount } } }
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+	page := func(t *testing.T, variables map[string]any) ([]string, map[string]any) {
+		t.Helper()
+
+		_, resp := post(t, r, query, variables)
+		require.Empty(t, resp.Errors)
+		conn, _ := resp.Data["products"].(map[string]any)
+		edges, _ := conn["edges"].([]any)
+		amounts := make([]string, 0, len(edges))
+		for _, e := range edges {
+			node, _ := e.(map[string]any)["node"].(map[string]any)
+			price, _ := node["price"].(map[string]any)
+			amounts = append(amounts, price["amount"].(string))
+		}
+		info, _ := conn["pageInfo"].(map[string]any)
+
+		return amounts, info
+	}
+
+	// Act & Assert
+	amounts, info := page(t, nil)
+	assert.Equal(t, []string{"5.00", "15.00"}, amounts)
+	assert.Equal(t, true, info["hasNextPage"])
+
+	amounts, info = page(t, map[string]any{"after": info["endCursor"]})
+	assert.Equal(t, []string{"25.00"}, amounts)
+	assert.Equal(t, false, info["hasNextPage"])
+
+	amounts, _ = page(t, map[string]any{"filter": map[string]any{"minPrice": "10", "maxPrice": "20"}})
+	assert.Equal(t, []string{"15.00"}, amounts)
+}
+
+func TestHandler_ErrorCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T) port.ProductUsecase
+		query      string
+		wantCode   string
+		wantMsg    string
+		wantFields bool
+	}{
+		{
+			name:       "validation failure lists the fields",
+			setup:      func(*testing.T) port.ProductUsecase { return newMemoryUsecase() },
+			query:      `mutation { createProduct(input: {name: "", qty: -1, price: {amount: "1"}}) { id } }`,
+			wantCode:   graphqlapi.CodeBadUserInput,
+			wantMsg:    entity.ErrProductInvalid.Error(),
+			wantFields: true,
+		},
+		{
+			name:       "malformed id",
+			setup:      func(t *testing.T) port.ProductUsecase { return mockbuilder.NewProductUsecaseBuilder(t).Build() },
+			query:      `{ product(id: "42") { id } }`,
+			wantCode:   graphqlapi.CodeBadUserInput,
+			wantMsg:    "id: must be a UUID",
+			wantFields: true,
+		},
+		{
+			name:     "malformed cursor",
+			setup:    func(t *testing.T) port.ProductUsecase { return mockbuilder.NewProductUsecaseBuilder(t).Build() },
+			query:    `{ products(after: "nope") { pageInfo { hasNextPage } } }`,
+			wantCode: graphqlapi.CodeBadUserInput,
+			wantMsg:  "not issued by this service",
+		},
+		{
+			name: "invalid list query",
+			setup: func(t *testing.T) port.ProductUsecase {
+				return mockbuilder.NewProductUsecaseBuilder(t).ListReturnsInvalidQuery().Build()
+			},
+			query:    `{ products { pageInfo { hasNextPage } } }`,
+			wantCode: graphqlapi.CodeBadUserInput,
+		},
+		{
+			name: "not found",
+			setup: func(t *testing.T) port.ProductUsecase {
+				return mockbuilder.NewProductUsecaseBuilder(t).GetByIDNotFound().Build()
+			},
+			query:    fmt.Sprintf(`{ product(id: %q) { id } }`, datatest.FakeProductID),
+			wantCode: graphqlapi.CodeNotFound,
+		},
+		{
+			name: "sku taken",
+			setup: func(t *testing.T) port.ProductUsecase {
+				return mockbuilder.NewProductUsecaseBuilder(t).CreateProductReturnsSKUTaken().Build()
+			},
+			query:    `mutation { createProduct(input: {sku: "HAT-01", name: "Hat", qty: 1, price: {amount: "1"}}) { id } }`,
+			wantCode: graphqlapi.CodeConflict,
+			wantMsg:  entity.ErrProductSKUTaken.Error(),
+		},
+		{
+			name:     "update without expected version",
+			setup:    func(t *testing.T) port.ProductUsecase { return mockbuilder.NewProductUsecaseBuilder(t).Build() },
+			query:    fmt.Sprintf(`mutation { updateProduct(id: %q, input: {name: "Cup"}) { id } }`, datatest.FakeProductID),
+			wantCode: graphqlapi.CodePreconditionRequired,
+			wantMsg:  "expectedVersion is required",
+		},
+		{
+			name: "database constraint violation is not leaked",
+			setup: func(t *testing.T) port.ProductUsecase {
+				return mockbuilder.NewProductUsecaseBuilder(t).CreateProductReturnsCheckViolation().Build()
+			},
+			query:    `mutation { createProduct(input: {name: "Hat", qty: 1, price: {amount: "1"}}) { id } }`,
+			wantCode: graphqlapi.CodeBadUserInput,
+			wantMsg:  "value violates a database constraint",
+		},
+		{
+			name: "unexpected error is not leaked",
+			setup: func(t *testing.T) port.ProductUsecase {
+				return mockbuilder.NewProductUsecaseBuilder(t).CreateProductReturnErrDB().Build()
+			},
+			query:    `mutation { createProduct(input: {name: "Hat", qty: 1, price: {amount: "1"}}) { id } }`,
+			wantCode: graphqlapi.CodeInternal,
+			wantMsg:  "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			r := newTestRouter(t, tt.setup(t), graphqlapi.Limits{})
+
+			// Act
+			status, resp := post(t, r, tt.query, nil)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, status)
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, tt.wantCode, resp.code())
+			assert.Contains(t, resp.Errors[0].Message, tt.wantMsg)
+			assert.NotContains(t, resp.Errors[0].Message, datatest.ErrUnexpectedDB.Error())
+			assert.NotContains(t, resp.Errors[0].Message, datatest.CheckViolationCode)
+			assert.NotContains(t, resp.Errors[0].Message, datatest.CheckViolationConstraint)
+			if tt.wantFields {
+				assert.NotEmpty(t, resp.Errors[0].Extensions["fields"])
+			}
+		})
+	}
+}
+
+func TestHandler_Limits(t *testing.T) {
+	t.Parallel()
+
+	limits := graphqlapi.Limits{MaxDepth: 4, MaxComplexity: 100}
+
+	tests := []struct {
+		name      string
+		query     string
+		variables map[string]any
+		wantErr   string
+	}{
+		{
+			name:    "too deep",
+			query:   `{ products { edges { node { price { amount } } } } }`,
+			wantErr: "query depth 5 exceeds the limit of 4",
+		},
+		{
+			name:    "too many items",
+			query:   `{ products(first: 50) { edges { node { id name } } } }`,
+			wantErr: "query complexity 201 exceeds the limit of 100",
+		},
+		{
+			name:      "page size from a variable",
+			query:     `query($n: Int) { products(first: $n) { edges { node { id name } } } }`,
+			variables: map[string]any{"n": 50},
+			wantErr:   "query complexity 201 exceeds the limit of 100",
+		},
+		{
+			name: "fragments are expanded",
+			query: `{ a: products { ...page } b: products { ...page } }
+				fragment page on ProductConnection { edges { node { id } } }`,
+			wantErr: "query complexity 122 exceeds the limit of 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange: the usecase must not be reached.
+			r := newTestRouter(t, mockbuilder.NewProductUsecaseBuilder(t).Build(), limits)
+
+			// Act
+			status, resp := post(t, r, tt.query, tt.variables)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, status)
+			assert.Nil(t, resp.Data)
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, graphqlapi.CodeQueryTooComplex, resp.code())
+			assert.Equal(t, tt.wantErr, resp.Errors[0].Message)
+		})
+	}
+
+	t.Run("within limits", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		r := newTestRouter(t, mockbuilder.NewProductUsecaseBuilder(t).ListSuccess().Build(), limits)
+
+		// Act
+		_, resp := post(t, r, `{ products(first: 10) { edges { node { id name } } pageInfo { hasNextPage } } }`, nil)
+
+		// Assert
+		assert.Empty(t, resp.Errors)
+		assert.NotNil(t, resp.Data["products"])
+	})
+}
+
+func TestHandler_Requests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		request    func() *http.Request
+		wantStatus int
+		wantMsg    string
+	}{
+		{
+			name: "malformed body",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString("{"))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "request body must be a JSON object with a query",
+		},
+		{
+			name: "missing query",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/graphql", nil)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantMsg:    "query is required",
+		},
+		{
+			name: "syntax error",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape("{ products {"), nil)
+			},
+			wantStatus: http.StatusOK,
+			wantMsg:    "Syntax Error",
+		},
+		{
+			name: "unknown field",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape("{ product(id: \"x\") { cost } }"), nil)
+			},
+			wantStatus: http.StatusOK,
+			wantMsg:    `Cannot query field "cost"`,
+		},
+		{
+			name: "mutation over GET",
+			request: func() *http.Request {
+				q := `mutation { createProduct(input: {name: "Hat", qty: 1, price: {amount: "1"}}) { id } }`
+				return httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(q), nil)
+			},
+			wantStatus: http.StatusMethodNotAllowed,
+			wantMsg:    "mutations must be sent with POST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			r := newTestRouter(t, mockbuilder.NewProductUsecaseBuilder(t).Build(), graphqlapi.Limits{})
+
+			// Act
+			status, resp := serve(t, r, tt.request())
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, status)
+			require.NotEmpty(t, resp.Errors)
+			assert.Contains(t, resp.Errors[0].Message, tt.wantMsg)
+		})
+	}
+
+	t.Run("query over GET", func(t *testing.T) {
+		t.Parallel()
+
+		// Arrange
+		r := newTestRouter(t, mockbuilder.NewProductUsecaseBuilder(t).GetByIDSuccess().Build(), graphqlapi.Limits{})
+		params := url.Values{
+			"query":     {`query($id: ID!) { product(id: $id) { id qty } }`},
+			"variables": {fmt.Sprintf(`{"id": %q}`, datatest.FakeProductID)},
+		}
+
+		// Act
+		status, resp := serve(t, r, httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil))
+
+		// Assert
+		assert.Equal(t, http.StatusOK, status)
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]any{
+			"product": map[string]any{"id": datatest.FakeProductID.String(), "qty": float64(3)},
+		}, resp.Data)
+	})
+}
@@ -0,0 +1,159 @@
+package graphqlapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Default query limits.
+const (
+	DefaultMaxDepth      = 8
+	DefaultMaxComplexity = 2000
+)
+
+// Limits bound the cost of a query before it is executed, so a single request cannot
+// make the resolvers load an unbounded number of products.
+type Limits struct {
+	// MaxDepth is the deepest nesting of fields allowed, e.g. 4 for
+	// products { edges { node { price } } }. Zero means DefaultMaxDepth.
+	MaxDepth int
+
+	// MaxComplexity bounds the estimated number of resolved fields: every field costs 1,
+	// and the fields selected below a connection are counted once per requested item.
+	// Zero means DefaultMaxComplexity.
+	MaxComplexity int
+}
+
+// listFields are the connection fields whose selection is resolved once per item;
+// their page size is read from the "first" argument.
+var listFields = map[string]bool{
+	"products": true,
+}
+
+// check rejects every operation of doc that exceeds the limits. It runs after
+// validation, so the fragments it expands are known to exist and not to be cyclic.
+// Introspection fields are not counted: they are answered from the schema alone.
+func (l Limits) check(doc *ast.Document, variables map[string]any) error {
+	if l.MaxDepth <= 0 {
+		l.MaxDepth = DefaultMaxDepth
+	}
+	if l.MaxComplexity <= 0 {
+		l.MaxComplexity = DefaultMaxComplexity
+	}
+
+	w := costWalker{
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+	}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			w.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+
+		depth, complexity := w.selectionSet(op.SelectionSet, 0)
+		if depth > l.MaxDepth {
+			return &resolverError{
+				message: fmt.Sprintf("query depth %d exceeds the limit of %d", depth, l.MaxDepth),
+				code:    CodeQueryTooComplex,
+			}
+		}
+		if complexity > l.MaxComplexity {
+			return &resolverError{
+				message: fmt.Sprintf("query complexity %d exceeds the limit of %d", complexity, l.MaxComplexity),
+				code:    CodeQueryTooComplex,
+			}
+		}
+	}
+
+	return nil
+}
+
+// costWalker computes the depth and complexity of selection sets.
+type costWalker struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// selectionSet returns the depth and complexity of set, whose fields are at depth+1.
+func (w costWalker) selectionSet(set *ast.SelectionSet, depth int) (int, int) {
+	if set == nil {
+		return depth, 0
+	}
+
+	maxDepth, complexity := depth, 0
+	for _, selection := range set.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			d, c = w.selectionSet(s.SelectionSet, depth+1)
+			d = max(d, depth+1)
+			if listFields[s.Name.Value] {
+				c *= w.pageSize(s)
+			}
+			c++
+		case *ast.InlineFragment:
+			d, c = w.selectionSet(s.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			if fragment, ok := w.fragments[s.Name.Value]; ok {
+				d, c = w.selectionSet(fragment.SelectionSet, depth)
+			}
+		}
+		maxDepth = max(maxDepth, d)
+		complexity += c
+	}
+
+	return maxDepth, complexity
+}
+
+// pageSize returns the "first" argument of a connection field, resolving variables,
+// or dto.DefaultListLimit when it is not given.
+func (w costWalker) pageSize(field *ast.Field) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+
+		var n int
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			n, _ = strconv.Atoi(v.Value)
+		case *ast.Variable:
+			n = intVariable(w.variables[v.Name.Value])
+		}
+		if n > 0 {
+			return n
+		}
+	}
+
+	return dto.DefaultListLimit
+}
+
+// intVariable reads an Int variable as decoded from a JSON request body.
+func intVariable(v any) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	case json.Number:
+		i, _ := n.Int64()
+		return int(i)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,483 @@
+package graphqlapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+// resolver holds the dependencies of the field resolvers.
+type resolver struct {
+	productUC port.ProductUsecase
+	logger    port.Logger
+}
+
+// NewSchema builds the product schema. Every resolver goes through productUC, so the
+// GraphQL API enforces the same rules as the HTTP and gRPC ones.
+func NewSchema(productUC port.ProductUsecase, logger port.Logger) (graphql.Schema, error) {
+	r := &resolver{productUC: productUC, logger: logger}
+
+	priceType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Price",
+		Description: "A price in the currency's major units.",
+		Fields: graphql.Fields{
+			"amount": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: `Decimal amount, e.g. "12.30"; kept as text so it never passes through floating point.`,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(entity.Money).Decimal(), nil
+				},
+			},
+			"currency": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "ISO 4217 code.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Source.(entity.Money).Currency, nil
+				},
+			},
+		},
+	})
+
+	productType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Product",
+		Fields: graphql.Fields{
+			"id": productField(graphql.NewNonNull(graphql.ID), func(p *entity.Product) any {
+				return p.ID.String()
+			}),
+			"sku": productField(graphql.String, func(p *entity.Product) any {
+				if p.SKU == "" {
+					return nil
+				}
+				return string(p.SKU)
+			}),
+			"name": productField(graphql.NewNonNull(graphql.String), func(p *entity.Product) any {
+				return p.Name
+			}),
+			"qty": productField(graphql.NewNonNull(graphql.Int), func(p *entity.Product) any {
+				return p.Qty
+			}),
+			"price": productField(graphql.NewNonNull(priceType), func(p *entity.Product) any {
+				return p.Price
+			}),
+			"createdAt": productField(graphql.NewNonNull(graphql.DateTime), func(p *entity.Product) any {
+				return p.CreatedAt
+			}),
+			"updatedAt": productField(graphql.DateTime, func(p *entity.Product) any {
+				if p.UpdatedAt == nil {
+					return nil
+				}
+				return *p.UpdatedAt
+			}),
+			"deletedAt": productField(graphql.DateTime, func(p *entity.Product) any {
+				if p.DeletedAt == nil {
+					return nil
+				}
+				return *p.DeletedAt
+			}),
+			"version": productField(graphql.NewNonNull(graphql.Int), func(p *entity.Product) any {
+				return p.Version
+			}),
+		},
+	})
+
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ProductEdge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: graphql.NewNonNull(productType)},
+		},
+	})
+
+	pageInfoType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "PageInfo",
+		Fields: graphql.Fields{
+			"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"endCursor":   &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	connectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ProductConnection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType)))},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	})
+
+	sortFieldEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "ProductSortField",
+		Values: graphql.EnumValueConfigMap{
+			"CREATED_AT": &graphql.EnumValueConfig{Value: string(dto.SortByCreatedAt)},
+			"NAME":       &graphql.EnumValueConfig{Value: string(dto.SortByName)},
+			"PRICE":      &graphql.EnumValueConfig{Value: string(dto.SortByPrice)},
+		},
+	})
+
+	sortOrderEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "SortOrder",
+		Values: graphql.EnumValueConfigMap{
+			"ASC":  &graphql.EnumValueConfig{Value: string(dto.SortAsc)},
+			"DESC": &graphql.EnumValueConfig{Value: string(dto.SortDesc)},
+		},
+	})
+
+	filterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "ProductFilter",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"namePrefix": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"currency": &graphql.InputObjectFieldConfig{
+				Type:        graphql.String,
+				Description: "Currency of the price range and of a sort by price; defaults to the service currency.",
+			},
+			"minPrice":       &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Decimal amount."},
+			"maxPrice":       &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "Decimal amount."},
+			"minQty":         &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"maxQty":         &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"includeDeleted": &graphql.InputObjectFieldConfig{Type: graphql.Boolean, DefaultValue: false},
+		},
+	})
+
+	priceInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "PriceInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"amount": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String), Description: `Decimal amount, e.g. "12.30".`},
+			"currency": &graphql.InputObjectFieldConfig{
+				Type:        graphql.String,
+				Description: "ISO 4217 code; defaults to the product's current currency, or the service currency for a new product.",
+			},
+		},
+	})
+
+	createInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateProductInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"sku":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"name":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"qty":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"price": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(priceInputType)},
+		},
+	})
+
+	updateInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "UpdateProductInput",
+		Description: "Fields left out keep their stored value.",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"sku":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"name":  &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"qty":   &graphql.InputObjectFieldConfig{Type: graphql.Int},
+			"price": &graphql.InputObjectFieldConfig{Type: priceInputType},
+			"expectedVersion": &graphql.InputObjectFieldConfig{
+				Type:        graphql.Int,
+				Description: "Required. Version last read, or 0 to overwrite any version; PRECONDITION_FAILED if the product has changed since.",
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"product": &graphql.Field{
+				Type: productType,
+				Args: graphql.FieldConfigArgument{
+					"id":             &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"includeDeleted": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+				},
+				Resolve: r.product,
+			},
+			"products": &graphql.Field{
+				Type: graphql.NewNonNull(connectionType),
+				Args: graphql.FieldConfigArgument{
+					"filter": &graphql.ArgumentConfig{Type: filterType},
+					"first": &graphql.ArgumentConfig{
+						Type:         graphql.Int,
+						DefaultValue: dto.DefaultListLimit,
+						Description:  "Page size, at most 100.",
+					},
+					"after":  &graphql.ArgumentConfig{Type: graphql.String, Description: "Cursor of the edge to resume after."},
+					"sortBy": &graphql.ArgumentConfig{Type: sortFieldEnum, DefaultValue: string(dto.SortByCreatedAt)},
+					"order":  &graphql.ArgumentConfig{Type: sortOrderEnum, DefaultValue: string(dto.SortDesc)},
+				},
+				Resolve: r.products,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createProduct": &graphql.Field{
+				Type: graphql.NewNonNull(productType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createInputType)},
+				},
+				Resolve: r.createProduct,
+			},
+			"updateProduct": &graphql.Field{
+				Type: graphql.NewNonNull(productType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(updateInputType)},
+				},
+				Resolve: r.updateProduct,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// productField declares a Product field read from the *entity.Product being resolved.
+func productField(typ graphql.Output, get func(*entity.Product) any) *graphql.Field {
+	return &graphql.Field{
+		Type: typ,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return get(p.Source.(*entity.Product)), nil
+		},
+	}
+}
+
+func (r *resolver) product(p graphql.ResolveParams) (any, error) {
+	id, err := parseID("id", p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	includeDeleted, _ := p.Args["includeDeleted"].(bool)
+	product, err := r.productUC.GetByID(p.Context, dto.GetProductQuery{ID: id, IncludeDeleted: includeDeleted})
+	if err != nil {
+		return nil, toResolverError(p.Context, r.logger, err)
+	}
+
+	return product, nil
+}
+
+// connection is the resolved value of a ProductConnection.
+type connection struct {
+	Edges    []edge   `json:"edges"`
+	PageInfo pageInfo `json:"pageInfo"`
+}
+
+type edge struct {
+	Cursor string          `json:"cursor"`
+	Node   *entity.Product `json:"node"`
+}
+
+type pageInfo struct {
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   *string `json:"endCursor"`
+}
+
+func (r *resolver) products(p graphql.ResolveParams) (any, error) {
+	first, _ := p.Args["first"].(int)
+	if first < 0 {
+		return nil, badUserInput("first", "must not be negative")
+	}
+
+	query := dto.ListProductsQuery{Limit: first}
+	query.SortBy = dto.ProductSortField(stringArg(p.Args, "sortBy"))
+	query.Order = dto.SortOrder(stringArg(p.Args, "order"))
+
+	cursor, err := decodeCursor(stringArg(p.Args, "after"))
+	if err != nil {
+		return nil, err
+	}
+	query.Cursor = cursor
+
+	if filter, ok := p.Args["filter"].(map[string]any); ok {
+		if err := applyFilter(&query, filter); err != nil {
+			return nil, err
+		}
+	}
+	// Prices only compare within a currency; as in the HTTP API, sort the default one.
+	if query.SortBy == dto.SortByPrice && query.Currency == "" {
+		query.Currency = entity.DefaultCurrency
+	}
+
+	page, err := r.productUC.List(p.Context, query)
+	if err != nil {
+		return nil, toResolverError(p.Context, r.logger, err)
+	}
+
+	conn := connection{
+		Edges:    make([]edge, 0, len(page.Items)),
+		PageInfo: pageInfo{HasNextPage: page.HasMore},
+	}
+	for i := range page.Items {
+		item := &page.Items[i]
+		conn.Edges = append(conn.Edges, edge{
+			Cursor: encodeCursor(dto.ProductCursor{
+				SortBy:    query.SortBy,
+				Order:     query.Order,
+				Name:      item.Name,
+				Price:     item.Price.Amount,
+				CreatedAt: item.CreatedAt,
+				ID:        item.ID,
+			}),
+			Node: item,
+		})
+	}
+	if n := len(conn.Edges); n > 0 {
+		conn.PageInfo.EndCursor = &conn.Edges[n-1].Cursor
+	}
+
+	return conn, nil
+}
+
+// applyFilter copies a ProductFilter argument into query. Price bounds are decimal
+// amounts of the filter's currency, entity.DefaultCurrency when none is given, as in
+// the HTTP API.
+func applyFilter(query *dto.ListProductsQuery, filter map[string]any) error {
+	query.NamePrefix = stringArg(filter, "namePrefix")
+	query.Currency = stringArg(filter, "currency")
+	query.IncludeDeleted, _ = filter["includeDeleted"].(bool)
+	if minQty, ok := filter["minQty"].(int); ok {
+		query.MinQty = &minQty
+	}
+	if maxQty, ok := filter["maxQty"].(int); ok {
+		query.MaxQty = &maxQty
+	}
+
+	minPrice, hasMin := filter["minPrice"].(string)
+	maxPrice, hasMax := filter["maxPrice"].(string)
+	if query.Currency == "" && (hasMin || hasMax) {
+		query.Currency = entity.DefaultCurrency
+	}
+
+	for _, bound := range []struct {
+		field string
+		value string
+		set   bool
+		dest  **int64
+	}{
+		{"filter.minPrice", minPrice, hasMin, &query.MinPrice},
+		{"filter.maxPrice", maxPrice, hasMax, &query.MaxPrice},
+	} {
+		if !bound.set {
+			continue
+		}
+		price, err := entity.ParseMoney(bound.value, query.Currency)
+		if err != nil {
+			return badUserInput(bound.field, err.Error())
+		}
+		*bound.dest = &price.Amount
+	}
+
+	return nil
+}
+
+func (r *resolver) createProduct(p graphql.ResolveParams) (any, error) {
+	input, _ := p.Args["input"].(map[string]any)
+	qty, _ := input["qty"].(int)
+	price, _ := input["price"].(map[string]any)
+
+	product, err := r.productUC.CreateProduct(p.Context, dto.CreateProductInput{
+		SKU:   stringArg(input, "sku"),
+		Name:  stringArg(input, "name"),
+		Qty:   qty,
+		Price: toPriceInput(price),
+	})
+	if err != nil {
+		return nil, toResolverError(p.Context, r.logger, err)
+	}
+
+	return product, nil
+}
+
+func (r *resolver) updateProduct(p graphql.ResolveParams) (any, error) {
+	id, err := parseID("id", p.Args["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	input, _ := p.Args["input"].(map[string]any)
+	patch := dto.PatchProductInput{ID: id}
+	if sku, ok := input["sku"].(string); ok {
+		patch.SKU = &sku
+	}
+	if name, ok := input["name"].(string); ok {
+		patch.Name = &name
+	}
+	if qty, ok := input["qty"].(int); ok {
+		patch.Qty = &qty
+	}
+	if price, ok := input["price"].(map[string]any); ok {
+		priceInput := toPriceInput(price)
+		patch.Price = &priceInput
+	}
+	version, ok := input["expectedVersion"].(int)
+	if !ok {
+		return nil, &resolverError{message: "expectedVersion is required", code: CodePreconditionRequired}
+	}
+	patch.ExpectedVersion = int64(version)
+
+	product, err := r.productUC.PatchProduct(p.Context, patch)
+	if err != nil {
+		return nil, toResolverError(p.Context, r.logger, err)
+	}
+
+	return product, nil
+}
+
+func toPriceInput(price map[string]any) dto.PriceInput {
+	return dto.PriceInput{Amount: stringArg(price, "amount"), Currency: stringArg(price, "currency")}
+}
+
+// stringArg returns the string argument named key, or "" when it was not given.
+func stringArg(args map[string]any, key string) string {
+	s, _ := args[key].(string)
+	return s
+}
+
+func parseID(field string, raw any) (uuid.UUID, error) {
+	s, _ := raw.(string)
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, badUserInput(field, "must be a UUID")
+	}
+
+	return id, nil
+}
+
+// cursorToken is the wire format of an edge cursor. Clients must treat the encoded
+// value as opaque.
+type cursorToken struct {
+	SortBy    dto.ProductSortField `json:"s"`
+	Order     dto.SortOrder        `json:"o"`
+	Name      string               `json:"n,omitempty"`
+	Price     int64                `json:"m,omitempty"` // minor units
+	CreatedAt time.Time            `json:"t"`
+	ID        uuid.UUID            `json:"i"`
+}
+
+// encodeCursor turns the position of a product into an opaque cursor.
+func encodeCursor(cursor dto.ProductCursor) string {
+	// Marshalling a struct of plain values cannot fail.
+	raw, _ := json.Marshal(cursorToken(cursor))
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor parses a cursor made by encodeCursor; "" yields a nil cursor.
+func decodeCursor(cursor string) (*dto.ProductCursor, error) {
+	if cursor == "" {
+		return nil, nil //nolint:nilnil // no cursor means "start from the first page"
+	}
+
+	var decoded cursorToken
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		err = json.Unmarshal(raw, &decoded)
+	}
+	if err != nil || decoded.ID == uuid.Nil || decoded.CreatedAt.IsZero() {
+		return nil, badUserInput("after", "not issued by this service")
+	}
+
+	position := dto.ProductCursor(decoded)
+	return &position, nil
+}
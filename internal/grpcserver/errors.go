@@ -0,0 +1,89 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/publicerr"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CodeForKind maps an apperror kind to its gRPC status code.
+// This is the only place where domain errors meet gRPC, like controller.StatusForKind for HTTP.
+func CodeForKind(kind apperror.Kind) codes.Code {
+	switch kind {
+	case apperror.Invalid:
+		return codes.InvalidArgument
+	case apperror.NotFound:
+		return codes.NotFound
+	case apperror.Conflict:
+		return codes.AlreadyExists
+	case apperror.PreconditionFailed:
+		// Optimistic concurrency: the client should re-read and retry.
+		return codes.Aborted
+	case apperror.PreconditionRequired, apperror.Unprocessable:
+		return codes.FailedPrecondition
+	case apperror.Forbidden:
+		return codes.PermissionDenied
+	case apperror.Unavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
+
+// toStatus converts an error returned by a usecase into a gRPC status error, carrying
+// what publicerr lets the client see. The offending fields of invalid input are listed
+// in a BadRequest detail; unexpected errors are logged here.
+func toStatus(ctx context.Context, log port.Logger, err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	public := publicerr.Of(err)
+	if public.Internal {
+		log.Error(ctx, "request failed", "kind", public.Kind.String(), "error", err)
+		return status.Error(codes.Internal, public.Message)
+	}
+
+	st := status.New(CodeForKind(public.Kind), public.Message)
+	if len(public.Fields) == 0 {
+		return st.Err()
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(public.Fields))
+	for _, f := range public.Fields {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       f.Field,
+			Description: f.Message,
+			Reason:      f.Code,
+		})
+	}
+	detailed, detailErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// invalidArgument reports a malformed request field, e.g. an ID that is not a UUID.
+func invalidArgument(field, msg string) error {
+	st := status.New(codes.InvalidArgument, field+": "+msg)
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: msg, Reason: entity.CodeFormat}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
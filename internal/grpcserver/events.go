@@ -0,0 +1,100 @@
+package grpcserver
+
+import (
+	"context"
+	"sync"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+)
+
+// DefaultWatchBuffer is how many events a watcher may lag behind before it is disconnected.
+const DefaultWatchBuffer = 256
+
+// EventHub fans product events out to WatchProducts streams.
+//
+// Its Handle method listens to a port.EventBroadcaster, so watchers see a change once
+// the relay of any instance has published it, with the publisher's at-least-once
+// guarantee.
+// Handle never blocks on a watcher: one whose buffer is full is dropped, and its
+// stream ends with RESOURCE_EXHAUSTED so the client knows to reconnect.
+type EventHub struct {
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+	buffer   int
+	closed   bool
+	done     chan struct{}
+}
+
+// watcher is a WatchProducts stream subscribed to the hub.
+type watcher struct {
+	events  chan entity.Event
+	dropped chan struct{} // closed when the watcher fell behind
+}
+
+// NewEventHub creates a hub whose watchers may lag up to buffer events behind;
+// buffer <= 0 means DefaultWatchBuffer.
+func NewEventHub(buffer int) *EventHub {
+	if buffer <= 0 {
+		buffer = DefaultWatchBuffer
+	}
+
+	return &EventHub{
+		watchers: make(map[*watcher]struct{}),
+		buffer:   buffer,
+		done:     make(chan struct{}),
+	}
+}
+
+// Handle hands a published event to every watcher. It never fails, so a slow watcher
+// cannot make the relay retry the event for the other subscribers.
+func (h *EventHub) Handle(_ context.Context, event entity.Event) error {
+	switch event.Type {
+	case entity.EventProductCreated, entity.EventProductUpdated, entity.EventProductDeleted:
+	default:
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for w := range h.watchers {
+		select {
+		case w.events <- event:
+		default:
+			delete(h.watchers, w)
+			close(w.dropped)
+		}
+	}
+
+	return nil
+}
+
+// Close ends every watch stream, which would otherwise keep a graceful stop waiting forever.
+// Watchers subscribing afterwards are ended immediately.
+func (h *EventHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.closed {
+		h.closed = true
+		close(h.done)
+	}
+}
+
+// subscribe registers a watcher; the returned function unregisters it.
+func (h *EventHub) subscribe() (*watcher, func()) {
+	w := &watcher{
+		events:  make(chan entity.Event, h.buffer),
+		dropped: make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.watchers[w] = struct{}{}
+
+	return w, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.watchers, w)
+	}
+}
@@ -0,0 +1,165 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataRequestID carries the correlation ID of a call, like the X-Request-ID header.
+const MetadataRequestID = "x-request-id"
+
+// maxRequestIDLength bounds client-supplied IDs so they cannot bloat logs.
+const maxRequestIDLength = 128
+
+// RequestIDUnary and RequestIDStream accept the x-request-id metadata sent by the caller
+// or generate a new UUID, store it in the call context and send it back in the header.
+// They must run before any interceptor that logs, so every record carries the ID.
+func RequestIDUnary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withRequestID(ctx), req)
+	}
+}
+
+// RequestIDStream is the streaming counterpart of RequestIDUnary.
+func RequestIDStream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &contextStream{ServerStream: ss, ctx: withRequestID(ss.Context())})
+	}
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataRequestID); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if !validRequestID(id) {
+		id = requestid.New()
+	}
+
+	// Failing to send the header only loses the echo; the call itself is unaffected.
+	_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataRequestID, id))
+
+	return requestid.WithID(ctx, id)
+}
+
+// validRequestID accepts 1 to maxRequestIDLength characters of printable ASCII without
+// spaces, so a client cannot inject control characters into logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LoggingUnary and LoggingStream attach the method to the call context, so every record
+// logged downstream carries it, and write one access log record per call with its
+// status code and latency.
+func LoggingUnary(log port.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx = logger.WithAttrs(ctx, slog.String("grpc_method", info.FullMethod))
+
+		resp, err := handler(ctx, req)
+		logCall(ctx, log, start, err)
+
+		return resp, err
+	}
+}
+
+// LoggingStream is the streaming counterpart of LoggingUnary; the record is written when
+// the stream ends.
+func LoggingStream(log port.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := logger.WithAttrs(ss.Context(), slog.String("grpc_method", info.FullMethod))
+
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		logCall(ctx, log, start, err)
+
+		return err
+	}
+}
+
+func logCall(ctx context.Context, log port.Logger, start time.Time, err error) {
+	code := status.Code(err)
+	args := []any{
+		slog.String("grpc_code", code.String()),
+		slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		args = append(args, slog.String("peer", p.Addr.String()))
+	}
+	if err != nil {
+		args = append(args, slog.String("error", status.Convert(err).Message()))
+	}
+
+	switch code {
+	case codes.OK, codes.Canceled:
+		log.Info(ctx, "call completed", args...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unimplemented:
+		log.Error(ctx, "call completed", args...)
+	default:
+		log.Warn(ctx, "call completed", args...)
+	}
+}
+
+// RecoveryUnary and RecoveryStream turn a panic in a handler into an INTERNAL error,
+// logging the panic value and stack, so one bad call cannot crash the process.
+func RecoveryUnary(log port.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, log, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStream is the streaming counterpart of RecoveryUnary.
+func RecoveryStream(log port.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), log, r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+func recovered(ctx context.Context, log port.Logger, r any) error {
+	log.Error(ctx, "panic recovered", "panic", r, "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal server error")
+}
+
+// contextStream overrides the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context //nolint:containedctx // the stream's context is what is being replaced
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
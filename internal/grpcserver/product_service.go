@@ -0,0 +1,350 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	productv1 "github.com/DucTran999/go-clean-archx/api/product/v1"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// productService implements productv1.ProductServiceServer on top of a ProductUsecase.
+type productService struct {
+	productv1.UnimplementedProductServiceServer
+
+	productUC port.ProductUsecase
+	events    *EventHub
+	logger    port.Logger
+}
+
+// NewProductService returns the gRPC product service. WatchProducts streams the events
+// handed to events, which must listen to the events broadcast by the outbox relay.
+func NewProductService(productUC port.ProductUsecase, events *EventHub, logger port.Logger) productv1.ProductServiceServer {
+	return &productService{
+		productUC: productUC,
+		events:    events,
+		logger:    logger,
+	}
+}
+
+func (s *productService) CreateProduct(ctx context.Context, req *productv1.CreateProductRequest) (*productv1.Product, error) {
+	product, err := s.productUC.CreateProduct(ctx, dto.CreateProductInput{
+		SKU:   req.GetSku(),
+		Name:  req.GetName(),
+		Qty:   int(req.GetQty()),
+		Price: toPriceInput(req.GetPrice()),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	return toProtoProduct(product), nil
+}
+
+func (s *productService) GetProduct(ctx context.Context, req *productv1.GetProductRequest) (*productv1.Product, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.productUC.GetByID(ctx, dto.GetProductQuery{ID: id, IncludeDeleted: req.GetIncludeDeleted()})
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	return toProtoProduct(product), nil
+}
+
+func (s *productService) ListProducts(ctx context.Context, req *productv1.ListProductsRequest) (*productv1.ListProductsResponse, error) {
+	cursor, err := decodePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	query := dto.ListProductsQuery{
+		Cursor:         cursor,
+		Limit:          int(req.GetPageSize()),
+		SortBy:         sortFields[req.GetSortBy()],
+		Order:          sortOrders[req.GetOrder()],
+		NamePrefix:     req.GetNamePrefix(),
+		Currency:       req.GetCurrency(),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	}
+	if query.SortBy == "" {
+		return nil, invalidArgument("sort_by", "unsupported sort field")
+	}
+	if query.Order == "" {
+		return nil, invalidArgument("order", "unsupported sort order")
+	}
+	if req.MinQty != nil {
+		minQty := int(req.GetMinQty())
+		query.MinQty = &minQty
+	}
+	if req.MaxQty != nil {
+		maxQty := int(req.GetMaxQty())
+		query.MaxQty = &maxQty
+	}
+	if err := setPriceRange(&query, req.MinPrice, req.MaxPrice); err != nil {
+		return nil, err
+	}
+
+	page, err := s.productUC.List(ctx, query)
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	resp := &productv1.ListProductsResponse{
+		Products:      make([]*productv1.Product, 0, len(page.Items)),
+		NextPageToken: encodePageToken(page.NextCursor),
+	}
+	for i := range page.Items {
+		resp.Products = append(resp.Products, toProtoProduct(&page.Items[i]))
+	}
+
+	return resp, nil
+}
+
+func (s *productService) UpdateProduct(ctx context.Context, req *productv1.UpdateProductRequest) (*productv1.Product, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	// Unlike dto.PatchProductInput, 0 does not mean "any version": it is what a client
+	// that never set the field sends, so it must not overwrite whatever is stored.
+	if req.GetExpectedVersion() == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "expected_version is required")
+	}
+
+	input := dto.PatchProductInput{
+		ID:              id,
+		SKU:             req.Sku,
+		Name:            req.Name,
+		ExpectedVersion: req.GetExpectedVersion(),
+	}
+	if req.Qty != nil {
+		qty := int(req.GetQty())
+		input.Qty = &qty
+	}
+	if req.Price != nil {
+		price := toPriceInput(req.GetPrice())
+		input.Price = &price
+	}
+
+	product, err := s.productUC.PatchProduct(ctx, input)
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	return toProtoProduct(product), nil
+}
+
+func (s *productService) DeleteProduct(ctx context.Context, req *productv1.DeleteProductRequest) (*productv1.DeleteProductResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.productUC.DeleteProduct(ctx, id); err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	return &productv1.DeleteProductResponse{}, nil
+}
+
+func (s *productService) WatchProducts(req *productv1.WatchProductsRequest, stream productv1.ProductService_WatchProductsServer) error {
+	ctx := stream.Context()
+
+	var only map[uuid.UUID]bool
+	for _, raw := range req.GetProductIds() {
+		id, err := parseID("product_ids", raw)
+		if err != nil {
+			return err
+		}
+		if only == nil {
+			only = make(map[uuid.UUID]bool)
+		}
+		only[id] = true
+	}
+
+	w, unsubscribe := s.events.subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-s.events.done:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case <-w.dropped:
+			return status.Error(codes.ResourceExhausted, "watcher fell behind; reconnect and re-read the products")
+		case event := <-w.events:
+			if only != nil && !only[event.AggregateID] {
+				continue
+			}
+			msg, err := toProtoEvent(event)
+			if err != nil {
+				s.logger.Error(ctx, "failed to decode product event", "event_id", event.ID, "error", err)
+				continue
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sortFields and sortOrders map the wire enums to the usecase values; the zero
+// values select the defaults of the HTTP API.
+var (
+	sortFields = map[productv1.ProductSortField]dto.ProductSortField{
+		productv1.ProductSortField_PRODUCT_SORT_FIELD_UNSPECIFIED: dto.SortByCreatedAt,
+		productv1.ProductSortField_PRODUCT_SORT_FIELD_CREATED_AT:  dto.SortByCreatedAt,
+		productv1.ProductSortField_PRODUCT_SORT_FIELD_NAME:        dto.SortByName,
+		productv1.ProductSortField_PRODUCT_SORT_FIELD_PRICE:       dto.SortByPrice,
+	}
+	sortOrders = map[productv1.SortOrder]dto.SortOrder{
+		productv1.SortOrder_SORT_ORDER_UNSPECIFIED: dto.SortDesc,
+		productv1.SortOrder_SORT_ORDER_ASC:         dto.SortAsc,
+		productv1.SortOrder_SORT_ORDER_DESC:        dto.SortDesc,
+	}
+)
+
+// setPriceRange converts the decimal price bounds into minor units of the query's
+// currency. As in the HTTP API, a price range or a sort by price without a currency
+// uses entity.DefaultCurrency.
+func setPriceRange(query *dto.ListProductsQuery, minPrice, maxPrice *string) error {
+	if query.Currency == "" && (minPrice != nil || maxPrice != nil || query.SortBy == dto.SortByPrice) {
+		query.Currency = entity.DefaultCurrency
+	}
+
+	for _, bound := range []struct {
+		field string
+		value *string
+		dest  **int64
+	}{
+		{"min_price", minPrice, &query.MinPrice},
+		{"max_price", maxPrice, &query.MaxPrice},
+	} {
+		if bound.value == nil {
+			continue
+		}
+		price, err := entity.ParseMoney(*bound.value, query.Currency)
+		if err != nil {
+			return invalidArgument(bound.field, err.Error())
+		}
+		*bound.dest = &price.Amount
+	}
+
+	return nil
+}
+
+func parseID(field, raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, invalidArgument(field, "must be a UUID")
+	}
+
+	return id, nil
+}
+
+func toPriceInput(price *productv1.Price) dto.PriceInput {
+	return dto.PriceInput{Amount: price.GetAmount(), Currency: price.GetCurrency()}
+}
+
+func toProtoProduct(p *entity.Product) *productv1.Product {
+	product := &productv1.Product{
+		Id:         p.ID.String(),
+		Sku:        string(p.SKU),
+		Name:       p.Name,
+		Qty:        int32(p.Qty), //nolint:gosec // quantities are stored as a PostgreSQL integer
+		Price:      &productv1.Price{Amount: p.Price.Decimal(), Currency: p.Price.Currency},
+		PriceMinor: p.Price.Amount,
+		CreatedAt:  timestamppb.New(p.CreatedAt),
+		Version:    p.Version,
+	}
+	if p.UpdatedAt != nil {
+		product.UpdatedAt = timestamppb.New(*p.UpdatedAt)
+	}
+	if p.DeletedAt != nil {
+		product.DeletedAt = timestamppb.New(*p.DeletedAt)
+	}
+
+	return product
+}
+
+// eventTypes maps the domain event types to the wire enum.
+var eventTypes = map[entity.EventType]productv1.ProductEvent_Type{
+	entity.EventProductCreated: productv1.ProductEvent_TYPE_CREATED,
+	entity.EventProductUpdated: productv1.ProductEvent_TYPE_UPDATED,
+	entity.EventProductDeleted: productv1.ProductEvent_TYPE_DELETED,
+}
+
+// toProtoEvent converts a product event; the product is decoded from the event payload,
+// which is the product itself for every type but deletions.
+func toProtoEvent(event entity.Event) (*productv1.ProductEvent, error) {
+	msg := &productv1.ProductEvent{
+		EventId:    event.ID.String(),
+		Type:       eventTypes[event.Type],
+		ProductId:  event.AggregateID.String(),
+		OccurredAt: timestamppb.New(event.OccurredAt),
+	}
+	if event.Type != entity.EventProductDeleted {
+		var product entity.Product
+		if err := json.Unmarshal(event.Payload, &product); err != nil {
+			return nil, err
+		}
+		msg.Product = toProtoProduct(&product)
+	}
+
+	return msg, nil
+}
+
+// pageToken is the wire format of a product cursor. Clients must treat the encoded
+// value as opaque.
+type pageToken struct {
+	SortBy    dto.ProductSortField `json:"s"`
+	Order     dto.SortOrder        `json:"o"`
+	Name      string               `json:"n,omitempty"`
+	Price     int64                `json:"m,omitempty"` // minor units
+	CreatedAt time.Time            `json:"t"`
+	ID        uuid.UUID            `json:"i"`
+}
+
+// encodePageToken turns a cursor into an opaque token; nil yields "", the last page.
+func encodePageToken(cursor *dto.ProductCursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	// Marshalling a struct of plain values cannot fail.
+	raw, _ := json.Marshal(pageToken(*cursor))
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodePageToken parses a token made by encodePageToken; "" yields a nil cursor.
+func decodePageToken(token string) (*dto.ProductCursor, error) {
+	if token == "" {
+		return nil, nil //nolint:nilnil // no token means "start from the first page"
+	}
+
+	var decoded pageToken
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		err = json.Unmarshal(raw, &decoded)
+	}
+	if err != nil || decoded.ID == uuid.Nil || decoded.CreatedAt.IsZero() {
+		return nil, invalidArgument("page_token", "not issued by this service")
+	}
+
+	cursor := dto.ProductCursor(decoded)
+	return &cursor, nil
+}
@@ -0,0 +1,396 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	productv1 "github.com/DucTran999/go-clean-archx/api/product/v1"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/grpcserver"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/internal/usecase"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mockbuilder"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// testServer is a Server listening on an in-memory connection.
+type testServer struct {
+	client productv1.ProductServiceClient
+	events *grpcserver.EventHub
+	stop   context.CancelFunc
+	done   <-chan error
+}
+
+// startServer serves productUC over bufconn and returns a connected client.
+func startServer(t *testing.T, productUC port.ProductUsecase, dialOpts ...grpc.DialOption) *testServer {
+	t.Helper()
+
+	ln := bufconn.Listen(1 << 20)
+	events := grpcserver.NewEventHub(grpcserver.DefaultWatchBuffer)
+	srv := grpcserver.New(productUC, events, grpcserver.Options{ShutdownTimeout: time.Second, Logger: logger.NewNop()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+
+	dialOpts = append(dialOpts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOpts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return &testServer{client: productv1.NewProductServiceClient(conn), events: events, stop: cancel, done: done}
+}
+
+// newMemoryUsecase returns a product usecase backed by the in-memory repositories.
+func newMemoryUsecase() port.ProductUsecase {
+	return usecase.NewProductUsecase(
+		repository.NewMemoryProductRepository(), repository.NewMemoryOutboxRepository(),
+		repository.NewMemoryTxManager(), logger.NewNop(),
+	)
+}
+
+func TestProductService_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	srv := startServer(t, newMemoryUsecase())
+	ctx := t.Context()
+
+	// Act & Assert
+	created, err := srv.client.CreateProduct(ctx, &productv1.CreateProductRequest{
+		Sku: "mug-1", Name: "Mug", Qty: 3, Price: &productv1.Price{Amount: "12.3"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "MUG-1", created.GetSku())
+	assert.Equal(t, "12.30", created.GetPrice().GetAmount())
+	assert.Equal(t, entity.DefaultCurrency, created.GetPrice().GetCurrency())
+	assert.Equal(t, int64(1230), created.GetPriceMinor())
+	assert.Equal(t, int64(1), created.GetVersion())
+
+	got, err := srv.client.GetProduct(ctx, &productv1.GetProductRequest{Id: created.GetId()})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(created, got))
+
+	updated, err := srv.client.UpdateProduct(ctx, &productv1.UpdateProductRequest{
+		Id: created.GetId(), Qty: proto.Int32(0), ExpectedVersion: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), updated.GetQty())
+	assert.Equal(t, "Mug", updated.GetName(), "fields not set are kept")
+	assert.Equal(t, int64(2), updated.GetVersion())
+
+	_, err = srv.client.UpdateProduct(ctx, &productv1.UpdateProductRequest{
+		Id: created.GetId(), Name: proto.String("Cup"), ExpectedVersion: 1,
+	})
+	assert.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = srv.client.UpdateProduct(ctx, &productv1.UpdateProductRequest{
+		Id: created.GetId(), Name: proto.String("Cup"),
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "expected_version is required")
+
+	_, err = srv.client.DeleteProduct(ctx, &productv1.DeleteProductRequest{Id: created.GetId()})
+	require.NoError(t, err)
+
+	_, err = srv.client.GetProduct(ctx, &productv1.GetProductRequest{Id: created.GetId()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	deleted, err := srv.client.GetProduct(ctx, &productv1.GetProductRequest{Id: created.GetId(), IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.NotNil(t, deleted.GetDeletedAt())
+}
+
+func TestProductService_ListProducts(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	srv := startServer(t, newMemoryUsecase())
+	ctx := t.Context()
+	for _, p := range []struct {
+		name  string
+		qty   int32
+		price string
+	}{{"Cup", 1, "2.50"}, {"Bowl", 5, "4"}, {"Plate", 9, "6"}} {
+		_, err := srv.client.CreateProduct(ctx, &productv1.CreateProductRequest{
+			Name: p.name, Qty: p.qty, Price: &productv1.Price{Amount: p.price},
+		})
+		require.NoError(t, err)
+	}
+
+	// Act: page through by name, one product per page
+	var names []string
+	req := &productv1.ListProductsRequest{
+		PageSize: 1,
+		SortBy:   productv1.ProductSortField_PRODUCT_SORT_FIELD_NAME,
+		Order:    productv1.SortOrder_SORT_ORDER_ASC,
+	}
+	for {
+		resp, err := srv.client.ListProducts(ctx, req)
+		require.NoError(t, err)
+		for _, p := range resp.GetProducts() {
+			names = append(names, p.GetName())
+		}
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+
+	filtered, err := srv.client.ListProducts(ctx, &productv1.ListProductsRequest{
+		SortBy:   productv1.ProductSortField_PRODUCT_SORT_FIELD_PRICE,
+		Order:    productv1.SortOrder_SORT_ORDER_ASC,
+		MinPrice: proto.String("3"),
+		MaxQty:   proto.Int32(5),
+	})
+	require.NoError(t, err)
+
+	_, badToken := srv.client.ListProducts(ctx, &productv1.ListProductsRequest{PageToken: "not-a-token"})
+	_, badPrice := srv.client.ListProducts(ctx, &productv1.ListProductsRequest{MinPrice: proto.String("cheap")})
+
+	// Assert
+	assert.Equal(t, []string{"Bowl", "Cup", "Plate"}, names)
+	require.Len(t, filtered.GetProducts(), 1)
+	assert.Equal(t, "Bowl", filtered.GetProducts()[0].GetName())
+	assert.Equal(t, codes.InvalidArgument, status.Code(badToken))
+	assert.Equal(t, codes.InvalidArgument, status.Code(badPrice))
+}
+
+func TestProductService_ErrorCodes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T) port.ProductUsecase
+		call       func(ctx context.Context, client productv1.ProductServiceClient) error
+		wantCode   codes.Code
+		wantMsg    string
+		wantFields bool
+	}{
+		{
+			name: "validation failure lists the fields",
+			setup: func(*testing.T) port.ProductUsecase {
+				return newMemoryUsecase()
+			},
+			call: func(ctx context.Context, client productv1.ProductServiceClient) error {
+				_, err := client.CreateProduct(ctx, &productv1.CreateProductRequest{Qty: -1, Price: &productv1.Price{Amount: "1"}})
+				return err
+			},
+			wantCode:   codes.InvalidArgument,
+			wantMsg:    entity.ErrProductInvalid.Error(),
+			wantFields: true,
+		},
+		{
+			name: "malformed id",
+			setup: func(t *testing.T) port.ProductUsecase {
+				return mockbuilder.NewProductUsecaseBuilder(t).Build()
+			},
+			call: func(ctx context.Context, client productv1.ProductServiceClient) error {
+				_, err := client.GetProduct(ctx, &productv1.GetProductRequest{Id: "42"})
+				return err
+			},
+			wantCode:   codes.InvalidArgument,
+			wantMsg:    "id: must be a UUID",
+			wantFields: true,
+		},
+		{
+			name: "database constraint violation is not leaked",
+			setup: func(t *testing.T) port.ProductUsecase {
+				return mockbuilder.NewProductUsecaseBuilder(t).CreateProductReturnsCheckViolation().Build()
+			},
+			call: func(ctx context.Context, client productv1.ProductServiceClient) error {
+				_, err := client.CreateProduct(ctx, &productv1.CreateProductRequest{Name: "Hat"})
+				return err
+			},
+			wantCode: codes.InvalidArgument,
+			wantMsg:  "value violates a database constraint",
+		},
+		{
+			name: "sku taken",
+			setup: func(t *testing.T) port.ProductUsecase {
+				return mockbuilder.NewProductUsecaseBuilder(t).CreateProductReturnsSKUTaken().Build()
+			},
+			call: func(ctx context.Context, client productv1.ProductServiceClient) error {
+				_, err := client.CreateProduct(ctx, &productv1.CreateProductRequest{Sku: "HAT-01", Name: "Hat"})
+				return err
+			},
+			wantCode: codes.AlreadyExists,
+			wantMsg:  entity.ErrProductSKUTaken.Error(),
+		},
+		{
+			name: "unexpected error is not leaked",
+			setup: func(t *testing.T) port.ProductUsecase {
+				return mockbuilder.NewProductUsecaseBuilder(t).CreateProductReturnErrDB().Build()
+			},
+			call: func(ctx context.Context, client productv1.ProductServiceClient) error {
+				_, err := client.CreateProduct(ctx, &productv1.CreateProductRequest{Name: "Hat"})
+				return err
+			},
+			wantCode: codes.Internal,
+			wantMsg:  "internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			srv := startServer(t, tt.setup(t))
+
+			// Act
+			err := tt.call(t.Context(), srv.client)
+
+			// Assert
+			st := status.Convert(err)
+			assert.Equal(t, tt.wantCode, st.Code())
+			assert.Contains(t, st.Message(), tt.wantMsg)
+			assert.NotContains(t, st.Message(), datatest.ErrUnexpectedDB.Error())
+			assert.NotContains(t, st.Message(), datatest.CheckViolationCode)
+			assert.NotContains(t, st.Message(), datatest.CheckViolationConstraint)
+			if tt.wantFields {
+				require.Len(t, st.Details(), 1)
+				badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+				require.True(t, ok)
+				assert.NotEmpty(t, badRequest.GetFieldViolations())
+			}
+		})
+	}
+}
+
+func TestProductService_WatchProducts(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	srv := startServer(t, newMemoryUsecase())
+	ctx := t.Context()
+	watched := &entity.Product{ID: datatest.FakeProductID, Name: "Mug", Price: entity.NewMoney(1230, "USD"), Version: 2}
+	other := &entity.Product{ID: uuid.New(), Name: "Cup", Price: entity.NewMoney(250, "USD"), Version: 1}
+
+	stream, err := srv.client.WatchProducts(ctx, &productv1.WatchProductsRequest{ProductIds: []string{watched.ID.String()}})
+	require.NoError(t, err)
+	waitForWatcher(t, srv.events, stream, watched)
+
+	// Act
+	require.NoError(t, srv.events.Handle(ctx, entity.NewProductUpdated(other)))
+	require.NoError(t, srv.events.Handle(ctx, entity.NewProductDeleted(watched.ID)))
+
+	// Assert: the event about the other product is filtered out
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, productv1.ProductEvent_TYPE_DELETED, event.GetType())
+	assert.Equal(t, watched.ID.String(), event.GetProductId())
+	assert.Nil(t, event.GetProduct())
+
+	// Shutting down ends the stream instead of waiting for it.
+	srv.stop()
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	require.NoError(t, <-srv.done)
+}
+
+func TestProductService_WatchProductsDropsSlowWatcher(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: a fixed flow-control window, so the server blocks once the client stops reading.
+	srv := startServer(t, newMemoryUsecase(), grpc.WithInitialWindowSize(64<<10))
+	ctx := t.Context()
+	product := &entity.Product{ID: datatest.FakeProductID, Name: "Mug", Price: entity.NewMoney(1230, "USD")}
+
+	stream, err := srv.client.WatchProducts(ctx, &productv1.WatchProductsRequest{})
+	require.NoError(t, err)
+	waitForWatcher(t, srv.events, stream, product)
+
+	// Act: publish far more than the window and the watch buffer hold
+	for range 20 * grpcserver.DefaultWatchBuffer {
+		require.NoError(t, srv.events.Handle(ctx, entity.NewProductDeleted(product.ID)))
+	}
+
+	// Assert: the events already sent arrive, then the stream ends
+	for {
+		_, err = stream.Recv()
+		if err != nil {
+			break
+		}
+	}
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+// waitForWatcher publishes an event about p until the stream receives it, which proves
+// the watcher is subscribed.
+func waitForWatcher(t *testing.T, events *grpcserver.EventHub, stream grpc.ServerStreamingClient[productv1.ProductEvent], p *entity.Product) {
+	t.Helper()
+
+	received := make(chan error, 1)
+	go func() {
+		event, err := stream.Recv()
+		if err == nil && event.GetProduct().GetName() != p.Name {
+			err = errors.New("unexpected event")
+		}
+		received <- err
+	}()
+
+	for {
+		require.NoError(t, events.Handle(t.Context(), entity.NewProductCreated(p)))
+		select {
+		case err := <-received:
+			require.NoError(t, err)
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("request id is echoed or generated", func(t *testing.T) {
+		t.Parallel()
+
+		srv := startServer(t, mockbuilder.NewProductUsecaseBuilder(t).GetByIDSuccess().Build())
+
+		var header metadata.MD
+		ctx := metadata.AppendToOutgoingContext(t.Context(), grpcserver.MetadataRequestID, "req-123")
+		_, err := srv.client.GetProduct(ctx, &productv1.GetProductRequest{Id: datatest.FakeProductID.String()}, grpc.Header(&header))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"req-123"}, header.Get(grpcserver.MetadataRequestID))
+
+		ctx = metadata.AppendToOutgoingContext(t.Context(), grpcserver.MetadataRequestID, "bad id\n")
+		_, err = srv.client.GetProduct(ctx, &productv1.GetProductRequest{Id: "42"}, grpc.Header(&header))
+		require.Error(t, err)
+		require.Len(t, header.Get(grpcserver.MetadataRequestID), 1)
+		assert.NotEqual(t, "bad id\n", header.Get(grpcserver.MetadataRequestID)[0])
+	})
+
+	t.Run("panic becomes an internal error", func(t *testing.T) {
+		t.Parallel()
+
+		srv := startServer(t, mockbuilder.NewProductUsecaseBuilder(t).GetByIDPanics().Build())
+
+		_, err := srv.client.GetProduct(t.Context(), &productv1.GetProductRequest{Id: datatest.FakeProductID.String()})
+		assert.Equal(t, codes.Internal, status.Code(err))
+
+		// The server survives the panic.
+		_, err = srv.client.GetProduct(t.Context(), &productv1.GetProductRequest{Id: "42"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,108 @@
+// Package grpcserver serves the ProductService of api/product/v1 over gRPC: it converts
+// protobuf messages to and from the usecase DTOs, reports domain errors as status codes
+// and streams product events to watchers.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	productv1 "github.com/DucTran999/go-clean-archx/api/product/v1"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"google.golang.org/grpc"
+)
+
+// Options tunes the server.
+type Options struct {
+	// ShutdownTimeout bounds how long in-flight calls may take to drain before
+	// the remaining connections are closed.
+	ShutdownTimeout time.Duration
+
+	// Logger receives access logs, failed calls and lifecycle events. It defaults to slog.Default.
+	Logger port.Logger
+}
+
+// Server serves the gRPC API with the same lifecycle as the HTTP server: it drains
+// in-flight calls on shutdown, so the resources they use can be released afterwards.
+type Server struct {
+	grpcServer *grpc.Server
+	events     *EventHub
+	opts       Options
+}
+
+// New creates a Server exposing ProductService. Every call goes through the request ID,
+// logging and recovery interceptors, in that order.
+func New(productUC port.ProductUsecase, events *EventHub, opts Options) *Server {
+	if opts.Logger == nil {
+		opts.Logger = logger.FromSlog(slog.Default())
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			RequestIDUnary(),
+			LoggingUnary(opts.Logger),
+			RecoveryUnary(opts.Logger),
+		),
+		grpc.ChainStreamInterceptor(
+			RequestIDStream(),
+			LoggingStream(opts.Logger),
+			RecoveryStream(opts.Logger),
+		),
+	)
+	productv1.RegisterProductServiceServer(grpcServer, NewProductService(productUC, events, opts.Logger))
+
+	return &Server{
+		grpcServer: grpcServer,
+		events:     events,
+		opts:       opts,
+	}
+}
+
+// Serve accepts connections on ln until ctx is cancelled or the server fails. It then
+// ends the watch streams and waits up to ShutdownTimeout for the other calls to finish.
+// It returns nil after a clean shutdown.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		s.opts.Logger.Info(ctx, "grpc server started", "addr", ln.Addr().String())
+		serveErr <- s.grpcServer.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			return fmt.Errorf("serve grpc: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	// The serve context is already cancelled, so lifecycle logs use a fresh one.
+	logCtx := context.Background()
+	s.opts.Logger.Info(logCtx, "draining in-flight grpc calls", "timeout", s.opts.ShutdownTimeout)
+	s.events.Close()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(s.opts.ShutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+		s.opts.Logger.Info(logCtx, "all in-flight grpc calls completed")
+		return nil
+	case <-timer.C:
+		s.grpcServer.Stop()
+		<-stopped
+		s.opts.Logger.Error(logCtx, "grpc drain deadline exceeded, closed remaining connections")
+		return errors.New("drain grpc calls: deadline exceeded")
+	}
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/DucTran999/go-clean-archx/internal/requestid"
+)
+
+type attrsKey struct{}
+
+// WithAttrs returns a copy of ctx carrying attrs in addition to any attributes
+// already attached. Every record logged with the returned context includes them.
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing := attrsFromContext(ctx)
+
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+	merged = append(merged, existing...)
+	merged = append(merged, attrs...)
+
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	if ctx == nil {
+		return nil
+	}
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// contextHandler adds the request ID and the attributes attached with WithAttrs to each record.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	id := requestid.FromContext(ctx)
+	attrs := attrsFromContext(ctx)
+	if id != "" || len(attrs) > 0 {
+		r = r.Clone()
+		if id != "" {
+			r.AddAttrs(slog.String("request_id", id))
+		}
+		r.AddAttrs(attrs...)
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,101 @@
+// Package logger implements port.Logger on top of log/slog.
+//
+// Records are written as JSON in deployed environments, where they are shipped to
+// the log pipeline, and as human-readable text during local development.
+// Attributes attached to a context with WithAttrs are added to every record
+// logged with that context.
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/DucTran999/go-clean-archx/internal/port"
+)
+
+// Output formats.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Options configures a logger.
+type Options struct {
+	// Format is FormatJSON or FormatText. Use FormatForEnv to derive it from SERVICE_ENV.
+	Format string
+
+	// Level is the minimum level that is written.
+	Level slog.Level
+
+	// Service, when set, is attached to every record as the "service" attribute.
+	Service string
+}
+
+// FormatForEnv returns the output format for a SERVICE_ENV value:
+// text for local environments, JSON everywhere else.
+func FormatForEnv(env string) string {
+	switch strings.ToLower(env) {
+	case "dev", "local", "test":
+		return FormatText
+	default:
+		return FormatJSON
+	}
+}
+
+// NewSlog returns a *slog.Logger writing to w that also picks up context attributes.
+// It is exposed for adapters that need a *slog.Logger rather than a port.Logger.
+func NewSlog(w io.Writer, opts Options) *slog.Logger {
+	handlerOpts := &slog.HandlerOptions{Level: opts.Level}
+
+	var handler slog.Handler
+	if opts.Format == FormatText {
+		handler = slog.NewTextHandler(w, handlerOpts)
+	} else {
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	}
+
+	l := slog.New(contextHandler{Handler: handler})
+	if opts.Service != "" {
+		l = l.With(slog.String("service", opts.Service))
+	}
+
+	return l
+}
+
+// New returns a port.Logger writing to w.
+func New(w io.Writer, opts Options) port.Logger {
+	return FromSlog(NewSlog(w, opts))
+}
+
+// FromSlog adapts an existing *slog.Logger to port.Logger.
+func FromSlog(l *slog.Logger) port.Logger {
+	return &slogLogger{l: l}
+}
+
+// NewNop returns a logger that discards everything. It is meant for tests.
+func NewNop() port.Logger {
+	return FromSlog(slog.New(slog.DiscardHandler))
+}
+
+// slogLogger implements port.Logger with a *slog.Logger.
+type slogLogger struct {
+	l *slog.Logger
+}
+
+func (s *slogLogger) Debug(ctx context.Context, msg string, args ...any) {
+	s.l.DebugContext(ctx, msg, args...)
+}
+
+func (s *slogLogger) Info(ctx context.Context, msg string, args ...any) {
+	s.l.InfoContext(ctx, msg, args...)
+}
+
+func (s *slogLogger) Warn(ctx context.Context, msg string, args ...any) {
+	s.l.WarnContext(ctx, msg, args...)
+}
+
+func (s *slogLogger) Error(ctx context.Context, msg string, args ...any) {
+	s.l.ErrorContext(ctx, msg, args...)
+}
@@ -0,0 +1,95 @@
+package logger_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatForEnv(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		env      string
+		expected string
+	}{
+		{env: "dev", expected: logger.FormatText},
+		{env: "LOCAL", expected: logger.FormatText},
+		{env: "test", expected: logger.FormatText},
+		{env: "staging", expected: logger.FormatJSON},
+		{env: "prod", expected: logger.FormatJSON},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.env, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, logger.FormatForEnv(tc.env))
+		})
+	}
+}
+
+func TestLogger_JSONIncludesContextAttrs(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(&buf, logger.Options{Format: logger.FormatJSON, Service: "clean_arch"})
+
+	ctx := logger.WithAttrs(context.Background(), slog.String("request_id", "req-1"))
+	ctx = logger.WithAttrs(ctx, slog.String("route", "/products"))
+	log.Error(ctx, "failed to create product", "op", "create_product")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, "failed to create product", record["msg"])
+	assert.Equal(t, "clean_arch", record["service"])
+	assert.Equal(t, "create_product", record["op"])
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.Equal(t, "/products", record["route"])
+}
+
+func TestLogger_TextFormat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(&buf, logger.Options{Format: logger.FormatText})
+
+	log.Info(logger.WithAttrs(context.Background(), slog.String("request_id", "req-1")), "product created")
+
+	assert.Contains(t, buf.String(), `msg="product created"`)
+	assert.Contains(t, buf.String(), "request_id=req-1")
+}
+
+func TestLogger_RespectsLevel(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(&buf, logger.Options{Format: logger.FormatJSON, Level: slog.LevelWarn})
+
+	log.Debug(context.Background(), "debug")
+	log.Info(context.Background(), "info")
+	assert.Empty(t, buf.String())
+
+	log.Warn(context.Background(), "warn")
+	assert.Contains(t, buf.String(), `"msg":"warn"`)
+}
+
+func TestLogger_IncludesRequestID(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := logger.New(&buf, logger.Options{Format: logger.FormatJSON})
+
+	log.Info(requestid.WithID(context.Background(), "req-9"), "product deleted")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "req-9", record["request_id"])
+}
@@ -0,0 +1,185 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/gin-gonic/gin"
+)
+
+// Idempotency headers.
+const (
+	// HeaderIdempotencyKey is sent by clients to make a non-idempotent request safe to retry.
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	// HeaderIdempotentReplayed is set on responses replayed from a previous request.
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// maxIdempotencyKeyLength bounds client-supplied keys; UUIDs and similar tokens fit easily.
+const maxIdempotencyKeyLength = 255
+
+// Defaults applied to zero IdempotencyOptions fields.
+const (
+	DefaultIdempotencyTTL     = 24 * time.Hour
+	DefaultIdempotencyLockTTL = time.Minute
+)
+
+// IdempotencyOptions configures the Idempotency middleware.
+type IdempotencyOptions struct {
+	// TTL is how long a completed response is replayed to retries.
+	TTL time.Duration
+
+	// LockTTL is how long a request in progress holds its key. It must exceed the
+	// longest request, after which the key is considered abandoned (e.g. by a crash).
+	LockTTL time.Duration
+
+	// Logger reports store failures that happen after the response is sent; nil discards them.
+	Logger port.Logger
+}
+
+// Idempotency honors the Idempotency-Key header: the first request with a key is
+// processed and its response stored, and retries within opts.TTL get that response
+// replayed, with the Idempotent-Replayed header set, instead of being processed again.
+//
+// A key reused with a different request is rejected with apperror.Unprocessable, and
+// a retry arriving while the first request is still running with apperror.Conflict,
+// so duplicates are never processed concurrently. Requests that fail (handler errors
+// or 5xx) are not stored, which leaves the key free for a retry.
+//
+// Errors are reported with ctx.Error, so ErrorHandler must be registered before it.
+// Requests without the header pass through untouched.
+func Idempotency(store port.IdempotencyStore, opts IdempotencyOptions) gin.HandlerFunc {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultIdempotencyTTL
+	}
+	if opts.LockTTL <= 0 {
+		opts.LockTTL = DefaultIdempotencyLockTTL
+	}
+	if opts.Logger == nil {
+		opts.Logger = logger.NewNop()
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderIdempotencyKey)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if !validToken(key, maxIdempotencyKeyLength) {
+			c.Error(apperror.New(apperror.Invalid, "invalid Idempotency-Key header"))
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Error(apperror.Wrap(apperror.Invalid, err, "invalid request payload"))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Keys are scoped to the route, so one key cannot replay another endpoint's response.
+		key = c.Request.Method + " " + c.FullPath() + " " + key
+		fingerprint := requestFingerprint(c.Request, body)
+
+		ctx := c.Request.Context()
+		record, token, err := store.Acquire(ctx, key, fingerprint, opts.LockTTL)
+		if err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if token == "" {
+			replay(c, record, fingerprint)
+			return
+		}
+
+		// The outcome is stored even if the client has gone away in the meantime.
+		storeCtx := context.WithoutCancel(ctx)
+		completed := false
+		defer func() {
+			// Also runs when the handler panics, so the key is not held until LockTTL.
+			if !completed {
+				if err := store.Release(storeCtx, key, token); err != nil {
+					opts.Logger.Error(storeCtx, "failed to release idempotency key", "error", err)
+				}
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		c.Next()
+
+		if len(c.Errors) > 0 || !recorder.Written() || recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		err = store.Complete(storeCtx, key, token, dto.IdempotentResponse{
+			Status:      recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}, opts.TTL)
+		if err != nil {
+			opts.Logger.Error(storeCtx, "failed to store idempotent response", "error", err)
+			return
+		}
+		completed = true
+	}
+}
+
+// replay answers a retry from the record holding its key.
+func replay(c *gin.Context, record *dto.IdempotencyRecord, fingerprint string) {
+	switch {
+	case record.Fingerprint != fingerprint:
+		c.Error(apperror.New(apperror.Unprocessable, "Idempotency-Key was already used with a different request"))
+		c.Abort()
+	case record.InProgress():
+		c.Error(apperror.New(apperror.Conflict, "a request with this Idempotency-Key is still in progress"))
+		c.Abort()
+	default:
+		c.Header(HeaderIdempotentReplayed, "true")
+		c.Data(record.Response.Status, record.Response.ContentType, record.Response.Body)
+		c.Abort()
+	}
+}
+
+// requestFingerprint hashes what makes two requests "the same": method, URL and body.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.RequestURI()))
+	h.Write([]byte{0})
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder copies the response body as it is written, so it can be stored.
+type responseRecorder struct {
+	gin.ResponseWriter
+
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
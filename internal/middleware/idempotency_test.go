@@ -0,0 +1,142 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/controller"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/middleware"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIdempotentRouter serves POST /products with a handler that counts its calls
+// and fails with a 5xx when the body is "fail".
+func newIdempotentRouter(calls *atomic.Int32, block <-chan struct{}) *gin.Engine {
+	r := gin.New()
+	r.Use(controller.ErrorHandler(logger.NewNop()))
+	r.POST("/products",
+		middleware.Idempotency(repository.NewMemoryIdempotencyStore(), middleware.IdempotencyOptions{TTL: time.Minute}),
+		func(c *gin.Context) {
+			n := calls.Add(1)
+			if block != nil {
+				<-block
+			}
+
+			body, _ := c.GetRawData()
+			if string(body) == "fail" {
+				c.Error(assert.AnError)
+				return
+			}
+			c.JSON(http.StatusCreated, gin.H{"call": n})
+		})
+
+	return r
+}
+
+func postWithKey(r http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(middleware.HeaderIdempotencyKey, key)
+	}
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, req)
+
+	return resp
+}
+
+func TestIdempotency_ReplaysCompletedRequest(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	var calls atomic.Int32
+	r := newIdempotentRouter(&calls, nil)
+
+	first := postWithKey(r, "key-1", `{"name":"Hat"}`)
+	retry := postWithKey(r, "key-1", `{"name":"Hat"}`)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", retry.Header().Get("Content-Type"))
+	assert.Equal(t, "true", retry.Header().Get(middleware.HeaderIdempotentReplayed))
+	assert.Empty(t, first.Header().Get(middleware.HeaderIdempotentReplayed))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_KeyReusedWithDifferentPayload(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	var calls atomic.Int32
+	r := newIdempotentRouter(&calls, nil)
+
+	postWithKey(r, "key-1", `{"name":"Hat"}`)
+	resp := postWithKey(r, "key-1", `{"name":"Scarf"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.Code)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_ConcurrentDuplicateIsBlocked(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	var calls atomic.Int32
+	block := make(chan struct{})
+	r := newIdempotentRouter(&calls, block)
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		done <- postWithKey(r, "key-1", `{"name":"Hat"}`)
+	}()
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	duplicate := postWithKey(r, "key-1", `{"name":"Hat"}`)
+	close(block)
+	first := <-done
+
+	assert.Equal(t, http.StatusConflict, duplicate.Code)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestIdempotency_FailedRequestCanBeRetried(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	var calls atomic.Int32
+	r := newIdempotentRouter(&calls, nil)
+
+	first := postWithKey(r, "key-1", "fail")
+	retry := postWithKey(r, "key-1", "fail")
+
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+	assert.Equal(t, http.StatusInternalServerError, retry.Code)
+	assert.Empty(t, retry.Header().Get(middleware.HeaderIdempotentReplayed))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestIdempotency_WithoutOrInvalidKey(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	var calls atomic.Int32
+	r := newIdempotentRouter(&calls, nil)
+
+	// Without a key every request is processed.
+	assert.Equal(t, http.StatusCreated, postWithKey(r, "", `{}`).Code)
+	assert.Equal(t, http.StatusCreated, postWithKey(r, "", `{}`).Code)
+	assert.Equal(t, int32(2), calls.Load())
+
+	assert.Equal(t, http.StatusBadRequest, postWithKey(r, strings.Repeat("k", 256), `{}`).Code)
+	assert.Equal(t, http.StatusBadRequest, postWithKey(r, "bad key", `{}`).Code)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"github.com/DucTran999/go-clean-archx/internal/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// HeaderRequestID carries the correlation ID of a request.
+const HeaderRequestID = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied IDs so they cannot bloat logs.
+const maxRequestIDLength = 128
+
+// RequestID accepts the X-Request-ID sent by the caller (e.g. a gateway) or generates
+// a new UUID, stores it in the request context and echoes it in the response header.
+// It must run before any middleware that logs, so every record carries the ID.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(HeaderRequestID)
+		if !validRequestID(id) {
+			id = requestid.New()
+		}
+
+		c.Request = c.Request.WithContext(requestid.WithID(c.Request.Context(), id))
+		c.Header(HeaderRequestID, id)
+
+		c.Next()
+	}
+}
+
+// validRequestID accepts non-empty IDs of printable ASCII, so a client cannot
+// inject control characters into logs or response headers.
+func validRequestID(id string) bool {
+	return validToken(id, maxRequestIDLength)
+}
+
+// validToken reports whether s is 1 to maxLen characters of printable ASCII without spaces.
+func validToken(s string, maxLen int) bool {
+	if s == "" || len(s) > maxLen {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x21 || s[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,64 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/middleware"
+	"github.com/DucTran999/go-clean-archx/internal/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		incoming    string
+		expectKept  bool
+		expectFresh bool
+	}{
+		{name: "keeps incoming id", incoming: "gateway-7f3a", expectKept: true},
+		{name: "generates missing id", incoming: "", expectFresh: true},
+		{name: "replaces id with spaces", incoming: "bad id", expectFresh: true},
+		{name: "replaces oversized id", incoming: strings.Repeat("a", 129), expectFresh: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var seen string
+			router := gin.New()
+			router.Use(middleware.RequestID())
+			router.GET("/", func(ctx *gin.Context) {
+				seen = requestid.FromContext(ctx.Request.Context())
+				ctx.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.incoming != "" {
+				req.Header.Set(middleware.HeaderRequestID, tc.incoming)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			echoed := rec.Header().Get(middleware.HeaderRequestID)
+			assert.Equal(t, seen, echoed, "context and response header must agree")
+			if tc.expectKept {
+				assert.Equal(t, tc.incoming, echoed)
+			}
+			if tc.expectFresh {
+				_, err := uuid.Parse(echoed)
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Package middleware provides Gin middleware shared by every HTTP route.
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestLogger attaches the method and route to the request context, so every
+// record logged downstream (controllers, usecases, repositories) carries them,
+// and writes one access log record per request with its status and latency.
+//
+// The request ID is added to records by the logger itself, from the context
+// populated by RequestID, which must therefore be registered first.
+func RequestLogger(log port.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		// FullPath is the route template (/products/:id), which keeps the
+		// cardinality of the field low; unmatched requests have none.
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx := logger.WithAttrs(c.Request.Context(),
+			slog.String("method", c.Request.Method),
+			slog.String("route", route),
+		)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		args := []any{
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			args = append(args, slog.String("errors", c.Errors.String()))
+		}
+
+		switch {
+		case status >= 500:
+			log.Error(ctx, "request completed", args...)
+		case status >= 400:
+			log.Warn(ctx, "request completed", args...)
+		default:
+			log.Info(ctx, "request completed", args...)
+		}
+	}
+}
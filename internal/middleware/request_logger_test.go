@@ -0,0 +1,121 @@
+package middleware_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeRecords parses one JSON log record per line.
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var record map[string]any
+		require.NoError(t, dec.Decode(&record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestRequestLogger(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name            string
+		path            string
+		requestID       string
+		expectedStatus  int
+		expectedRoute   string
+		expectedLevel   string
+		expectGenerated bool
+	}{
+		{
+			name:           "propagates incoming request id",
+			path:           "/products/42",
+			requestID:      "req-123",
+			expectedStatus: http.StatusOK,
+			expectedRoute:  "/products/:id",
+			expectedLevel:  "INFO",
+		},
+		{
+			name:            "generates missing request id",
+			path:            "/products/42",
+			expectedStatus:  http.StatusOK,
+			expectedRoute:   "/products/:id",
+			expectedLevel:   "INFO",
+			expectGenerated: true,
+		},
+		{
+			name:            "unmatched route",
+			path:            "/nope",
+			expectedStatus:  http.StatusNotFound,
+			expectedRoute:   "unmatched",
+			expectedLevel:   "WARN",
+			expectGenerated: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			log := logger.New(&buf, logger.Options{Format: logger.FormatJSON})
+
+			router := gin.New()
+			router.Use(middleware.RequestID(), middleware.RequestLogger(log))
+			router.GET("/products/:id", func(ctx *gin.Context) {
+				// Handlers and usecases log with the request context.
+				log.Info(ctx.Request.Context(), "handled")
+				ctx.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			if tc.requestID != "" {
+				req.Header.Set(middleware.HeaderRequestID, tc.requestID)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedStatus, rec.Code)
+
+			records := decodeRecords(t, &buf)
+			require.NotEmpty(t, records)
+
+			access := records[len(records)-1]
+			assert.Equal(t, "request completed", access["msg"])
+			assert.Equal(t, tc.expectedLevel, access["level"])
+			assert.Equal(t, http.MethodGet, access["method"])
+			assert.Equal(t, tc.expectedRoute, access["route"])
+			assert.Equal(t, tc.path, access["path"])
+			assert.EqualValues(t, tc.expectedStatus, access["status"])
+			assert.Contains(t, access, "latency_ms")
+
+			if tc.expectGenerated {
+				assert.NotEmpty(t, access["request_id"])
+			} else {
+				assert.Equal(t, tc.requestID, access["request_id"])
+			}
+
+			// Records logged inside the handler share the correlation fields.
+			for _, record := range records[:len(records)-1] {
+				assert.Equal(t, access["request_id"], record["request_id"])
+				assert.Equal(t, tc.expectedRoute, record["route"])
+			}
+		})
+	}
+}
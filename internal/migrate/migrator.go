@@ -0,0 +1,301 @@
+// Package migrate applies the SQL schema migrations to PostgreSQL.
+//
+// It is a small, embeddable replacement for the golang-migrate CLI: migrations are read
+// from an fs.FS (the files embedded by package migrations) and the applied version is
+// recorded in the same schema_migrations table, so databases migrated by either tool
+// can be handed over to the other.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+)
+
+// NoVersion is the version of a database no migration has been applied to.
+const NoVersion uint64 = 0
+
+// lockKey identifies the advisory lock serializing migrators. Advisory locks are scoped
+// to the database, so one key is enough for every database of a server.
+const lockKey int64 = 3_841_126_904
+
+// nilVersion is stored by golang-migrate when a database without any version is dirty,
+// i.e. the rollback of the first migration failed.
+const nilVersion int64 = -1
+
+var (
+	// ErrDirty is returned when a previous migration failed halfway. The schema must be
+	// repaired by hand and the version set with Force before migrating again.
+	ErrDirty = errors.New("database is dirty")
+
+	// ErrUnknownVersion is returned for a version that matches none of the migrations.
+	ErrUnknownVersion = errors.New("unknown migration version")
+
+	// ErrIrreversible is returned when rolling back a migration that has no down migration.
+	ErrIrreversible = errors.New("migration cannot be rolled back")
+)
+
+// Options configures a Migrator.
+type Options struct {
+	// Logger reports every migration run; nil discards them.
+	Logger port.Logger
+}
+
+// Status describes the migrations of a database.
+type Status struct {
+	Version uint64
+	Dirty   bool
+
+	// Migrations lists every known migration; those up to Version are applied.
+	Migrations []Migration
+}
+
+// Migrator moves a database between the versions of a sequence of migrations.
+//
+// Every operation holds a PostgreSQL advisory lock for its whole duration, so instances
+// started together wait for each other instead of applying the same migration twice.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	logger     port.Logger
+}
+
+// New creates a Migrator applying the migrations found in fsys to db.
+func New(db *sql.DB, fsys fs.FS, opts Options) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Logger == nil {
+		opts.Logger = logger.NewNop()
+	}
+
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		logger:     opts.Logger,
+	}, nil
+}
+
+// Up applies every pending migration and returns how many were applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	return m.migrate(ctx, func(int) int {
+		return len(m.migrations) - 1
+	})
+}
+
+// Down rolls back the last n applied migrations, or fewer when fewer are applied.
+func (m *Migrator) Down(ctx context.Context, n int) (int, error) {
+	if n < 1 {
+		return 0, fmt.Errorf("down needs a positive number of migrations, got %d", n)
+	}
+
+	return m.migrate(ctx, func(current int) int {
+		return max(current-n, -1)
+	})
+}
+
+// Goto migrates up or down to version; NoVersion rolls back every migration.
+func (m *Migrator) Goto(ctx context.Context, version uint64) (int, error) {
+	target, err := m.index(version)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.migrate(ctx, func(int) int {
+		return target
+	})
+}
+
+// Force records version as the current one and clears the dirty flag without running
+// anything. It is meant for recovering from a failed migration once the schema has been
+// repaired by hand.
+func (m *Migrator) Force(ctx context.Context, version uint64) error {
+	if _, err := m.index(version); err != nil {
+		return err
+	}
+
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return setVersion(ctx, conn, version, false)
+	})
+}
+
+// Status reports the current version and the known migrations.
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	status := Status{Migrations: m.migrations}
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		var err error
+		status.Version, status.Dirty, err = readVersion(ctx, conn)
+		return err
+	})
+
+	return status, err
+}
+
+// migrate runs the migrations between the current version and the one at the index
+// returned by target, where -1 means NoVersion.
+func (m *Migrator) migrate(ctx context.Context, target func(current int) int) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := readVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("%w: migration %d failed, repair the schema then force a version", ErrDirty, version)
+		}
+		current, err := m.index(version)
+		if err != nil {
+			return fmt.Errorf("database is at version %d: %w", version, err)
+		}
+		to := target(current)
+
+		for ; current < to; current++ {
+			next := m.migrations[current+1]
+			if err := m.run(ctx, conn, next, "up", next.Up, next.Version); err != nil {
+				return err
+			}
+			applied++
+		}
+		for ; current > to; current-- {
+			last := m.migrations[current]
+			if last.Down == "" {
+				return fmt.Errorf("%w: %d_%s has no down migration", ErrIrreversible, last.Version, last.Name)
+			}
+			if err := m.run(ctx, conn, last, "down", last.Down, m.versionAt(current-1)); err != nil {
+				return err
+			}
+			applied++
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// run executes one migration, marking the database dirty at version until it succeeds.
+// Migration files may hold several statements; they are not wrapped in a transaction, so
+// a file can manage its own, or run statements such as CREATE INDEX CONCURRENTLY.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, mig Migration, direction, body string, version uint64) error {
+	if err := setVersion(ctx, conn, version, true); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, body); err != nil {
+		return fmt.Errorf("migration %d_%s %s failed: %w", mig.Version, mig.Name, direction, err)
+	}
+	if err := setVersion(ctx, conn, version, false); err != nil {
+		return err
+	}
+
+	m.logger.Info(ctx, "migration applied", "version", mig.Version, "name", mig.Name, "direction", direction)
+
+	return nil
+}
+
+// withLock runs fn on a dedicated connection holding the migration lock, creating the
+// schema_migrations table first when needed.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("get connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		// The session lock would be released with the connection anyway, but the pool keeps it open.
+		if _, unlockErr := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockKey); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("release migration lock: %w", unlockErr))
+		}
+	}()
+
+	if _, err := conn.ExecContext(ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`,
+	); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// index returns the position of version among the migrations, -1 for NoVersion.
+func (m *Migrator) index(version uint64) (int, error) {
+	if version == NoVersion {
+		return -1, nil
+	}
+	for i, mig := range m.migrations {
+		if mig.Version == version {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+}
+
+// versionAt returns the version of the migration at index i, NoVersion for -1.
+func (m *Migrator) versionAt(i int) uint64 {
+	if i < 0 {
+		return NoVersion
+	}
+
+	return m.migrations[i].Version
+}
+
+// readVersion returns the recorded version; the table holds at most one row.
+func readVersion(ctx context.Context, conn *sql.Conn) (uint64, bool, error) {
+	var (
+		version int64
+		dirty   bool
+	)
+	err := conn.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return NoVersion, false, nil
+	case err != nil:
+		return 0, false, fmt.Errorf("read schema version: %w", err)
+	case version == nilVersion:
+		return NoVersion, dirty, nil
+	}
+
+	return uint64(version), dirty, nil
+}
+
+// setVersion replaces the recorded version the way golang-migrate does: the table is
+// left empty for a clean NoVersion.
+func setVersion(ctx context.Context, conn *sql.Conn, version uint64, dirty bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("record schema version: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `TRUNCATE schema_migrations`); err != nil {
+		return fmt.Errorf("record schema version: %w", err)
+	}
+
+	stored := int64(version)
+	if version == NoVersion {
+		stored = nilVersion
+	}
+	if version != NoVersion || dirty {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`, stored, dirty,
+		); err != nil {
+			return fmt.Errorf("record schema version: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("record schema version: %w", err)
+	}
+
+	return nil
+}
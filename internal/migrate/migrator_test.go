@@ -0,0 +1,286 @@
+package migrate_test
+
+import (
+	"database/sql"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DucTran999/go-clean-archx/internal/migrate"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMigrations are three migrations; the last one cannot be rolled back.
+var testMigrations = fstest.MapFS{
+	"1_init.up.sql":         {Data: []byte("CREATE TABLE products ();")},
+	"1_init.down.sql":       {Data: []byte("DROP TABLE products;")},
+	"2_add_sku.up.sql":      {Data: []byte("ALTER TABLE products ADD sku TEXT;")},
+	"2_add_sku.down.sql":    {Data: []byte("ALTER TABLE products DROP sku;")},
+	"3_backfill_sku.up.sql": {Data: []byte("UPDATE products SET sku = id;")},
+}
+
+func newMigrator(t *testing.T) (*migrate.Migrator, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	m, err := migrate.New(db, testMigrations, migrate.Options{})
+	require.NoError(t, err)
+
+	return m, mock
+}
+
+// expectLock expects the advisory lock, the table creation and the version read.
+// A nil version means the table is empty.
+func expectLock(mock sqlmock.Sqlmock, version *int64, dirty bool) {
+	mock.ExpectExec(`SELECT pg_advisory_lock($1)`).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	rows := sqlmock.NewRows([]string{"version", "dirty"})
+	if version != nil {
+		rows.AddRow(*version, dirty)
+	}
+	mock.ExpectQuery(`SELECT version, dirty FROM schema_migrations LIMIT 1`).WillReturnRows(rows)
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`SELECT pg_advisory_unlock($1)`).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+// expectSetVersion expects the version to be recorded; a clean NoVersion leaves the table empty.
+func expectSetVersion(mock sqlmock.Sqlmock, version int64, dirty bool) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`TRUNCATE schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	if version != 0 || dirty {
+		if version == 0 {
+			version = -1
+		}
+		mock.ExpectExec(`INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`).
+			WithArgs(version, dirty).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+}
+
+// expectRun expects a migration body to run at version.
+func expectRun(mock sqlmock.Sqlmock, body string, version int64) {
+	expectSetVersion(mock, version, true)
+	mock.ExpectExec(body).WillReturnResult(sqlmock.NewResult(0, 0))
+	expectSetVersion(mock, version, false)
+}
+
+func version(v int64) *int64 {
+	return &v
+}
+
+func TestMigrator_Up(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	m, mock := newMigrator(t)
+	expectLock(mock, version(1), false)
+	expectRun(mock, "ALTER TABLE products ADD sku TEXT;", 2)
+	expectRun(mock, "UPDATE products SET sku = id;", 3)
+	expectUnlock(mock)
+
+	// Act
+	applied, err := m.Up(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_UpFromScratchFails(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: the first migration fails, leaving the database dirty at its version.
+	m, mock := newMigrator(t)
+	expectLock(mock, nil, false)
+	expectSetVersion(mock, 1, true)
+	mock.ExpectExec("CREATE TABLE products ();").WillReturnError(datatest.ErrUnexpectedDB)
+	expectUnlock(mock)
+
+	// Act
+	applied, err := m.Up(t.Context())
+
+	// Assert
+	assert.ErrorIs(t, err, datatest.ErrUnexpectedDB)
+	assert.Zero(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_RefusesDirtyDatabase(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	m, mock := newMigrator(t)
+	expectLock(mock, version(2), true)
+	expectUnlock(mock)
+
+	// Act
+	_, err := m.Up(t.Context())
+
+	// Assert
+	assert.ErrorIs(t, err, migrate.ErrDirty)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_RefusesUnknownVersion(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: the database was migrated by a newer build.
+	m, mock := newMigrator(t)
+	expectLock(mock, version(4), false)
+	expectUnlock(mock)
+
+	// Act
+	_, err := m.Up(t.Context())
+
+	// Assert
+	assert.ErrorIs(t, err, migrate.ErrUnknownVersion)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Down(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: rolling back more migrations than applied stops at NoVersion.
+	m, mock := newMigrator(t)
+	expectLock(mock, version(2), false)
+	expectRun(mock, "ALTER TABLE products DROP sku;", 1)
+	expectSetVersion(mock, 0, true)
+	mock.ExpectExec("DROP TABLE products;").WillReturnResult(sqlmock.NewResult(0, 0))
+	expectSetVersion(mock, 0, false)
+	expectUnlock(mock)
+
+	// Act
+	applied, err := m.Down(t.Context(), 5)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_DownIrreversible(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	m, mock := newMigrator(t)
+	expectLock(mock, version(3), false)
+	expectUnlock(mock)
+
+	// Act
+	applied, err := m.Down(t.Context(), 1)
+
+	// Assert
+	assert.ErrorIs(t, err, migrate.ErrIrreversible)
+	assert.Zero(t, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Goto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("up", func(t *testing.T) {
+		t.Parallel()
+
+		m, mock := newMigrator(t)
+		expectLock(mock, nil, false)
+		expectRun(mock, "CREATE TABLE products ();", 1)
+		expectRun(mock, "ALTER TABLE products ADD sku TEXT;", 2)
+		expectUnlock(mock)
+
+		applied, err := m.Goto(t.Context(), 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("down", func(t *testing.T) {
+		t.Parallel()
+
+		m, mock := newMigrator(t)
+		expectLock(mock, version(2), false)
+		expectRun(mock, "ALTER TABLE products DROP sku;", 1)
+		expectUnlock(mock)
+
+		applied, err := m.Goto(t.Context(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, applied)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		t.Parallel()
+
+		m, mock := newMigrator(t)
+
+		_, err := m.Goto(t.Context(), 7)
+
+		assert.ErrorIs(t, err, migrate.ErrUnknownVersion)
+		assert.NoError(t, mock.ExpectationsWereMet(), "nothing runs for an unknown version")
+	})
+}
+
+func TestMigrator_Force(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	m, mock := newMigrator(t)
+	mock.ExpectExec(`SELECT pg_advisory_lock($1)`).WithArgs(sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectSetVersion(mock, 2, false)
+	expectUnlock(mock)
+
+	// Act
+	err := m.Force(t.Context(), 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_Status(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: golang-migrate stores -1 when the rollback of the first migration failed.
+	m, mock := newMigrator(t)
+	expectLock(mock, version(-1), true)
+	expectUnlock(mock)
+
+	// Act
+	status, err := m.Status(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, migrate.NoVersion, status.Version)
+	assert.True(t, status.Dirty)
+	assert.Len(t, status.Migrations, 3)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_LockFailure(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	m, mock := newMigrator(t)
+	mock.ExpectExec(`SELECT pg_advisory_lock($1)`).WithArgs(sqlmock.AnyArg()).WillReturnError(sql.ErrConnDone)
+
+	// Act
+	_, err := m.Up(t.Context())
+
+	// Assert
+	assert.ErrorIs(t, err, sql.ErrConnDone)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,94 @@
+package migrate
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidSource is returned when the migration files cannot be turned into a sequence of migrations.
+var ErrInvalidSource = errors.New("invalid migration source")
+
+// filenamePattern matches golang-migrate file names, e.g. 202410192029_init_schema.up.sql.
+var filenamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one schema change, read from its <version>_<name>.up.sql file
+// and the optional matching .down.sql file.
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+
+	// Down reverts Up. A migration whose down file is missing or empty cannot be rolled back.
+	Down string
+}
+
+// Load reads the migrations in the root of fsys, ordered by version.
+// Files that are not .sql files are ignored.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	hasUp := make(map[uint64]bool)
+	hasDown := make(map[uint64]bool)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+
+		parts := filenamePattern.FindStringSubmatch(name)
+		if parts == nil {
+			return nil, fmt.Errorf("%w: %s is not named <version>_<name>.<up|down>.sql", ErrInvalidSource, name)
+		}
+		version, err := strconv.ParseUint(parts[1], 10, 63)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("%w: %s has an invalid version", ErrInvalidSource, name)
+		}
+
+		body, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", name, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: parts[2]}
+			byVersion[version] = m
+		}
+		if m.Name != parts[2] {
+			return nil, fmt.Errorf("%w: version %d is used by %q and %q", ErrInvalidSource, version, m.Name, parts[2])
+		}
+
+		target, seen := &m.Up, hasUp
+		if parts[3] == "down" {
+			target, seen = &m.Down, hasDown
+		}
+		if seen[version] {
+			return nil, fmt.Errorf("%w: %s is defined twice", ErrInvalidSource, name)
+		}
+		seen[version] = true
+		*target = string(body)
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if !hasUp[m.Version] {
+			return nil, fmt.Errorf("%w: version %d has no up migration", ErrInvalidSource, m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
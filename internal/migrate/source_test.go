@@ -0,0 +1,130 @@
+package migrate_test
+
+import (
+	"io/fs"
+	"math"
+	"regexp"
+	"strconv"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/migrate"
+	"github.com/DucTran999/go-clean-archx/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	fsys := fstest.MapFS{
+		"2_add_sku.up.sql":        {Data: []byte("ALTER TABLE products ADD sku TEXT;")},
+		"2_add_sku.down.sql":      {Data: []byte("ALTER TABLE products DROP sku;")},
+		"10_create_outbox.up.sql": {Data: []byte("CREATE TABLE outbox ();")},
+		"1_init.up.sql":           {Data: []byte("CREATE TABLE products ();")},
+		"1_init.down.sql":         {Data: []byte("DROP TABLE products;")},
+		"README.md":               {Data: []byte("not a migration")},
+	}
+
+	// Act
+	got, err := migrate.Load(fsys)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []migrate.Migration{
+		{Version: 1, Name: "init", Up: "CREATE TABLE products ();", Down: "DROP TABLE products;"},
+		{Version: 2, Name: "add_sku", Up: "ALTER TABLE products ADD sku TEXT;", Down: "ALTER TABLE products DROP sku;"},
+		{Version: 10, Name: "create_outbox", Up: "CREATE TABLE outbox ();"},
+	}, got)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		files []string
+	}{
+		{name: "bad file name", files: []string{"init.up.sql"}},
+		{name: "unknown direction", files: []string{"1_init.sideways.sql"}},
+		{name: "zero version", files: []string{"0_init.up.sql"}},
+		{name: "down without up", files: []string{"1_init.down.sql"}},
+		{name: "two names for one version", files: []string{"1_init.up.sql", "1_other.down.sql"}},
+		{name: "same migration twice", files: []string{"1_init.up.sql", "01_init.up.sql"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			fsys := fstest.MapFS{}
+			for _, f := range tt.files {
+				fsys[f] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+			}
+
+			// Act
+			_, err := migrate.Load(fsys)
+
+			// Assert
+			assert.ErrorIs(t, err, migrate.ErrInvalidSource)
+		})
+	}
+}
+
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	t.Parallel()
+
+	got, err := migrate.Load(migrations.FS)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, got)
+	assert.Equal(t, uint64(202410192029), got[0].Version)
+	for _, m := range got {
+		assert.NotEmpty(t, m.Down, "%d_%s should be reversible", m.Version, m.Name)
+	}
+}
+
+// The price rollback hard-codes a divisor per currency; it must agree with entity.Money.
+func TestLoad_PriceRollbackMatchesCurrencyExponents(t *testing.T) {
+	t.Parallel()
+
+	down, err := fs.ReadFile(migrations.FS, "202610181100_products_price_minor_units.down.sql")
+	require.NoError(t, err)
+
+	divisors := map[string]int{}
+	groups := regexp.MustCompile(`WHEN currency IN \(([^)]*)\) THEN (\d+)`).FindAllStringSubmatch(string(down), -1)
+	require.NotEmpty(t, groups)
+	for _, g := range groups {
+		divisor, err := strconv.Atoi(g[2])
+		require.NoError(t, err)
+		for _, code := range regexp.MustCompile(`'([A-Z]{3})'`).FindAllStringSubmatch(g[1], -1) {
+			divisors[code[1]] = divisor
+		}
+	}
+
+	guard := regexp.MustCompile(`(?s)NOT IN \(([^)]*)\)`).FindStringSubmatch(string(down))
+	require.Len(t, guard, 2)
+	guarded := map[string]int{}
+	for _, code := range regexp.MustCompile(`'([A-Z]{3})'`).FindAllStringSubmatch(guard[1], -1) {
+		guarded[code[1]] = divisors[code[1]]
+	}
+	assert.Equal(t, divisors, guarded, "the guard accepts exactly the converted currencies")
+
+	// Every three-letter code, so a currency added to entity.Money cannot be missed.
+	for a := 'A'; a <= 'Z'; a++ {
+		for b := 'A'; b <= 'Z'; b++ {
+			for c := 'A'; c <= 'Z'; c++ {
+				code := string([]rune{a, b, c})
+				exponent, ok := entity.CurrencyExponent(code)
+				divisor, listed := divisors[code]
+				if !assert.Equal(t, ok, listed, "%s listed in the rollback", code) || !ok {
+					continue
+				}
+				assert.Equal(t, int(math.Pow10(exponent)), divisor, "%s divisor", code)
+			}
+		}
+	}
+}
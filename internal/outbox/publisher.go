@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+)
+
+// Handler consumes a published event. It may see the same event more than once.
+type Handler func(ctx context.Context, event entity.Event) error
+
+// InProcessPublisher is an EventPublisher that hands events to handlers subscribed
+// in the same process. It suits a single deployment and tests; a broker-backed
+// publisher can replace it without touching the relay or the usecases.
+type InProcessPublisher struct {
+	mu       sync.RWMutex
+	handlers map[entity.EventType][]Handler
+	all      []Handler
+}
+
+var _ port.EventPublisher = (*InProcessPublisher)(nil)
+
+// NewInProcessPublisher creates a publisher without subscribers.
+func NewInProcessPublisher() *InProcessPublisher {
+	return &InProcessPublisher{
+		handlers: make(map[entity.EventType][]Handler),
+	}
+}
+
+// Subscribe registers h for the given event types, or for every event when none is given.
+func (p *InProcessPublisher) Subscribe(h Handler, types ...entity.EventType) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(types) == 0 {
+		p.all = append(p.all, h)
+		return
+	}
+	for _, t := range types {
+		p.handlers[t] = append(p.handlers[t], h)
+	}
+}
+
+// Publish calls the handlers subscribed to every event, then those subscribed to the
+// event's type, each group in subscription order. All handlers run even if one fails;
+// the event is then retried for all of them, which is why handlers must be idempotent.
+func (p *InProcessPublisher) Publish(ctx context.Context, event entity.Event) error {
+	p.mu.RLock()
+	handlers := make([]Handler, 0, len(p.all)+len(p.handlers[event.Type]))
+	handlers = append(handlers, p.all...)
+	handlers = append(handlers, p.handlers[event.Type]...)
+	p.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,36 @@
+package outbox_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/outbox"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInProcessPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	record := func(name string) outbox.Handler {
+		return func(_ context.Context, e entity.Event) error {
+			got = append(got, name+":"+string(e.Type))
+			return nil
+		}
+	}
+
+	publisher := outbox.NewInProcessPublisher()
+	publisher.Subscribe(record("deletions"), entity.EventProductDeleted)
+	publisher.Subscribe(record("all"))
+	publisher.Subscribe(func(context.Context, entity.Event) error { return errBrokerDown }, entity.EventProductCreated)
+
+	err := publisher.Publish(t.Context(), entity.NewProductCreated(&entity.Product{ID: datatest.FakeProductID}))
+	assert.ErrorIs(t, err, errBrokerDown)
+
+	err = publisher.Publish(t.Context(), entity.NewProductDeleted(datatest.FakeProductID))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"all:product.created", "all:product.deleted", "deletions:product.deleted"}, got)
+}
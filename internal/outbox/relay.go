@@ -0,0 +1,175 @@
+// Package outbox publishes the domain events stored in the transactional outbox.
+//
+// Usecases add events through port.OutboxRepository in the same transaction as the
+// change that raised them, so an event exists if and only if the change committed.
+// The Relay then polls the outbox and hands events to a port.EventPublisher,
+// retrying failed deliveries with exponential backoff, and purges events once they
+// have been sent for longer than the retention.
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/worker"
+)
+
+// Defaults applied to zero RelayOptions fields.
+const (
+	DefaultPollInterval  = time.Second
+	DefaultBatchSize     = 100
+	DefaultMinBackoff    = time.Second
+	DefaultMaxBackoff    = 5 * time.Minute
+	DefaultRetention     = 7 * 24 * time.Hour
+	DefaultPurgeInterval = time.Hour
+)
+
+// RelayOptions configures a Relay.
+type RelayOptions struct {
+	// PollInterval is how long the relay waits before polling again once the outbox is drained.
+	PollInterval time.Duration
+
+	// BatchSize bounds how many events are claimed, and kept locked, per transaction.
+	BatchSize int
+
+	// MinBackoff is the delay before retrying an event that failed once. It doubles
+	// with every further failure, up to MaxBackoff; events are retried until they succeed.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Retention is how long sent events are kept. Listeners of the event broadcast read
+	// events back from the outbox after they were sent, so it must leave them ample time.
+	Retention time.Duration
+
+	// PurgeInterval is how long the relay waits between purges of the expired events.
+	PurgeInterval time.Duration
+
+	// Logger reports failed deliveries; nil discards them.
+	Logger port.Logger
+}
+
+// Relay moves events from the outbox to an EventPublisher.
+//
+// Batches are claimed with FOR UPDATE SKIP LOCKED, so every instance of the service can
+// run a Relay: each event is handled by one of them at a time. An event is marked as sent
+// in the same transaction that claimed it; if that transaction fails after the event was
+// published, it is published again later (at-least-once delivery). Each event is published
+// in a nested transaction, so the writes of a failed delivery are undone on their own.
+type Relay struct {
+	txManager port.TxManager
+	outbox    port.OutboxRepository
+	publisher port.EventPublisher
+	backoff   worker.Backoff
+	opts      RelayOptions
+}
+
+// NewRelay creates a Relay publishing the events of outbox through publisher.
+func NewRelay(txManager port.TxManager, outbox port.OutboxRepository, publisher port.EventPublisher, opts RelayOptions) *Relay {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = DefaultRetention
+	}
+	if opts.PurgeInterval <= 0 {
+		opts.PurgeInterval = DefaultPurgeInterval
+	}
+	if opts.Logger == nil {
+		opts.Logger = logger.NewNop()
+	}
+
+	return &Relay{
+		txManager: txManager,
+		outbox:    outbox,
+		publisher: publisher,
+		backoff: worker.NewBackoff(opts.MinBackoff, opts.MaxBackoff, worker.Backoff{
+			Min: DefaultMinBackoff,
+			Max: DefaultMaxBackoff,
+		}),
+		opts: opts,
+	}
+}
+
+// Run relays events and purges the expired ones until ctx is cancelled, polling the
+// outbox as worker.Poll does.
+func (r *Relay) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		worker.Poll(ctx, worker.PollOptions{
+			Interval:     r.opts.PurgeInterval,
+			BatchSize:    r.opts.BatchSize,
+			Logger:       r.opts.Logger,
+			ErrorMessage: "failed to purge sent outbox events",
+		}, r.PurgeBatch)
+	}()
+
+	worker.Poll(ctx, worker.PollOptions{
+		Interval:     r.opts.PollInterval,
+		BatchSize:    r.opts.BatchSize,
+		Logger:       r.opts.Logger,
+		ErrorMessage: "failed to relay outbox events",
+	}, r.RelayBatch)
+	wg.Wait()
+}
+
+// PurgeBatch deletes one batch of events sent longer than the retention ago and
+// returns how many it deleted.
+func (r *Relay) PurgeBatch(ctx context.Context) (int, error) {
+	n, err := r.outbox.PurgeSent(ctx, r.opts.Retention, r.opts.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge outbox events: %w", err)
+	}
+	if n > 0 {
+		r.opts.Logger.Debug(ctx, "purged sent outbox events", "count", n)
+	}
+
+	return n, nil
+}
+
+// RelayBatch claims one batch of due events and publishes them. It returns how many
+// events were claimed, whether or not their delivery succeeded.
+func (r *Relay) RelayBatch(ctx context.Context) (int, error) {
+	var claimed int
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		messages, err := r.outbox.ClaimPending(ctx, r.opts.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to claim outbox events: %w", err)
+		}
+		claimed = len(messages)
+
+		for _, msg := range messages {
+			// Subscribers may write through the claim transaction; the savepoint keeps a
+			// failing one from aborting it, so the failure can still be recorded below.
+			err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+				return r.publisher.Publish(ctx, msg.Event)
+			})
+			if err != nil {
+				attempts := msg.Attempts + 1
+				r.opts.Logger.Warn(ctx, "failed to publish event, will retry",
+					"event_id", msg.Event.ID, "event_type", msg.Event.Type, "attempts", attempts, "error", err)
+
+				if err := r.outbox.MarkFailed(ctx, msg.Event.ID, r.backoff.Delay(attempts), err.Error()); err != nil {
+					return fmt.Errorf("failed to reschedule outbox event: %w", err)
+				}
+				continue
+			}
+
+			if err := r.outbox.MarkSent(ctx, msg.Event.ID); err != nil {
+				return fmt.Errorf("failed to mark outbox event as sent: %w", err)
+			}
+		}
+
+		return nil
+	})
+
+	return claimed, err
+}
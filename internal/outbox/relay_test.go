@@ -0,0 +1,243 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/outbox"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mockbuilder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var errBrokerDown = errors.New("broker down")
+
+func TestRelay_RelayBatch(t *testing.T) {
+	t.Parallel()
+
+	created := entity.NewProductCreated(&entity.Product{ID: datatest.FakeProductID})
+	deleted := entity.NewProductDeleted(datatest.FakeProductID)
+
+	tests := []struct {
+		name          string
+		setupOutbox   func(b *mockbuilder.OutboxRepoBuilder)
+		failing       entity.EventType
+		expectedCount int
+		expectedErr   error
+	}{
+		{
+			name: "published events are marked as sent",
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) {
+				b.ClaimPendingReturns(dto.OutboxMessage{Event: created}, dto.OutboxMessage{Event: deleted}).
+					MarkSentExpects(created).
+					MarkSentExpects(deleted)
+			},
+			expectedCount: 2,
+		},
+		{
+			name: "failed event is retried with backoff, the others are sent",
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) {
+				b.ClaimPendingReturns(dto.OutboxMessage{Event: created, Attempts: 2}, dto.OutboxMessage{Event: deleted}).
+					MarkFailedExpects(created, 4*time.Second).
+					MarkSentExpects(deleted)
+			},
+			failing:       entity.EventProductCreated,
+			expectedCount: 2,
+		},
+		{
+			name: "backoff is capped",
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) {
+				b.ClaimPendingReturns(dto.OutboxMessage{Event: created, Attempts: 30}).
+					MarkFailedExpects(created, time.Minute)
+			},
+			failing:       entity.EventProductCreated,
+			expectedCount: 1,
+		},
+		{
+			name:        "claim failure",
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) { b.ClaimPendingErrorDB() },
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+		{
+			name:        "empty outbox",
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) { b.ClaimPendingReturns() },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			store := mockbuilder.NewOutboxRepoBuilder(t)
+			tt.setupOutbox(store)
+
+			publisher := outbox.NewInProcessPublisher()
+			publisher.Subscribe(func(context.Context, entity.Event) error { return errBrokerDown }, tt.failing)
+
+			relay := outbox.NewRelay(mockbuilder.NewTxManagerBuilder(t).Build(), store.Build(), publisher, outbox.RelayOptions{
+				MinBackoff: time.Second,
+				MaxBackoff: time.Minute,
+			})
+
+			// Act
+			n, err := relay.RelayBatch(t.Context())
+
+			// Assert
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCount, n)
+		})
+	}
+}
+
+func TestRelay_RunStopsWithContext(t *testing.T) {
+	t.Parallel()
+
+	event := entity.NewProductDeleted(datatest.FakeProductID)
+	store := mockbuilder.NewOutboxRepoBuilder(t).
+		ClaimPendingReturns(dto.OutboxMessage{Event: event}).
+		MarkSentExpects(event).
+		PurgeSentAny()
+
+	var published atomic.Int32
+	publisher := outbox.NewInProcessPublisher()
+	publisher.Subscribe(func(context.Context, entity.Event) error {
+		published.Add(1)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	relay := outbox.NewRelay(mockbuilder.NewTxManagerBuilder(t).Build(), store.Build(), publisher, outbox.RelayOptions{
+		PollInterval: time.Hour,
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool { return published.Load() == 1 }, time.Second, time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("relay did not stop after its context was cancelled")
+	}
+}
+
+func TestRelay_PurgeBatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		opts          outbox.RelayOptions
+		setupOutbox   func(b *mockbuilder.OutboxRepoBuilder)
+		expectedCount int
+		expectedErr   error
+	}{
+		{
+			name:          "configured retention",
+			opts:          outbox.RelayOptions{Retention: 48 * time.Hour, BatchSize: 10},
+			setupOutbox:   func(b *mockbuilder.OutboxRepoBuilder) { b.PurgeSentExpects(48*time.Hour, 10, 10) },
+			expectedCount: 10,
+		},
+		{
+			name: "default retention",
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) {
+				b.PurgeSentExpects(outbox.DefaultRetention, outbox.DefaultBatchSize, 3)
+			},
+			expectedCount: 3,
+		},
+		{
+			name:        "purge failure",
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) { b.PurgeSentErrorDB() },
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			store := mockbuilder.NewOutboxRepoBuilder(t)
+			tt.setupOutbox(store)
+			relay := outbox.NewRelay(mockbuilder.NewTxManagerBuilder(t).Build(), store.Build(),
+				outbox.NewInProcessPublisher(), tt.opts)
+
+			// Act
+			n, err := relay.PurgeBatch(t.Context())
+
+			// Assert
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedCount, n)
+		})
+	}
+}
+
+// A subscriber writing through the claim transaction must not abort it when its write
+// fails: its savepoint is rolled back and the event is still rescheduled and committed.
+func TestRelay_RelayBatch_SubscriberDBError(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	require.NoError(t, err)
+
+	event := entity.NewProductDeleted(datatest.FakeProductID)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "outbox" .* FOR UPDATE SKIP LOCKED`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "aggregate_id", "payload", "occurred_at", "attempts"}).
+			AddRow(event.ID, string(event.Type), event.AggregateID, string(event.Payload), event.OccurredAt, 0))
+	mock.ExpectExec(`SAVEPOINT sp`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO "outbox"`).WillReturnError(datatest.ErrUnexpectedDB)
+	mock.ExpectExec(`ROLLBACK TO SAVEPOINT sp`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`UPDATE outbox\s+SET attempts = attempts \+ 1`).
+		WithArgs(float64(1), sqlmock.AnyArg(), event.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	outboxRepo := repository.NewOutboxRepository(db)
+	publisher := outbox.NewInProcessPublisher()
+	publisher.Subscribe(func(ctx context.Context, e entity.Event) error {
+		// Stands for a subscriber recording follow-up work in the same database.
+		return outboxRepo.Add(ctx, entity.NewProductDeleted(e.AggregateID))
+	})
+
+	relay := outbox.NewRelay(repository.NewTxManager(db), outboxRepo, publisher, outbox.RelayOptions{
+		MinBackoff: time.Second,
+	})
+
+	// Act
+	n, err := relay.RelayBatch(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
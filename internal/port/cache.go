@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores opaque values by key for a limited time. It is a best-effort store:
+// callers must keep working, from the source of truth, when it fails.
+type Cache interface {
+	// Get returns the value stored under key; found is false when there is none or it expired.
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+
+	// Set stores value under key for ttl, replacing any previous value.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the given keys; missing keys are ignored.
+	Delete(ctx context.Context, keys ...string) error
+}
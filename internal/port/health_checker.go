@@ -0,0 +1,19 @@
+// Package port defines the interfaces (ports) that represent dependencies of the use case layer.
+// These ports are implemented by the infrastructure layer and injected into the use cases,
+// enabling inversion of control and decoupling business logic from external systems.
+package port
+
+import "context"
+
+// HealthChecker reports whether an external dependency (database, cache, ...) is usable.
+//
+// Implementations live next to the adapter they check and are registered with the
+// readiness endpoint, so adding a dependency such as Redis only needs a new checker.
+type HealthChecker interface {
+	// Name identifies the dependency in the readiness report, e.g. "postgres".
+	Name() string
+
+	// Check returns nil when the dependency is reachable. It must honor ctx cancellation,
+	// since the readiness endpoint bounds every check with a timeout.
+	Check(ctx context.Context) error
+}
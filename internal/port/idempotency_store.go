@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+)
+
+// IdempotencyStore remembers requests sent with an idempotency key, so a retried
+// request replays the original response instead of being processed twice.
+//
+// Every method must be safe for concurrent use: the lock taken by Acquire is what
+// stops two in-flight duplicates from both reaching the usecase.
+type IdempotencyStore interface {
+	// Acquire atomically claims key for a request with the given fingerprint and holds
+	// it for lockTTL. When it returns a non-empty token, the caller owns the key and must
+	// call Complete or Release with that token. Otherwise the live record for the key is
+	// returned: either a completed response or a request still in progress. Expired
+	// records are ignored, so a key whose lock expired is taken over with a new token.
+	Acquire(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (record *dto.IdempotencyRecord, token string, err error)
+
+	// Complete stores the response of the request holding key with token and keeps it
+	// for ttl. It fails with an apperror.Conflict once the lock has expired or been taken over.
+	Complete(ctx context.Context, key, token string, response dto.IdempotentResponse, ttl time.Duration) error
+
+	// Release forgets key without storing a response, so the request can be retried.
+	// Like Complete, it fails when token no longer holds the key.
+	Release(ctx context.Context, key, token string) error
+}
@@ -0,0 +1,17 @@
+package port
+
+import "context"
+
+// Logger writes structured log records.
+//
+// args are alternating key/value pairs (or slog.Attr values), e.g.
+// logger.Error(ctx, "failed to create product", "error", err).
+// Fields attached to ctx by the request middleware, such as the request ID,
+// are added to every record, so usecases get the same correlation fields as
+// the delivery layer without having to pass them around.
+type Logger interface {
+	Debug(ctx context.Context, msg string, args ...any)
+	Info(ctx context.Context, msg string, args ...any)
+	Warn(ctx context.Context, msg string, args ...any)
+	Error(ctx context.Context, msg string, args ...any)
+}
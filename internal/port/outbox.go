@@ -0,0 +1,54 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/google/uuid"
+)
+
+// OutboxRepository keeps domain events next to the data they describe (transactional
+// outbox): events added inside TxManager.WithinTx commit or roll back together with
+// the writes that raised them, and a relay publishes them afterwards.
+type OutboxRepository interface {
+	// Add stores events to be published.
+	Add(ctx context.Context, events ...entity.Event) error
+
+	// ClaimPending returns up to limit unsent events that are due, oldest first, and locks
+	// them until the surrounding transaction ends. Events locked by another relay are
+	// skipped, so several instances can relay concurrently without publishing twice.
+	// It must be called inside TxManager.WithinTx.
+	ClaimPending(ctx context.Context, limit int) ([]dto.OutboxMessage, error)
+
+	// MarkSent records that the event has been published.
+	MarkSent(ctx context.Context, id uuid.UUID) error
+
+	// MarkFailed records a failed delivery and postpones the next one by retryIn.
+	MarkFailed(ctx context.Context, id uuid.UUID, retryIn time.Duration, cause string) error
+
+	// PurgeSent deletes up to limit events published more than retention ago and
+	// returns how many it deleted. Unsent events are never deleted.
+	PurgeSent(ctx context.Context, retention time.Duration, limit int) (int, error)
+}
+
+// EventPublisher delivers domain events to the services interested in them.
+// Delivery is at least once: an event may be published again after a failure,
+// so consumers must tolerate duplicates.
+type EventPublisher interface {
+	Publish(ctx context.Context, event entity.Event) error
+}
+
+// EventBroadcaster shares published events between the instances of the service. The
+// relay publishes each event on a single instance, so consumers that every instance
+// serves on its own, such as gRPC watch streams, listen to the broadcast instead.
+type EventBroadcaster interface {
+	// Broadcast hands event to the listeners of every instance once the surrounding
+	// transaction commits. It has the signature of an EventPublisher subscriber.
+	Broadcast(ctx context.Context, event entity.Event) error
+
+	// Listen calls handle with each broadcast event until ctx is cancelled. Events
+	// broadcast while the listener is reconnecting are missed.
+	Listen(ctx context.Context, handle func(ctx context.Context, event entity.Event) error)
+}
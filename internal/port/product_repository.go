@@ -6,7 +6,9 @@ package port
 import (
 	"context"
 
+	"github.com/DucTran999/go-clean-archx/internal/dto"
 	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/google/uuid"
 )
 
 // ProductRepository defines the expected behavior for persisting products.
@@ -14,5 +16,41 @@ import (
 // It is implemented by the infrastructure layer (e.g., database adapter).
 // Dependency inversion principle (DIP)
 type ProductRepository interface {
+	// Create inserts a new product and fills in its generated fields.
+	// It returns entity.ErrProductSKUTaken when another product has the same SKU.
 	Create(ctx context.Context, product *entity.Product) error
+
+	// UpsertBySKU creates the product, or atomically replaces the name, qty and price of
+	// the product with the same SKU (restoring it if it was soft-deleted).
+	// product is refreshed with the stored row; created reports which of the two happened.
+	//
+	// An existing product is only replaced while its version equals *expectedVersion, or
+	// whatever its version when *expectedVersion is 0; otherwise entity.ErrProductVersionMismatch
+	// is returned. A nil expectedVersion never replaces: it returns entity.ErrProductVersionRequired.
+	UpsertBySKU(ctx context.Context, product *entity.Product, expectedVersion *int64) (created bool, err error)
+
+	// GetByID returns the product with the given ID.
+	// Soft-deleted products are only returned when query.IncludeDeleted is set.
+	// With query.ForUpdate, the product is locked until the surrounding transaction ends.
+	// It returns entity.ErrProductNotFound when no product matches.
+	GetByID(ctx context.Context, query dto.GetProductQuery) (*entity.Product, error)
+
+	// List returns at most query.Limit products matching the query filters, ordered by
+	// query.SortBy and starting right after query.Cursor when it is set.
+	// Soft-deleted products are hidden unless query.IncludeDeleted is set.
+	List(ctx context.Context, query dto.ListProductsQuery) ([]entity.Product, error)
+
+	// Update persists the mutable fields of an existing product and refreshes
+	// it with the stored values (including the trigger-maintained UpdatedAt).
+	// It returns entity.ErrProductNotFound when no product matches and
+	// entity.ErrProductSKUTaken when the new SKU belongs to another product.
+	Update(ctx context.Context, product *entity.Product) error
+
+	// Delete soft-deletes the product with the given ID.
+	// It returns entity.ErrProductNotFound when no live product matches.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Restore clears the soft-delete marker and returns the restored product.
+	// It returns entity.ErrProductNotFound when no soft-deleted product matches.
+	Restore(ctx context.Context, id uuid.UUID) (*entity.Product, error)
 }
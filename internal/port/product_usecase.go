@@ -8,6 +8,7 @@ import (
 
 	"github.com/DucTran999/go-clean-archx/internal/dto"
 	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/google/uuid"
 )
 
 // ProductUsecase defines the contract for product-related business logic.
@@ -16,4 +17,27 @@ import (
 // Dependency inversion principle (DIP)
 type ProductUsecase interface {
 	CreateProduct(ctx context.Context, input dto.CreateProductInput) (*entity.Product, error)
+
+	// UpsertProductBySKU creates the product identified by input.SKU or replaces it in place,
+	// provided input.ExpectedVersion allows it. created is true when a new product was stored.
+	UpsertProductBySKU(ctx context.Context, input dto.UpsertProductInput) (product *entity.Product, created bool, err error)
+
+	// GetByID returns a single product, or entity.ErrProductNotFound if it does not exist.
+	GetByID(ctx context.Context, query dto.GetProductQuery) (*entity.Product, error)
+
+	// List returns one page of products; dto.ErrInvalidListQuery is returned for invalid queries.
+	List(ctx context.Context, query dto.ListProductsQuery) (*dto.ProductPage, error)
+
+	// UpdateProduct replaces the name, qty and price of an existing product,
+	// and its SKU when one is given.
+	UpdateProduct(ctx context.Context, input dto.UpdateProductInput) (*entity.Product, error)
+
+	// PatchProduct updates only the fields present in the input.
+	PatchProduct(ctx context.Context, input dto.PatchProductInput) (*entity.Product, error)
+
+	// DeleteProduct soft-deletes a product; it disappears from reads but is kept for reporting.
+	DeleteProduct(ctx context.Context, id uuid.UUID) error
+
+	// RestoreProduct brings a soft-deleted product back.
+	RestoreProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error)
 }
@@ -0,0 +1,15 @@
+package port
+
+import "context"
+
+// TxManager runs a unit of work atomically across repositories.
+//
+// The transaction travels in the context passed to fn: repositories called with that
+// context join it instead of using their own connection, so usecases never handle
+// a database handle directly.
+type TxManager interface {
+	// WithinTx runs fn in a transaction that is committed when fn returns nil and rolled
+	// back when it returns an error or panics. Called again inside fn, it opens a nested
+	// transaction (a savepoint) whose failure only undoes the nested writes.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,81 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/google/uuid"
+)
+
+// WebhookRepository persists webhook subscriptions and the log of their deliveries.
+type WebhookRepository interface {
+	// CreateSubscription inserts a subscription and fills in its generated fields.
+	CreateSubscription(ctx context.Context, sub *entity.WebhookSubscription) error
+
+	// GetSubscription returns the subscription with the given ID,
+	// or entity.ErrWebhookNotFound when there is none.
+	GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error)
+
+	// ListSubscriptions returns every subscription, oldest first.
+	ListSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+
+	// UpdateSubscription persists the settings of an existing subscription.
+	// It returns entity.ErrWebhookNotFound when no subscription matches.
+	UpdateSubscription(ctx context.Context, sub *entity.WebhookSubscription) error
+
+	// DeleteSubscription removes a subscription together with its deliveries.
+	// It returns entity.ErrWebhookNotFound when no subscription matches.
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+
+	// ListSubscribers returns the active subscriptions to the given event type.
+	ListSubscribers(ctx context.Context, eventType entity.EventType) ([]entity.WebhookSubscription, error)
+
+	// AddDeliveries stores deliveries to be sent. A delivery of an event already stored
+	// for the same subscription is ignored, so an event handled twice is sent once.
+	AddDeliveries(ctx context.Context, deliveries ...entity.WebhookDelivery) error
+
+	// ClaimDueDeliveries returns up to limit pending deliveries that are due, oldest first,
+	// and postpones their next attempt by lease so that no other dispatcher claims them
+	// meanwhile; deliveries being claimed by another dispatcher are skipped. It must be
+	// called inside TxManager.WithinTx.
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]dto.WebhookDispatch, error)
+
+	// UpdateDelivery persists the outcome of a delivery attempt.
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+
+	// ListDeliveries returns the deliveries of a subscription, newest first.
+	ListDeliveries(ctx context.Context, query dto.ListWebhookDeliveriesQuery) ([]entity.WebhookDelivery, error)
+}
+
+// WebhookSender sends a delivery to the partner endpoint. It returns the response
+// status code, or 0 when no response was received; any status other than 2xx is an error.
+type WebhookSender interface {
+	Send(ctx context.Context, dispatch dto.WebhookDispatch) (statusCode int, err error)
+}
+
+// WebhookUsecase defines the contract for managing webhook subscriptions.
+type WebhookUsecase interface {
+	// CreateWebhook subscribes a partner endpoint; the returned subscription holds its secret.
+	CreateWebhook(ctx context.Context, input dto.CreateWebhookInput) (*entity.WebhookSubscription, error)
+
+	// GetWebhook returns a subscription, or entity.ErrWebhookNotFound if it does not exist.
+	GetWebhook(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error)
+
+	// ListWebhooks returns every subscription.
+	ListWebhooks(ctx context.Context) ([]entity.WebhookSubscription, error)
+
+	// UpdateWebhook replaces the settings of a subscription.
+	UpdateWebhook(ctx context.Context, input dto.UpdateWebhookInput) (*entity.WebhookSubscription, error)
+
+	// DeleteWebhook removes a subscription; its pending deliveries are dropped.
+	DeleteWebhook(ctx context.Context, id uuid.UUID) error
+
+	// ListDeliveries returns the delivery log of a subscription, for debugging.
+	ListDeliveries(ctx context.Context, query dto.ListWebhookDeliveriesQuery) ([]entity.WebhookDelivery, error)
+
+	// HandleEvent queues a delivery of event to every active subscriber of its type.
+	// It is idempotent, so it can be subscribed to an at-least-once EventPublisher.
+	HandleEvent(ctx context.Context, event entity.Event) error
+}
@@ -0,0 +1,45 @@
+// Package publicerr decides which part of an error returned by a usecase a client may
+// see. The gRPC and GraphQL adapters only add their own codes and wire formats on top.
+package publicerr
+
+import (
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+)
+
+// InternalMessage is all a client learns about an unexpected failure.
+const InternalMessage = "internal server error"
+
+// Error is the client-facing view of an error.
+type Error struct {
+	// Kind is the kind of the original error, also when Internal is set.
+	Kind apperror.Kind
+
+	// Message is safe to show to the client.
+	Message string
+
+	// Fields lists the offending fields of invalid input.
+	Fields []entity.FieldError
+
+	// Internal reports an unexpected failure: Message is InternalMessage, and the
+	// original error should be logged since the client will not see it.
+	Internal bool
+}
+
+// Of returns what a client may see of err: the message carried by its apperror value,
+// never the text of the errors it wraps, which may come from the database. Invalid input
+// is detailed by the fields of an entity.ValidationError; an error without a kind or a
+// message is internal.
+func Of(err error) Error {
+	kind := apperror.KindOf(err)
+	msg := apperror.MessageOf(err)
+
+	switch {
+	case kind == apperror.Unknown || msg == "":
+		return Error{Kind: kind, Message: InternalMessage, Internal: true}
+	case kind == apperror.Invalid:
+		return Error{Kind: kind, Message: msg, Fields: entity.FieldErrors(err)}
+	default:
+		return Error{Kind: kind, Message: msg}
+	}
+}
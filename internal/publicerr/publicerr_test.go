@@ -0,0 +1,54 @@
+package publicerr_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/publicerr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOf(t *testing.T) {
+	t.Parallel()
+
+	nameEmpty := entity.FieldError{Field: "name", Code: entity.CodeRequired, Message: "name cannot be empty"}
+	invalid := &entity.ValidationError{Err: entity.ErrProductInvalid, Fields: []entity.FieldError{nameEmpty}}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected publicerr.Error
+	}{
+		{
+			name:     "kind and message are shown",
+			err:      fmt.Errorf("failed to get product: %w", entity.ErrProductNotFound),
+			expected: publicerr.Error{Kind: apperror.NotFound, Message: entity.ErrProductNotFound.Error()},
+		},
+		{
+			name:     "invalid input lists the fields",
+			err:      fmt.Errorf("product validation failed: %w", invalid),
+			expected: publicerr.Error{Kind: apperror.Invalid, Message: entity.ErrProductInvalid.Error(), Fields: []entity.FieldError{nameEmpty}},
+		},
+		{
+			name:     "unexpected error is internal",
+			err:      errors.New("connection refused"),
+			expected: publicerr.Error{Kind: apperror.Unknown, Message: publicerr.InternalMessage, Internal: true},
+		},
+		{
+			name:     "kind without a message is internal",
+			err:      apperror.Wrap(apperror.Unavailable, errors.New("pool exhausted"), ""),
+			expected: publicerr.Error{Kind: apperror.Unavailable, Message: publicerr.InternalMessage, Internal: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, publicerr.Of(tt.err))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// lruEntry is a cached value with its expiry.
+type lruEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// lruCache keeps values in process memory and evicts the least recently used ones
+// once it holds size entries. Like memoryIdempotencyStore, it suits tests and
+// single-instance deployments: other instances never see its invalidations.
+type lruCache struct {
+	entries *lru.Cache[string, lruEntry]
+}
+
+// NewLRUCache creates an in-process Cache holding at most size entries.
+func NewLRUCache(size int) (port.Cache, error) {
+	entries, err := lru.New[string, lruEntry](size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lruCache{
+		entries: entries,
+	}, nil
+}
+
+// Get returns the value stored under key. Expired entries are dropped when read.
+func (c *lruCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	entry, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+	if !time.Now().Before(entry.expiresAt) {
+		c.entries.Remove(key)
+		return nil, false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+// Set stores value under key until ttl elapses.
+func (c *lruCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.entries.Add(key, lruEntry{value: value, expiresAt: time.Now().Add(ttl)})
+
+	return nil
+}
+
+// Delete removes the given keys.
+func (c *lruCache) Delete(_ context.Context, keys ...string) error {
+	for _, key := range keys {
+		c.entries.Remove(key)
+	}
+
+	return nil
+}
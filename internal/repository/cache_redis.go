@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisCache is the Redis-backed implementation of port.Cache, shared by every instance.
+type redisCache struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisCache creates a Cache storing values in Redis. Every key is prefixed with
+// prefix, so several services can share a database without clashing.
+func NewRedisCache(client redis.UniversalClient, prefix string) port.Cache {
+	return &redisCache{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Get returns the value stored under key.
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, translateRedisError(err)
+	}
+
+	return value, true, nil
+}
+
+// Set stores value under key; Redis expires it after ttl.
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return translateRedisError(c.client.Set(ctx, c.prefix+key, value, ttl).Err())
+}
+
+// Delete removes the given keys in a single round trip.
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	prefixed := make([]string, 0, len(keys))
+	for _, key := range keys {
+		prefixed = append(prefixed, c.prefix+key)
+	}
+
+	return translateRedisError(c.client.Del(ctx, prefixed...).Err())
+}
+
+// translateRedisError reports every Redis failure as apperror.Unavailable: to its
+// callers, a cache that cannot answer is simply not there.
+func translateRedisError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return apperror.Wrap(apperror.Unavailable, err, "cache unavailable")
+}
@@ -0,0 +1,111 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRedisCache returns a Cache backed by an in-process Redis stand-in.
+func newRedisCache(t *testing.T) (port.Cache, *miniredis.Miniredis) {
+	t.Helper()
+
+	srv := miniredis.RunT(t)
+	// No retries, so tests of an unreachable Redis fail fast.
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return repository.NewRedisCache(client, "test:"), srv
+}
+
+func TestCache_Backends(t *testing.T) {
+	t.Parallel()
+
+	backends := []struct {
+		name  string
+		setup func(t *testing.T) (cache port.Cache, expire func(d time.Duration))
+	}{
+		{
+			name: "redis",
+			setup: func(t *testing.T) (port.Cache, func(time.Duration)) {
+				t.Helper()
+				cache, srv := newRedisCache(t)
+				return cache, srv.FastForward
+			},
+		},
+		{
+			name: "lru",
+			setup: func(t *testing.T) (port.Cache, func(time.Duration)) {
+				t.Helper()
+				cache, err := repository.NewLRUCache(10)
+				require.NoError(t, err)
+				return cache, time.Sleep
+			},
+		},
+	}
+
+	for _, bk := range backends {
+		t.Run(bk.name, func(t *testing.T) {
+			t.Parallel()
+
+			cache, expire := bk.setup(t)
+			ctx := t.Context()
+
+			_, found, err := cache.Get(ctx, "a")
+			require.NoError(t, err)
+			assert.False(t, found, "empty cache")
+
+			require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+			require.NoError(t, cache.Set(ctx, "b", []byte("2"), 50*time.Millisecond))
+			value, found, err := cache.Get(ctx, "a")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, []byte("1"), value)
+
+			require.NoError(t, cache.Delete(ctx, "a", "missing"))
+			_, found, _ = cache.Get(ctx, "a")
+			assert.False(t, found, "deleted key")
+
+			expire(60 * time.Millisecond)
+			_, found, _ = cache.Get(ctx, "b")
+			assert.False(t, found, "expired key")
+		})
+	}
+}
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	cache, err := repository.NewLRUCache(2)
+	require.NoError(t, err)
+	ctx := t.Context()
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, cache.Set(ctx, "b", []byte("2"), time.Minute))
+	_, _, _ = cache.Get(ctx, "a")
+	require.NoError(t, cache.Set(ctx, "c", []byte("3"), time.Minute))
+
+	_, found, _ := cache.Get(ctx, "b")
+	assert.False(t, found)
+	_, found, _ = cache.Get(ctx, "a")
+	assert.True(t, found)
+}
+
+func TestRedisCache_PrefixAndFailure(t *testing.T) {
+	t.Parallel()
+
+	cache, srv := newRedisCache(t)
+	require.NoError(t, cache.Set(t.Context(), "a", []byte("1"), time.Minute))
+	assert.True(t, srv.Exists("test:a"))
+
+	srv.Close()
+	_, _, err := cache.Get(t.Context(), "a")
+	assert.Equal(t, apperror.Unavailable, apperror.KindOf(err))
+}
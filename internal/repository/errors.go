@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// PostgreSQL error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html.
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+	pgNotNullViolation    = "23502"
+	pgSerializationFail   = "40001"
+	pgDeadlockDetected    = "40P01"
+	pgTooManyConnections  = "53300"
+	pgAdminShutdown       = "57P01"
+	pgCannotConnectNow    = "57P03"
+	pgConnectionException = "08" // class prefix
+)
+
+// translateError maps GORM and PostgreSQL errors onto apperror kinds, so the layers
+// above never need to know which database produced them. The original error stays
+// in the chain for logging; unrecognized errors are returned unchanged (kind Unknown).
+func translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperror.Wrap(apperror.NotFound, err, "record not found")
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return apperror.Wrap(apperror.Conflict, err, "resource already exists")
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, driver.ErrBadConn) {
+		return apperror.Wrap(apperror.Unavailable, err, "database unavailable")
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch {
+	case pgErr.Code == pgUniqueViolation:
+		return apperror.Wrap(apperror.Conflict, err, "resource already exists")
+	case pgErr.Code == pgForeignKeyViolation:
+		return apperror.Wrap(apperror.Conflict, err, "resource is referenced by or references a missing resource")
+	case pgErr.Code == pgCheckViolation, pgErr.Code == pgNotNullViolation:
+		return apperror.Wrap(apperror.Invalid, err, "value violates a database constraint")
+	case pgErr.Code == pgSerializationFail, pgErr.Code == pgDeadlockDetected:
+		return apperror.Wrap(apperror.Unavailable, err, "concurrent update, please retry")
+	case pgErr.Code == pgTooManyConnections, pgErr.Code == pgAdminShutdown, pgErr.Code == pgCannotConnectNow,
+		strings.HasPrefix(pgErr.Code, pgConnectionException):
+		return apperror.Wrap(apperror.Unavailable, err, "database unavailable")
+	}
+
+	return err
+}
+
+// isUniqueViolation reports whether err is a unique violation of the given index or constraint,
+// which lets a repository turn a specific duplicate into a meaningful domain error.
+func isUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == constraint
+}
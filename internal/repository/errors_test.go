@@ -0,0 +1,55 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductRepo_TranslatesDatabaseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		dbErr        error
+		expectedKind apperror.Kind
+	}{
+		{name: "unique violation", dbErr: &pgconn.PgError{Code: "23505"}, expectedKind: apperror.Conflict},
+		{name: "foreign key violation", dbErr: &pgconn.PgError{Code: "23503"}, expectedKind: apperror.Conflict},
+		{name: "check violation", dbErr: &pgconn.PgError{Code: "23514"}, expectedKind: apperror.Invalid},
+		{name: "serialization failure", dbErr: &pgconn.PgError{Code: "40001"}, expectedKind: apperror.Unavailable},
+		{name: "connection failure", dbErr: &pgconn.PgError{Code: "08006"}, expectedKind: apperror.Unavailable},
+		{name: "deadline exceeded", dbErr: context.DeadlineExceeded, expectedKind: apperror.Unavailable},
+		{name: "unrecognized error", dbErr: datatest.ErrUnexpectedDB, expectedKind: apperror.Unknown},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock := newMockDB(t)
+			repo := repository.NewProductRepository(db)
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(`INSERT INTO "products"`).WillReturnError(tc.dbErr)
+			mock.ExpectRollback()
+
+			// Act
+			err := repo.Create(context.Background(), &entity.Product{Name: "Laptop", Qty: 1, Price: entity.NewMoney(1000, "USD")})
+
+			// Assert
+			require.ErrorIs(t, err, tc.dbErr, "the original error stays in the chain for logging")
+			assert.Equal(t, tc.expectedKind, apperror.KindOf(err))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/worker"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+// EventChannel is the PostgreSQL notification channel carrying broadcast events.
+const EventChannel = "outbox_events"
+
+// listenBackoff spaces the attempts to re-open a lost LISTEN connection.
+var listenBackoff = worker.Backoff{Min: time.Second, Max: 30 * time.Second}
+
+// postgresEventBroadcaster broadcasts events with NOTIFY. Notifications carry the event
+// ID only, which keeps them far below the 8000-byte payload limit; listeners read the
+// event back from the outbox, where sent events are kept for the relay's retention.
+type postgresEventBroadcaster struct {
+	db     *gorm.DB
+	logger port.Logger
+}
+
+// NewPostgresEventBroadcaster creates an EventBroadcaster on the database of db.
+// Listen holds one connection of the pool for as long as it runs; logger reports
+// lost connections and unreadable events, nil discards them.
+func NewPostgresEventBroadcaster(db *gorm.DB, log port.Logger) port.EventBroadcaster {
+	if log == nil {
+		log = logger.NewNop()
+	}
+
+	return &postgresEventBroadcaster{
+		db:     db,
+		logger: log,
+	}
+}
+
+// Broadcast sends a notification, which PostgreSQL delivers when the transaction of ctx
+// commits, so a relay that fails after publishing does not announce the event twice.
+func (b *postgresEventBroadcaster) Broadcast(ctx context.Context, event entity.Event) error {
+	return translateError(conn(ctx, b.db).Exec(`SELECT pg_notify(?, ?)`, EventChannel, event.ID.String()).Error)
+}
+
+// Listen opens a connection listening on EventChannel and re-opens it when it is lost.
+func (b *postgresEventBroadcaster) Listen(ctx context.Context, handle func(ctx context.Context, event entity.Event) error) {
+	failures := 0
+	for {
+		err := b.listen(ctx, handle, func() { failures = 0 })
+		if ctx.Err() != nil {
+			return
+		}
+		failures++
+		b.logger.Warn(ctx, "lost the event broadcast connection, reconnecting", "attempts", failures, "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenBackoff.Delay(failures)):
+		}
+	}
+}
+
+// listen runs one LISTEN session, calling listening once it is established. The
+// connection is never returned to the pool, where it would keep receiving notifications.
+func (b *postgresEventBroadcaster) listen(
+	ctx context.Context, handle func(ctx context.Context, event entity.Event) error, listening func(),
+) error {
+	sqlDB, err := b.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+	sqlConn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get a connection: %w", err)
+	}
+	defer sqlConn.Close()
+
+	var listenErr error
+	_ = sqlConn.Raw(func(driverConn any) error {
+		listenErr = b.receive(ctx, driverConn, handle, listening)
+		// Tell database/sql to close the connection instead of pooling it.
+		return driver.ErrBadConn
+	})
+
+	return listenErr
+}
+
+// receive listens on driverConn and delivers notifications until it fails.
+func (b *postgresEventBroadcaster) receive(
+	ctx context.Context, driverConn any, handle func(ctx context.Context, event entity.Event) error, listening func(),
+) error {
+	stdConn, ok := driverConn.(*stdlib.Conn)
+	if !ok {
+		return fmt.Errorf("unexpected driver connection %T", driverConn)
+	}
+	pgConn := stdConn.Conn()
+
+	if _, err := pgConn.Exec(ctx, "LISTEN "+EventChannel); err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	listening()
+
+	for {
+		notification, err := pgConn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for notifications: %w", err)
+		}
+		b.deliver(ctx, notification.Payload, handle)
+	}
+}
+
+// deliver reads back the event named by a notification and hands it to handle.
+func (b *postgresEventBroadcaster) deliver(
+	ctx context.Context, payload string, handle func(ctx context.Context, event entity.Event) error,
+) {
+	id, err := uuid.Parse(payload)
+	if err != nil {
+		b.logger.Warn(ctx, "ignored a malformed event notification", "payload", payload)
+		return
+	}
+
+	var row outboxMessage
+	if err := b.db.WithContext(ctx).Where("id = ?", id).Take(&row).Error; err != nil {
+		b.logger.Warn(ctx, "failed to read a broadcast event", "event_id", id, "error", err)
+		return
+	}
+
+	event := row.toMessage().Event
+	if err := handle(ctx, event); err != nil {
+		b.logger.Warn(ctx, "failed to handle a broadcast event", "event_id", id, "event_type", event.Type, "error", err)
+	}
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+)
+
+// memoryEventBroadcaster hands events to the listeners of this process, which is
+// every instance there is when the data lives in process memory.
+type memoryEventBroadcaster struct {
+	mu        sync.RWMutex
+	listeners map[int]func(ctx context.Context, event entity.Event) error
+	nextID    int
+}
+
+// NewMemoryEventBroadcaster creates an in-process EventBroadcaster.
+func NewMemoryEventBroadcaster() port.EventBroadcaster {
+	return &memoryEventBroadcaster{
+		listeners: make(map[int]func(ctx context.Context, event entity.Event) error),
+	}
+}
+
+// Broadcast calls every listener right away; their errors are not reported, as a
+// listener of another instance could not report them either.
+func (b *memoryEventBroadcaster) Broadcast(ctx context.Context, event entity.Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, handle := range b.listeners {
+		_ = handle(ctx, event)
+	}
+
+	return nil
+}
+
+// Listen registers handle until ctx is cancelled.
+func (b *memoryEventBroadcaster) Listen(ctx context.Context, handle func(ctx context.Context, event entity.Event) error) {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.listeners[id] = handle
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.listeners, id)
+	b.mu.Unlock()
+}
@@ -0,0 +1,144 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/test/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresEventBroadcaster_Broadcast(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	event := entity.Event{ID: uuid.New(), Type: entity.EventProductCreated}
+	mock.ExpectExec(`SELECT pg_notify\(\$1, \$2\)`).
+		WithArgs(repository.EventChannel, event.ID.String()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Act
+	err := repository.NewPostgresEventBroadcaster(db, logger.NewNop()).Broadcast(t.Context(), event)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresEventBroadcaster_Listen(t *testing.T) {
+	// Two pools stand for two instances: one relays, the other serves a watcher.
+	relayDB := dbtest.Open(t)
+	dbtest.Migrate(t, relayDB)
+	watcherDB := dbtest.Open(t)
+
+	txManager := repository.NewTxManager(relayDB)
+	outboxRepo := repository.NewOutboxRepository(relayDB)
+	relayer := repository.NewPostgresEventBroadcaster(relayDB, logger.NewNop())
+	watcher := repository.NewPostgresEventBroadcaster(watcherDB, logger.NewNop())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	received := make(chan entity.Event, 16)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		watcher.Listen(ctx, func(ctx context.Context, event entity.Event) error {
+			select {
+			case received <- event:
+			case <-ctx.Done():
+			}
+			return nil
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	broadcast := func(event entity.Event, commit bool) {
+		err := txManager.WithinTx(t.Context(), func(ctx context.Context) error {
+			if err := outboxRepo.Add(ctx, event); err != nil {
+				return err
+			}
+			if err := relayer.Broadcast(ctx, event); err != nil {
+				return err
+			}
+			if !commit {
+				return errors.New("rolled back")
+			}
+			return nil
+		})
+		if commit {
+			require.NoError(t, err)
+		}
+	}
+
+	// A rolled-back broadcast is never delivered; the committed one after it is, in full.
+	payload := json.RawMessage(`{"name":"Mug"}`)
+	rolledBack := entity.Event{ID: uuid.New(), Type: entity.EventProductUpdated, OccurredAt: time.Now(), Payload: payload}
+	broadcast(rolledBack, false)
+
+	var got entity.Event
+	require.Eventually(t, func() bool {
+		// The listener may not be connected yet: broadcast until it answers.
+		committed := entity.Event{ID: uuid.New(), Type: entity.EventProductCreated, AggregateID: uuid.New(),
+			OccurredAt: time.Now(), Payload: payload}
+		broadcast(committed, true)
+
+		select {
+		case got = <-received:
+			return true
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.NotEqual(t, rolledBack.ID, got.ID)
+	assert.Equal(t, entity.EventProductCreated, got.Type)
+	assert.NotEqual(t, uuid.Nil, got.AggregateID)
+	assert.JSONEq(t, string(payload), string(got.Payload))
+}
+
+func TestMemoryEventBroadcaster(t *testing.T) {
+	t.Parallel()
+
+	broadcaster := repository.NewMemoryEventBroadcaster()
+	event := entity.Event{ID: uuid.New(), Type: entity.EventProductCreated}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	received := make(chan entity.Event, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		broadcaster.Listen(ctx, func(_ context.Context, event entity.Event) error {
+			received <- event
+			return errors.New("listener errors are not reported")
+		})
+	}()
+
+	// Listen registers asynchronously: broadcast until the listener answers.
+	require.Eventually(t, func() bool {
+		require.NoError(t, broadcaster.Broadcast(t.Context(), event))
+		select {
+		case got := <-received:
+			return assert.Equal(t, event, got)
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	// A listener whose context ends is unregistered.
+	cancel()
+	<-done
+	require.NoError(t, broadcaster.Broadcast(t.Context(), event))
+	assert.Empty(t, received)
+}
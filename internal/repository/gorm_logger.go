@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// DefaultSlowQueryThreshold is the duration above which a query is logged as slow.
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// gormLogger routes GORM's logs through port.Logger. Because repositories always call
+// db.WithContext(ctx), every failed or slow query is logged with the request's context
+// and therefore carries its request ID.
+type gormLogger struct {
+	logger        port.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+// NewGormLogger returns a GORM logger that reports failed queries as errors and queries
+// slower than slowThreshold as warnings. A zero threshold disables slow query logging.
+func NewGormLogger(logger port.Logger, slowThreshold time.Duration) gormlogger.Interface {
+	return &gormLogger{
+		logger:        logger,
+		level:         gormlogger.Warn,
+		slowThreshold: slowThreshold,
+	}
+}
+
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *l
+	clone.level = level
+	return &clone
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, args ...any) {
+	if l.level >= gormlogger.Info {
+		l.logger.Info(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, args ...any) {
+	if l.level >= gormlogger.Warn {
+		l.logger.Warn(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, args ...any) {
+	if l.level >= gormlogger.Error {
+		l.logger.Error(ctx, fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace is called by GORM after every statement.
+// gorm.ErrRecordNotFound is an expected outcome, not a failure, so it is never logged as one.
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		l.logger.Error(ctx, "database query failed",
+			"error", err, "sql", sql, "rows", rows, "elapsed_ms", durationMS(elapsed))
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		l.logger.Warn(ctx, "slow database query",
+			"sql", sql, "rows", rows, "elapsed_ms", durationMS(elapsed), "threshold", l.slowThreshold)
+	case l.level >= gormlogger.Info:
+		sql, rows := fc()
+		l.logger.Debug(ctx, "database query",
+			"sql", sql, "rows", rows, "elapsed_ms", durationMS(elapsed))
+	}
+}
+
+func durationMS(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
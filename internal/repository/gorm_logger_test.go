@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/internal/requestid"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newLoggedMockDB is like newMockDB but routes GORM's logs into buf as JSON.
+func newLoggedMockDB(t *testing.T, buf *bytes.Buffer) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{
+		Logger: repository.NewGormLogger(logger.New(buf, logger.Options{Format: logger.FormatJSON}), 0),
+	})
+	require.NoError(t, err)
+
+	return gormDB, mock
+}
+
+func TestGormLogger_FailedQueryCarriesRequestID(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	db, mock := newLoggedMockDB(t, &buf)
+	repo := repository.NewProductRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "products"`).WillReturnError(datatest.ErrUnexpectedDB)
+	mock.ExpectRollback()
+
+	ctx := requestid.WithID(context.Background(), "req-42")
+	err := repo.Create(ctx, &entity.Product{Name: "Laptop", Qty: 1, Price: entity.NewMoney(1000, "USD")})
+	require.ErrorIs(t, err, datatest.ErrUnexpectedDB)
+
+	var record map[string]any
+	require.NoError(t, json.NewDecoder(&buf).Decode(&record))
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, "database query failed", record["msg"])
+	assert.Equal(t, "req-42", record["request_id"])
+	assert.Contains(t, record["sql"], `INSERT INTO "products"`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGormLogger_RecordNotFoundIsNotLogged(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	db, mock := newLoggedMockDB(t, &buf)
+	repo := repository.NewProductRepository(db)
+
+	mock.ExpectQuery(`SELECT \* FROM "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.GetByID(context.Background(), dto.GetProductQuery{ID: datatest.FakeProductID})
+	require.ErrorIs(t, err, entity.ErrProductNotFound)
+
+	assert.Empty(t, buf.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
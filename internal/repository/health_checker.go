@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// postgresHealthChecker pings the PostgreSQL connection pool behind GORM.
+type postgresHealthChecker struct {
+	db *gorm.DB
+}
+
+// NewPostgresHealthChecker creates a HealthChecker for the given GORM connection.
+func NewPostgresHealthChecker(db *gorm.DB) port.HealthChecker {
+	return &postgresHealthChecker{
+		db: db,
+	}
+}
+
+// Name returns the dependency name shown in readiness reports.
+func (c *postgresHealthChecker) Name() string {
+	return "postgres"
+}
+
+// Check pings the database, respecting the deadline carried by ctx.
+func (c *postgresHealthChecker) Check(ctx context.Context) error {
+	sqlDB, err := c.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+
+	return sqlDB.PingContext(ctx)
+}
+
+// redisHealthChecker pings the Redis server behind a client.
+type redisHealthChecker struct {
+	client redis.UniversalClient
+}
+
+// NewRedisHealthChecker creates a HealthChecker for the given Redis client.
+func NewRedisHealthChecker(client redis.UniversalClient) port.HealthChecker {
+	return &redisHealthChecker{
+		client: client,
+	}
+}
+
+// Name returns the dependency name shown in readiness reports.
+func (c *redisHealthChecker) Name() string {
+	return "redis"
+}
+
+// Check sends PING, respecting the deadline carried by ctx.
+func (c *redisHealthChecker) Check(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
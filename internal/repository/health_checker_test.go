@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestPostgresHealthChecker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		pingErr     error
+		expectedErr error
+	}{
+		{name: "database reachable"},
+		{name: "database unreachable", pingErr: datatest.ErrUnexpectedDB, expectedErr: datatest.ErrUnexpectedDB},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+			require.NoError(t, err)
+			mock.ExpectPing() // issued by gorm.Open
+			gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{
+				Logger: logger.Default.LogMode(logger.Silent),
+			})
+			require.NoError(t, err)
+
+			mock.ExpectPing().WillReturnError(tt.pingErr)
+			checker := repository.NewPostgresHealthChecker(gormDB)
+
+			// Act
+			err = checker.Check(t.Context())
+
+			// Assert
+			assert.Equal(t, "postgres", checker.Name())
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRedisHealthChecker(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	checker := repository.NewRedisHealthChecker(client)
+
+	// Act & Assert
+	assert.Equal(t, "redis", checker.Name())
+	require.NoError(t, checker.Check(t.Context()))
+
+	srv.Close()
+	assert.Error(t, checker.Check(t.Context()), "an unreachable server fails the check")
+}
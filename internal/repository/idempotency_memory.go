@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
+)
+
+// memoryIdempotencyStore keeps idempotency records in process memory.
+// It suits tests and single-instance deployments: records are lost on restart
+// and are not shared between instances.
+type memoryIdempotencyStore struct {
+	mu         sync.Mutex
+	records    map[string]memoryIdempotencyEntry
+	lastPurged time.Time
+}
+
+// memoryIdempotencyEntry is a record with the token of the request that acquired it.
+type memoryIdempotencyEntry struct {
+	record dto.IdempotencyRecord
+	token  string
+}
+
+// memoryPurgeInterval bounds how often Acquire sweeps expired records.
+const memoryPurgeInterval = time.Minute
+
+// NewMemoryIdempotencyStore creates an in-process IdempotencyStore.
+func NewMemoryIdempotencyStore() port.IdempotencyStore {
+	return &memoryIdempotencyStore{
+		records: make(map[string]memoryIdempotencyEntry),
+	}
+}
+
+// Acquire claims key with a fresh token, or returns the live record that holds it.
+func (s *memoryIdempotencyStore) Acquire(
+	_ context.Context, key, fingerprint string, lockTTL time.Duration,
+) (*dto.IdempotencyRecord, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.purgeExpired(now)
+
+	if entry, ok := s.records[key]; ok && now.Before(entry.record.ExpiresAt) {
+		return &entry.record, "", nil
+	}
+
+	token := uuid.NewString()
+	s.records[key] = memoryIdempotencyEntry{
+		record: dto.IdempotencyRecord{
+			Key:         key,
+			Fingerprint: fingerprint,
+			ExpiresAt:   now.Add(lockTTL),
+		},
+		token: token,
+	}
+
+	return nil, token, nil
+}
+
+// Complete stores the response and extends the record's life to ttl, provided token
+// still holds the key and its lock has not expired.
+func (s *memoryIdempotencyStore) Complete(
+	_ context.Context, key, token string, response dto.IdempotentResponse, ttl time.Duration,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := s.heldEntry(key, token)
+	if !ok || !now.Before(entry.record.ExpiresAt) {
+		return errIdempotencyLockLost
+	}
+
+	entry.record.Response = &response
+	entry.record.ExpiresAt = now.Add(ttl)
+	s.records[key] = entry
+
+	return nil
+}
+
+// Release forgets a request that did not complete, provided token still holds it.
+func (s *memoryIdempotencyStore) Release(_ context.Context, key, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.heldEntry(key, token); !ok {
+		return errIdempotencyLockLost
+	}
+	delete(s.records, key)
+
+	return nil
+}
+
+// heldEntry returns the in-progress entry of key if token acquired it; the caller must hold s.mu.
+func (s *memoryIdempotencyStore) heldEntry(key, token string) (memoryIdempotencyEntry, bool) {
+	entry, ok := s.records[key]
+	if !ok || entry.token != token || !entry.record.InProgress() {
+		return memoryIdempotencyEntry{}, false
+	}
+
+	return entry, true
+}
+
+// purgeExpired drops records past their expiry so the map does not grow without bound.
+// The sweep runs at most once per memoryPurgeInterval; the caller must hold s.mu.
+func (s *memoryIdempotencyStore) purgeExpired(now time.Time) {
+	if now.Sub(s.lastPurged) < memoryPurgeInterval {
+		return
+	}
+	s.lastPurged = now
+
+	for key, entry := range s.records {
+		if !now.Before(entry.record.ExpiresAt) {
+			delete(s.records, key)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryIdempotencyStore(t *testing.T) {
+	t.Parallel()
+
+	store := repository.NewMemoryIdempotencyStore()
+	ctx := t.Context()
+
+	// The first caller gets the key.
+	record, token, err := store.Acquire(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Nil(t, record)
+
+	// A duplicate sees the request in progress.
+	record, dupToken, err := store.Acquire(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, dupToken)
+	assert.True(t, record.InProgress())
+
+	// Only the holder's token completes the request.
+	response := dto.IdempotentResponse{Status: 201, ContentType: "application/json", Body: []byte(`{}`)}
+	assert.ErrorIs(t, store.Complete(ctx, "key", "other", response, time.Minute), apperror.Conflict)
+	require.NoError(t, store.Complete(ctx, "key", token, response, time.Minute))
+
+	// Once completed, the response is returned to retries.
+	record, dupToken, err = store.Acquire(ctx, "key", "other", time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, dupToken)
+	assert.Equal(t, "fp", record.Fingerprint)
+	assert.Equal(t, &response, record.Response)
+
+	// A completed record is not released, and cannot be completed twice.
+	assert.ErrorIs(t, store.Release(ctx, "key", token), apperror.Conflict)
+	_, dupToken, err = store.Acquire(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, dupToken)
+	assert.ErrorIs(t, store.Complete(ctx, "key", token, response, time.Minute), apperror.Conflict)
+}
+
+func TestMemoryIdempotencyStore_ReleaseAndExpiry(t *testing.T) {
+	t.Parallel()
+
+	store := repository.NewMemoryIdempotencyStore()
+	ctx := t.Context()
+
+	_, token, err := store.Acquire(ctx, "released", "fp", time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NoError(t, store.Release(ctx, "released", token))
+
+	_, token, err = store.Acquire(ctx, "released", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token, "a released key can be acquired again")
+
+	// An abandoned lock expires, and its holder can no longer complete.
+	_, token, err = store.Acquire(ctx, "abandoned", "fp", time.Millisecond)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	time.Sleep(5 * time.Millisecond)
+
+	assert.ErrorIs(t, store.Complete(ctx, "abandoned", token, dto.IdempotentResponse{Status: 201}, time.Minute), apperror.Conflict)
+	_, token, err = store.Acquire(ctx, "abandoned", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+}
+
+func TestMemoryIdempotencyStore_TakeOver(t *testing.T) {
+	t.Parallel()
+
+	store := repository.NewMemoryIdempotencyStore()
+	ctx := t.Context()
+	response := dto.IdempotentResponse{Status: 201, ContentType: "application/json", Body: []byte(`{}`)}
+
+	_, stale, err := store.Acquire(ctx, "key", "fp", time.Millisecond)
+	require.NoError(t, err)
+	require.NotEmpty(t, stale)
+	time.Sleep(5 * time.Millisecond)
+
+	_, current, err := store.Acquire(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, current, "an expired lock is taken over")
+
+	// The slow original request can neither overwrite nor drop the new holder's lock.
+	assert.ErrorIs(t, store.Complete(ctx, "key", stale, response, time.Minute), apperror.Conflict)
+	assert.ErrorIs(t, store.Release(ctx, "key", stale), apperror.Conflict)
+
+	record, token, err := store.Acquire(ctx, "key", "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, token)
+	assert.True(t, record.InProgress(), "the new holder keeps the key")
+
+	require.NoError(t, store.Complete(ctx, "key", current, response, time.Minute))
+}
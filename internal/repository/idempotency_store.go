@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// errIdempotencyLockLost is returned by Complete and Release when the key is no longer
+// held with the caller's token, typically because the request outlived its lock and a
+// retry took the key over.
+var errIdempotencyLockLost = apperror.New(apperror.Conflict, "idempotency key is no longer held")
+
+// idempotencyKey is a row of the idempotency_keys table.
+type idempotencyKey struct {
+	Key         string `gorm:"primaryKey"`
+	Fingerprint string
+	LockToken   *string `gorm:"type:uuid"`
+	Status      *int    // NULL while the request is in progress
+	ContentType *string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// TableName implements gorm's tabler interface.
+func (idempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+func (k *idempotencyKey) toRecord() *dto.IdempotencyRecord {
+	record := &dto.IdempotencyRecord{
+		Key:         k.Key,
+		Fingerprint: k.Fingerprint,
+		ExpiresAt:   k.ExpiresAt,
+	}
+	if k.Status != nil {
+		record.Response = &dto.IdempotentResponse{Status: *k.Status, Body: k.Body}
+		if k.ContentType != nil {
+			record.Response.ContentType = *k.ContentType
+		}
+	}
+
+	return record
+}
+
+// postgresIdempotencyStore keeps idempotency records in PostgreSQL, so the lock and the
+// stored responses are shared by every instance of the service.
+type postgresIdempotencyStore struct {
+	db *gorm.DB
+}
+
+// NewPostgresIdempotencyStore creates an IdempotencyStore backed by the idempotency_keys table.
+func NewPostgresIdempotencyStore(db *gorm.DB) port.IdempotencyStore {
+	return &postgresIdempotencyStore{
+		db: db,
+	}
+}
+
+// acquireQuery inserts the lock row, or takes over a row whose key has expired.
+// The primary key makes it atomic: of two concurrent duplicates only one affects a row.
+const acquireQuery = `INSERT INTO idempotency_keys (key, fingerprint, lock_token, expires_at)
+VALUES (?, ?, ?, now() + make_interval(secs => ?))
+ON CONFLICT (key) DO UPDATE SET
+	fingerprint = EXCLUDED.fingerprint,
+	lock_token = EXCLUDED.lock_token,
+	status = NULL,
+	content_type = NULL,
+	body = NULL,
+	created_at = now(),
+	expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= now()`
+
+// Acquire claims key with a fresh token, or returns the live record that holds it.
+func (s *postgresIdempotencyStore) Acquire(
+	ctx context.Context, key, fingerprint string, lockTTL time.Duration,
+) (*dto.IdempotencyRecord, string, error) {
+	token := uuid.NewString()
+
+	// The holder may expire between the INSERT and the SELECT; one more attempt then wins the key.
+	for range 2 {
+		result := s.db.WithContext(ctx).Exec(acquireQuery, key, fingerprint, token, lockTTL.Seconds())
+		if result.Error != nil {
+			return nil, "", translateError(result.Error)
+		}
+		if result.RowsAffected == 1 {
+			return nil, token, nil
+		}
+
+		var row idempotencyKey
+		err := s.db.WithContext(ctx).Where("key = ? AND expires_at > now()", key).Take(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, "", translateError(err)
+		}
+
+		return row.toRecord(), "", nil
+	}
+
+	return nil, "", apperror.New(apperror.Unavailable, "idempotency key is contended, please retry")
+}
+
+// Complete stores the response and extends the record's life to ttl, provided token
+// still holds the key and its lock has not expired.
+func (s *postgresIdempotencyStore) Complete(
+	ctx context.Context, key, token string, response dto.IdempotentResponse, ttl time.Duration,
+) error {
+	result := s.db.WithContext(ctx).Exec(
+		`UPDATE idempotency_keys
+		SET status = ?, content_type = ?, body = ?, expires_at = now() + make_interval(secs => ?)
+		WHERE key = ? AND lock_token = ? AND status IS NULL AND expires_at > now()`,
+		response.Status, response.ContentType, response.Body, ttl.Seconds(), key, token,
+	)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errIdempotencyLockLost
+	}
+
+	return nil
+}
+
+// Release deletes the lock row of a request that did not complete, provided token still holds it.
+func (s *postgresIdempotencyStore) Release(ctx context.Context, key, token string) error {
+	result := s.db.WithContext(ctx).Exec(
+		`DELETE FROM idempotency_keys WHERE key = ? AND lock_token = ? AND status IS NULL`,
+		key, token,
+	)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errIdempotencyLockLost
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/dbtest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresIdempotencyStore_Acquire(t *testing.T) {
+	t.Parallel()
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name             string
+		setupMock        func(mock sqlmock.Sqlmock)
+		expectedAcquired bool
+		expectedRecord   *dto.IdempotencyRecord
+		expectedErr      error
+	}{
+		{
+			name: "acquired",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO idempotency_keys .* ON CONFLICT \(key\) DO UPDATE .* WHERE idempotency_keys.expires_at <= now\(\)`).
+					WithArgs("key", "fp", sqlmock.AnyArg(), float64(60)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedAcquired: true,
+		},
+		{
+			name: "held by a completed request",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO idempotency_keys`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`SELECT \* FROM "idempotency_keys" WHERE key = \$1 AND expires_at > now\(\) LIMIT \$2`).
+					WithArgs("key", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"key", "fingerprint", "status", "content_type", "body", "expires_at"}).
+						AddRow("key", "fp", 201, "application/json", []byte(`{}`), expiresAt))
+			},
+			expectedRecord: &dto.IdempotencyRecord{
+				Key:         "key",
+				Fingerprint: "fp",
+				Response:    &dto.IdempotentResponse{Status: 201, ContentType: "application/json", Body: []byte(`{}`)},
+				ExpiresAt:   expiresAt,
+			},
+		},
+		{
+			name: "held by a request in progress",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO idempotency_keys`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`SELECT \* FROM "idempotency_keys"`).
+					WillReturnRows(sqlmock.NewRows([]string{"key", "fingerprint", "status", "content_type", "body", "expires_at"}).
+						AddRow("key", "fp", nil, nil, nil, expiresAt))
+			},
+			expectedRecord: &dto.IdempotencyRecord{Key: "key", Fingerprint: "fp", ExpiresAt: expiresAt},
+		},
+		{
+			name: "holder expired in between",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO idempotency_keys`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`SELECT \* FROM "idempotency_keys"`).WillReturnRows(sqlmock.NewRows([]string{"key"}))
+				mock.ExpectExec(`INSERT INTO idempotency_keys`).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedAcquired: true,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO idempotency_keys`).WillReturnError(datatest.ErrUnexpectedDB)
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock := newMockDB(t)
+			store := repository.NewPostgresIdempotencyStore(db)
+			tt.setupMock(mock)
+
+			// Act
+			record, token, err := store.Acquire(t.Context(), "key", "fp", time.Minute)
+
+			// Assert
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedAcquired, token != "")
+				assert.Equal(t, tt.expectedRecord, record)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresIdempotencyStore_Complete(t *testing.T) {
+	t.Parallel()
+
+	response := dto.IdempotentResponse{Status: 201, ContentType: "application/json", Body: []byte(`{}`)}
+
+	tests := []struct {
+		name        string
+		affected    int64
+		expectedErr error
+	}{
+		{name: "stored", affected: 1},
+		{name: "lock lost", affected: 0, expectedErr: apperror.Conflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock := newMockDB(t)
+			store := repository.NewPostgresIdempotencyStore(db)
+			mock.ExpectExec(`UPDATE idempotency_keys\s+SET status = \$1, content_type = \$2, body = \$3, .*`+
+				`WHERE key = \$5 AND lock_token = \$6 AND status IS NULL AND expires_at > now\(\)`).
+				WithArgs(201, "application/json", []byte(`{}`), float64(3600), "key", "token").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			// Act
+			err := store.Complete(t.Context(), "key", "token", response, time.Hour)
+
+			// Assert
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresIdempotencyStore_Release(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		affected    int64
+		expectedErr error
+	}{
+		{name: "released", affected: 1},
+		{name: "lock lost", affected: 0, expectedErr: apperror.Conflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock := newMockDB(t)
+			store := repository.NewPostgresIdempotencyStore(db)
+			mock.ExpectExec(`DELETE FROM idempotency_keys WHERE key = \$1 AND lock_token = \$2 AND status IS NULL`).
+				WithArgs("key", "token").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			// Act
+			err := store.Release(t.Context(), "key", "token")
+
+			// Assert
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// TestPostgresIdempotencyStore_TakeOver checks that a request whose lock expired cannot
+// complete or release the key once a retry has taken it over. It needs a PostgreSQL
+// database, see dbtest.EnvDatabaseURL.
+func TestPostgresIdempotencyStore_TakeOver(t *testing.T) {
+	db := dbtest.Open(t)
+	dbtest.Migrate(t, db)
+
+	store := repository.NewPostgresIdempotencyStore(db)
+	ctx := t.Context()
+	key := "take-over " + uuid.NewString()
+	response := dto.IdempotentResponse{Status: 201, ContentType: "application/json", Body: []byte(`{}`)}
+
+	_, stale, err := store.Acquire(ctx, key, "fp", time.Millisecond)
+	require.NoError(t, err)
+	require.NotEmpty(t, stale)
+	time.Sleep(10 * time.Millisecond)
+
+	_, current, err := store.Acquire(ctx, key, "fp", time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, current, "an expired lock is taken over")
+
+	assert.ErrorIs(t, store.Complete(ctx, key, stale, response, time.Hour), apperror.Conflict)
+	assert.ErrorIs(t, store.Release(ctx, key, stale), apperror.Conflict)
+
+	record, token, err := store.Acquire(ctx, key, "fp", time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, token)
+	assert.True(t, record.InProgress(), "the new holder keeps the key")
+
+	require.NoError(t, store.Complete(ctx, key, current, response, time.Hour))
+}
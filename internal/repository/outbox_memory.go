@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
+)
+
+// memoryOutboxEntry is an unsent event and its delivery state.
+type memoryOutboxEntry struct {
+	message       dto.OutboxMessage
+	nextAttemptAt time.Time
+	lastError     string
+}
+
+// memoryOutboxRepo keeps unsent events in process memory; sent events are dropped.
+// Claims are not locked, so it supports a single relay only.
+type memoryOutboxRepo struct {
+	mu      sync.Mutex
+	pending map[uuid.UUID]*memoryOutboxEntry
+}
+
+// NewMemoryOutboxRepository creates an in-process OutboxRepository.
+func NewMemoryOutboxRepository() port.OutboxRepository {
+	return &memoryOutboxRepo{
+		pending: make(map[uuid.UUID]*memoryOutboxEntry),
+	}
+}
+
+// Add queues events; an event already queued is left as is.
+func (r *memoryOutboxRepo) Add(_ context.Context, events ...entity.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, e := range events {
+		if _, exists := r.pending[e.ID]; exists {
+			continue
+		}
+		r.pending[e.ID] = &memoryOutboxEntry{
+			message:       dto.OutboxMessage{Event: e},
+			nextAttemptAt: time.Now(),
+		}
+	}
+
+	return nil
+}
+
+// ClaimPending returns up to limit due events, oldest first.
+func (r *memoryOutboxRepo) ClaimPending(_ context.Context, limit int) ([]dto.OutboxMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	messages := make([]dto.OutboxMessage, 0, len(r.pending))
+	for _, entry := range r.pending {
+		if !entry.nextAttemptAt.After(now) {
+			messages = append(messages, entry.message)
+		}
+	}
+	slices.SortFunc(messages, func(a, b dto.OutboxMessage) int {
+		if c := a.Event.OccurredAt.Compare(b.Event.OccurredAt); c != 0 {
+			return c
+		}
+		return slices.Compare(a.Event.ID[:], b.Event.ID[:])
+	})
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+
+	return messages, nil
+}
+
+// MarkSent forgets the event.
+func (r *memoryOutboxRepo) MarkSent(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.pending, id)
+
+	return nil
+}
+
+// MarkFailed counts the failed attempt and schedules the next one.
+func (r *memoryOutboxRepo) MarkFailed(_ context.Context, id uuid.UUID, retryIn time.Duration, cause string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.pending[id]; ok {
+		entry.message.Attempts++
+		entry.nextAttemptAt = time.Now().Add(retryIn)
+		entry.lastError = cause
+	}
+
+	return nil
+}
+
+// PurgeSent has nothing to delete: sent events are dropped by MarkSent.
+func (r *memoryOutboxRepo) PurgeSent(context.Context, time.Duration, int) (int, error) {
+	return 0, nil
+}
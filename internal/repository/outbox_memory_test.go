@@ -0,0 +1,43 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryOutboxRepo(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewMemoryOutboxRepository()
+	ctx := t.Context()
+
+	now := time.Now()
+	older := entity.Event{ID: uuid.New(), Type: entity.EventProductCreated, OccurredAt: now.Add(-time.Minute)}
+	newer := entity.Event{ID: uuid.New(), Type: entity.EventProductUpdated, OccurredAt: now}
+	require.NoError(t, repo.Add(ctx, newer, older))
+
+	// Due events come oldest first, up to the limit.
+	messages, err := repo.ClaimPending(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, older.ID, messages[0].Event.ID)
+
+	// A failed event waits for its retry; a sent one is gone.
+	require.NoError(t, repo.MarkFailed(ctx, older.ID, time.Hour, "subscriber down"))
+	require.NoError(t, repo.MarkSent(ctx, newer.ID))
+	messages, err = repo.ClaimPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+
+	require.NoError(t, repo.MarkFailed(ctx, older.ID, 0, "subscriber down"))
+	messages, err = repo.ClaimPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, 2, messages[0].Attempts)
+}
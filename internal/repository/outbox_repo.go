@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// outboxMessage is a row of the outbox table.
+type outboxMessage struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EventType   string
+	AggregateID uuid.UUID `gorm:"type:uuid"`
+	Payload     string    `gorm:"type:jsonb"`
+	OccurredAt  time.Time
+
+	// Delivery state is read-only here: the columns default on insert and only
+	// MarkSent and MarkFailed change them.
+	Attempts      int        `gorm:"->"`
+	NextAttemptAt time.Time  `gorm:"->"`
+	LastError     *string    `gorm:"->"`
+	SentAt        *time.Time `gorm:"->"`
+}
+
+// TableName implements gorm's tabler interface.
+func (outboxMessage) TableName() string {
+	return "outbox"
+}
+
+func (m *outboxMessage) toMessage() dto.OutboxMessage {
+	return dto.OutboxMessage{
+		Event: entity.Event{
+			ID:          m.ID,
+			Type:        entity.EventType(m.EventType),
+			AggregateID: m.AggregateID,
+			OccurredAt:  m.OccurredAt,
+			Payload:     json.RawMessage(m.Payload),
+		},
+		Attempts: m.Attempts,
+	}
+}
+
+// outboxRepo is the GORM-based implementation of port.OutboxRepository.
+type outboxRepo struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates an OutboxRepository backed by the outbox table.
+// Like the other repositories of this package, it joins the transaction of TxManager.
+func NewOutboxRepository(db *gorm.DB) port.OutboxRepository {
+	return &outboxRepo{
+		db: db,
+	}
+}
+
+// Add inserts events into the outbox.
+func (r *outboxRepo) Add(ctx context.Context, events ...entity.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([]outboxMessage, 0, len(events))
+	for _, e := range events {
+		rows = append(rows, outboxMessage{
+			ID:          e.ID,
+			EventType:   string(e.Type),
+			AggregateID: e.AggregateID,
+			Payload:     string(e.Payload),
+			OccurredAt:  e.OccurredAt,
+		})
+	}
+
+	return translateError(conn(ctx, r.db).Create(&rows).Error)
+}
+
+// ClaimPending selects due events with FOR UPDATE SKIP LOCKED.
+func (r *outboxRepo) ClaimPending(ctx context.Context, limit int) ([]dto.OutboxMessage, error) {
+	var rows []outboxMessage
+	err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+		Where("sent_at IS NULL AND next_attempt_at <= now()").
+		Order("occurred_at, id").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	messages := make([]dto.OutboxMessage, 0, len(rows))
+	for i := range rows {
+		messages = append(messages, rows[i].toMessage())
+	}
+
+	return messages, nil
+}
+
+// MarkSent stamps the event as published.
+func (r *outboxRepo) MarkSent(ctx context.Context, id uuid.UUID) error {
+	return translateError(conn(ctx, r.db).Exec(`UPDATE outbox SET sent_at = now() WHERE id = ?`, id).Error)
+}
+
+// MarkFailed counts the failed attempt and schedules the next one.
+func (r *outboxRepo) MarkFailed(ctx context.Context, id uuid.UUID, retryIn time.Duration, cause string) error {
+	return translateError(conn(ctx, r.db).Exec(
+		`UPDATE outbox
+		SET attempts = attempts + 1, next_attempt_at = now() + make_interval(secs => ?), last_error = ?
+		WHERE id = ?`,
+		retryIn.Seconds(), cause, id,
+	).Error)
+}
+
+// purgeSentQuery deletes a bounded batch, so a large backlog of sent events does not
+// turn into one long-running delete.
+const purgeSentQuery = `DELETE FROM outbox WHERE id IN (
+	SELECT id FROM outbox WHERE sent_at < now() - make_interval(secs => ?) LIMIT ?
+)`
+
+// PurgeSent deletes a batch of events sent more than retention ago.
+func (r *outboxRepo) PurgeSent(ctx context.Context, retention time.Duration, limit int) (int, error) {
+	result := conn(ctx, r.db).Exec(purgeSentQuery, retention.Seconds(), limit)
+	if result.Error != nil {
+		return 0, translateError(result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
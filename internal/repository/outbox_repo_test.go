@@ -0,0 +1,133 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepo_AddJoinsTransaction(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	txm := repository.NewTxManager(db)
+	products := repository.NewProductRepository(db)
+	outbox := repository.NewOutboxRepository(db)
+
+	event := entity.NewProductDeleted(datatest.FakeProductID)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "products" SET "deleted_at"`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO "outbox" \("id","event_type","aggregate_id","payload","occurred_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5\)`).
+		WithArgs(event.ID, "product.deleted", datatest.FakeProductID, string(event.Payload), event.OccurredAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Act
+	err := txm.WithinTx(t.Context(), func(ctx context.Context) error {
+		if err := products.Delete(ctx, datatest.FakeProductID); err != nil {
+			return err
+		}
+		return outbox.Add(ctx, event)
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepo_ClaimPending(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	txm := repository.NewTxManager(db)
+	outbox := repository.NewOutboxRepository(db)
+
+	occurredAt := time.Now().UTC()
+	eventID := datatest.FakeProductID
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "outbox" WHERE sent_at IS NULL AND next_attempt_at <= now\(\) ` +
+		`ORDER BY occurred_at, id LIMIT \$1 FOR UPDATE SKIP LOCKED`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "aggregate_id", "payload", "occurred_at", "attempts"}).
+			AddRow(eventID, "product.deleted", datatest.FakeProductID, `{"id":"x"}`, occurredAt, 2))
+	mock.ExpectCommit()
+
+	// Act
+	var got []dto.OutboxMessage
+	err := txm.WithinTx(t.Context(), func(ctx context.Context) error {
+		var err error
+		got, err = outbox.ClaimPending(ctx, 10)
+		return err
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []dto.OutboxMessage{{
+		Event: entity.Event{
+			ID:          eventID,
+			Type:        entity.EventProductDeleted,
+			AggregateID: datatest.FakeProductID,
+			OccurredAt:  occurredAt,
+			Payload:     []byte(`{"id":"x"}`),
+		},
+		Attempts: 2,
+	}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepo_MarkSentAndFailed(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	outbox := repository.NewOutboxRepository(db)
+
+	mock.ExpectExec(`UPDATE outbox SET sent_at = now\(\) WHERE id = \$1`).
+		WithArgs(datatest.FakeProductID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE outbox\s+SET attempts = attempts \+ 1, next_attempt_at = now\(\) \+ make_interval\(secs => \$1\), last_error = \$2\s+WHERE id = \$3`).
+		WithArgs(float64(30), "broker down", datatest.FakeProductID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE outbox SET sent_at`).
+		WillReturnError(datatest.ErrUnexpectedDB)
+
+	// Act & Assert
+	require.NoError(t, outbox.MarkSent(t.Context(), datatest.FakeProductID))
+	require.NoError(t, outbox.MarkFailed(t.Context(), datatest.FakeProductID, 30*time.Second, "broker down"))
+	require.ErrorIs(t, outbox.MarkSent(t.Context(), datatest.FakeProductID), datatest.ErrUnexpectedDB)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxRepo_PurgeSent(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	outbox := repository.NewOutboxRepository(db)
+
+	mock.ExpectExec(`DELETE FROM outbox WHERE id IN \(\s+`+
+		`SELECT id FROM outbox WHERE sent_at < now\(\) - make_interval\(secs => \$1\) LIMIT \$2\s+\)`).
+		WithArgs(float64(86400), 100).
+		WillReturnResult(sqlmock.NewResult(0, 42))
+
+	// Act
+	n, err := outbox.PurgeSent(t.Context(), 24*time.Hour, 100)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 42, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultProductCacheTTL applies when ProductCacheOptions.TTL is zero.
+const DefaultProductCacheTTL = 5 * time.Minute
+
+// ProductCacheOptions configures NewCachedProductRepository.
+type ProductCacheOptions struct {
+	// TTL bounds how long a cached product is served. It is also the longest a
+	// reader can see a stale product if an invalidation is lost.
+	TTL time.Duration
+
+	// Logger reports cache failures, which never fail a request; nil discards them.
+	Logger port.Logger
+}
+
+// cachedProductRepo decorates a ProductRepository with a cache-aside GetByID.
+type cachedProductRepo struct {
+	next  port.ProductRepository
+	cache port.Cache
+	group singleflight.Group
+	opts  ProductCacheOptions
+}
+
+// NewCachedProductRepository wraps next so that GetByID of live products is served
+// from cache, loading each missing product once however many requests ask for it
+// concurrently. Every successful write evicts the product once its transaction commits.
+//
+// Reads made inside a transaction or for update, and reads of soft-deleted products,
+// bypass the cache: the former may see uncommitted writes or must see the latest
+// committed one, and the latter are rare.
+func NewCachedProductRepository(next port.ProductRepository, cache port.Cache, opts ProductCacheOptions) port.ProductRepository {
+	if opts.TTL <= 0 {
+		opts.TTL = DefaultProductCacheTTL
+	}
+	if opts.Logger == nil {
+		opts.Logger = logger.NewNop()
+	}
+
+	return &cachedProductRepo{
+		next:  next,
+		cache: cache,
+		opts:  opts,
+	}
+}
+
+// productCacheKey is the cache key of the product with the given ID. The version
+// suffix is bumped whenever the cached representation changes shape.
+func productCacheKey(id uuid.UUID) string {
+	return "product:v1:" + id.String()
+}
+
+// Create inserts the product. A new product cannot be cached yet, so nothing is evicted.
+func (r *cachedProductRepo) Create(ctx context.Context, product *entity.Product) error {
+	return r.next.Create(ctx, product)
+}
+
+// UpsertBySKU creates or replaces the product, then evicts it.
+func (r *cachedProductRepo) UpsertBySKU(ctx context.Context, product *entity.Product, expectedVersion *int64) (bool, error) {
+	created, err := r.next.UpsertBySKU(ctx, product, expectedVersion)
+	if err != nil {
+		return false, err
+	}
+	r.invalidate(ctx, product.ID)
+
+	return created, nil
+}
+
+// GetByID returns the cached product, or loads it from next and caches it.
+func (r *cachedProductRepo) GetByID(ctx context.Context, query dto.GetProductQuery) (*entity.Product, error) {
+	if query.IncludeDeleted || query.ForUpdate || inTx(ctx) {
+		return r.next.GetByID(ctx, query)
+	}
+
+	key := productCacheKey(query.ID)
+	if product, ok := r.lookup(ctx, key); ok {
+		return product, nil
+	}
+
+	// The load is shared by every concurrent caller, so it must not be cancelled along
+	// with the first one; each caller still stops waiting when its own context ends.
+	ch := r.group.DoChan(key, func() (any, error) {
+		loadCtx := context.WithoutCancel(ctx)
+		product, err := r.next.GetByID(loadCtx, query)
+		if err != nil {
+			return nil, err
+		}
+		r.store(loadCtx, key, product)
+
+		return *product, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		// Every caller gets its own copy, since usecases modify the product they read.
+		product := res.Val.(entity.Product)
+		return &product, nil
+	}
+}
+
+// List is not cached: pages depend on too many parameters to be invalidated reliably.
+func (r *cachedProductRepo) List(ctx context.Context, query dto.ListProductsQuery) ([]entity.Product, error) {
+	return r.next.List(ctx, query)
+}
+
+// Update persists the product, then evicts it.
+func (r *cachedProductRepo) Update(ctx context.Context, product *entity.Product) error {
+	if err := r.next.Update(ctx, product); err != nil {
+		return err
+	}
+	r.invalidate(ctx, product.ID)
+
+	return nil
+}
+
+// Delete soft-deletes the product, then evicts it.
+func (r *cachedProductRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := r.next.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+
+	return nil
+}
+
+// Restore brings the product back, then evicts it.
+func (r *cachedProductRepo) Restore(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	product, err := r.next.Restore(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx, id)
+
+	return product, nil
+}
+
+// lookup returns the cached product under key. Failures count as misses.
+func (r *cachedProductRepo) lookup(ctx context.Context, key string) (*entity.Product, bool) {
+	raw, found, err := r.cache.Get(ctx, key)
+	if err != nil {
+		r.opts.Logger.Warn(ctx, "product cache read failed", "key", key, "error", err)
+		return nil, false
+	}
+	if !found {
+		return nil, false
+	}
+
+	var product entity.Product
+	if err := json.Unmarshal(raw, &product); err != nil {
+		r.opts.Logger.Warn(ctx, "dropping unreadable cached product", "key", key, "error", err)
+		return nil, false
+	}
+
+	return &product, true
+}
+
+// store caches product under key; a failure only costs a later miss.
+func (r *cachedProductRepo) store(ctx context.Context, key string, product *entity.Product) {
+	// A product is a plain struct of JSON-friendly fields, so marshalling cannot fail.
+	raw, _ := json.Marshal(product)
+	if err := r.cache.Set(ctx, key, raw, r.opts.TTL); err != nil {
+		r.opts.Logger.Warn(ctx, "product cache write failed", "key", key, "error", err)
+	}
+}
+
+// invalidate evicts the product once the surrounding transaction, if any, commits:
+// evicting earlier would let a concurrent reader cache the old row again.
+func (r *cachedProductRepo) invalidate(ctx context.Context, id uuid.UUID) {
+	ctx = context.WithoutCancel(ctx)
+	afterCommit(ctx, func() {
+		key := productCacheKey(id)
+		if err := r.cache.Delete(ctx, key); err != nil {
+			r.opts.Logger.Error(ctx, "product cache invalidation failed, stale reads possible until expiry",
+				"key", key, "error", err)
+		}
+	})
+}
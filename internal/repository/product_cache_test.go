@@ -0,0 +1,280 @@
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mockbuilder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fakeProductQuery = dto.GetProductQuery{ID: datatest.FakeProductID}
+
+func TestCachedProductRepo_GetByIDServedFromCache(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cache, srv := newRedisCache(t)
+	next := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccessOnce().Build()
+	repo := repository.NewCachedProductRepository(next, cache, repository.ProductCacheOptions{TTL: time.Minute})
+
+	// Act
+	first, err := repo.GetByID(t.Context(), fakeProductQuery)
+	require.NoError(t, err)
+	second, err := repo.GetByID(t.Context(), fakeProductQuery)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, first, second)
+	assert.Equal(t, datatest.FakePrice, second.Price)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, time.Minute, srv.TTL("test:product:v1:"+datatest.FakeProductID.String()))
+}
+
+func TestCachedProductRepo_Bypass(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cache, err := repository.NewLRUCache(10)
+	require.NoError(t, err)
+	next := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().Build()
+	repo := repository.NewCachedProductRepository(next, cache, repository.ProductCacheOptions{})
+
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	// Act: neither deleted products, reads for update nor reads inside a transaction are cached.
+	_, err = repo.GetByID(t.Context(), dto.GetProductQuery{ID: datatest.FakeProductID, IncludeDeleted: true})
+	require.NoError(t, err)
+	_, err = repo.GetByID(t.Context(), dto.GetProductQuery{ID: datatest.FakeProductID, ForUpdate: true})
+	require.NoError(t, err)
+	err = repository.NewTxManager(db).WithinTx(t.Context(), func(ctx context.Context) error {
+		_, err := repo.GetByID(ctx, fakeProductQuery)
+		return err
+	})
+	require.NoError(t, err)
+
+	// Assert
+	_, found, err := cache.Get(t.Context(), "product:v1:"+datatest.FakeProductID.String())
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCachedProductRepo_WritesInvalidate(t *testing.T) {
+	t.Parallel()
+
+	stored := func() *entity.Product {
+		return &entity.Product{ID: datatest.FakeProductID, Name: "Stored Product", Price: datatest.FakePrice, Version: 1}
+	}
+
+	tests := []struct {
+		name            string
+		setupRepo       func(b *mockbuilder.ProductRepoBuilder)
+		write           func(ctx context.Context, repo port.ProductRepository) error
+		expectedEvicted bool
+	}{
+		{
+			name:      "update",
+			setupRepo: func(b *mockbuilder.ProductRepoBuilder) { b.UpdateSuccess() },
+			write: func(ctx context.Context, repo port.ProductRepository) error {
+				return repo.Update(ctx, stored())
+			},
+			expectedEvicted: true,
+		},
+		{
+			name:      "upsert",
+			setupRepo: func(b *mockbuilder.ProductRepoBuilder) { b.UpsertBySKUSuccess(false) },
+			write: func(ctx context.Context, repo port.ProductRepository) error {
+				_, err := repo.UpsertBySKU(ctx, stored(), new(int64))
+				return err
+			},
+			expectedEvicted: true,
+		},
+		{
+			name:      "delete",
+			setupRepo: func(b *mockbuilder.ProductRepoBuilder) { b.DeleteSuccess() },
+			write: func(ctx context.Context, repo port.ProductRepository) error {
+				return repo.Delete(ctx, datatest.FakeProductID)
+			},
+			expectedEvicted: true,
+		},
+		{
+			name:      "restore",
+			setupRepo: func(b *mockbuilder.ProductRepoBuilder) { b.RestoreSuccess() },
+			write: func(ctx context.Context, repo port.ProductRepository) error {
+				_, err := repo.Restore(ctx, datatest.FakeProductID)
+				return err
+			},
+			expectedEvicted: true,
+		},
+		{
+			name:      "failed write keeps the cached product",
+			setupRepo: func(b *mockbuilder.ProductRepoBuilder) { b.UpdateVersionMismatch() },
+			write: func(ctx context.Context, repo port.ProductRepository) error {
+				return repo.Update(ctx, stored())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			cache, srv := newRedisCache(t)
+			b := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccessOnce()
+			tt.setupRepo(b)
+			repo := repository.NewCachedProductRepository(b.Build(), cache, repository.ProductCacheOptions{})
+
+			_, err := repo.GetByID(t.Context(), fakeProductQuery)
+			require.NoError(t, err)
+
+			// Act
+			_ = tt.write(t.Context(), repo)
+
+			// Assert
+			assert.Equal(t, !tt.expectedEvicted, srv.Exists("test:product:v1:"+datatest.FakeProductID.String()))
+		})
+	}
+}
+
+func TestCachedProductRepo_InvalidatesAfterCommit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		setupMock       func(mock sqlmock.Sqlmock)
+		unitOfWork      func(ctx context.Context, repo port.ProductRepository) error
+		expectedEvicted bool
+	}{
+		{
+			name: "committed",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectCommit()
+			},
+			unitOfWork: func(ctx context.Context, repo port.ProductRepository) error {
+				return repo.Delete(ctx, datatest.FakeProductID)
+			},
+			expectedEvicted: true,
+		},
+		{
+			name: "rolled back",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			unitOfWork: func(ctx context.Context, repo port.ProductRepository) error {
+				if err := repo.Delete(ctx, datatest.FakeProductID); err != nil {
+					return err
+				}
+				return datatest.ErrUnexpectedDB
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			cache, srv := newRedisCache(t)
+			next := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccessOnce().DeleteSuccess().Build()
+			repo := repository.NewCachedProductRepository(next, cache, repository.ProductCacheOptions{})
+			key := "test:product:v1:" + datatest.FakeProductID.String()
+
+			db, mock := newMockDB(t)
+			tt.setupMock(mock)
+
+			_, err := repo.GetByID(t.Context(), fakeProductQuery)
+			require.NoError(t, err)
+
+			// Act
+			_ = repository.NewTxManager(db).WithinTx(t.Context(), func(ctx context.Context) error {
+				err := tt.unitOfWork(ctx, repo)
+				assert.True(t, srv.Exists(key), "evicted before the transaction ended")
+				return err
+			})
+
+			// Assert
+			assert.Equal(t, !tt.expectedEvicted, srv.Exists(key))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCachedProductRepo_ConcurrentMissesLoadOnce(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cache, err := repository.NewLRUCache(10)
+	require.NoError(t, err)
+	release := make(chan time.Time)
+	next := mockbuilder.NewProductRepoBuilder(t).GetByIDBlocksUntil(release).Build()
+	repo := repository.NewCachedProductRepository(next, cache, repository.ProductCacheOptions{})
+
+	// Act
+	const readers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, readers)
+	for range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.GetByID(t.Context(), fakeProductQuery)
+			errs <- err
+		}()
+	}
+	time.Sleep(20 * time.Millisecond) // let every reader join the load
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	// Assert: the mock accepts a single call, so every reader shared it.
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+func TestCachedProductRepo_CacheFailureFallsBack(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	cache, srv := newRedisCache(t)
+	srv.Close()
+	next := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().DeleteSuccess().Build()
+	repo := repository.NewCachedProductRepository(next, cache, repository.ProductCacheOptions{})
+
+	// Act
+	product, err := repo.GetByID(t.Context(), fakeProductQuery)
+	deleteErr := repo.Delete(t.Context(), datatest.FakeProductID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, datatest.FakeProductID, product.ID)
+	require.NoError(t, deleteErr)
+}
+
+func TestCachedProductRepo_NotFoundIsNotCached(t *testing.T) {
+	t.Parallel()
+
+	cache, srv := newRedisCache(t)
+	next := mockbuilder.NewProductRepoBuilder(t).GetByIDNotFound().Build()
+	repo := repository.NewCachedProductRepository(next, cache, repository.ProductCacheOptions{})
+
+	_, err := repo.GetByID(t.Context(), fakeProductQuery)
+
+	require.ErrorIs(t, err, entity.ErrProductNotFound)
+	assert.Empty(t, srv.Keys())
+}
@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
+)
+
+// currencyPattern mirrors the products_currency_check constraint.
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// maxProductNameLength mirrors the VARCHAR(255) name column.
+const maxProductNameLength = 255
+
+// memoryProductRepo keeps products in process memory. It behaves like productRepo,
+// including the checks the schema enforces, so it can stand in for Postgres in tests
+// and in demo mode; products are lost on restart.
+//
+// Names are sorted by byte value, while Postgres sorts them by the database collation,
+// so pages sorted by name may differ for mixed-case or non-ASCII names.
+type memoryProductRepo struct {
+	mu       sync.RWMutex
+	products map[uuid.UUID]entity.Product
+}
+
+// NewMemoryProductRepository creates an empty in-process ProductRepository.
+func NewMemoryProductRepository() port.ProductRepository {
+	return &memoryProductRepo{
+		products: make(map[uuid.UUID]entity.Product),
+	}
+}
+
+// Create stores a new product, filling in its ID, CreatedAt and Version like the column defaults do.
+func (r *memoryProductRepo) Create(_ context.Context, product *entity.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *product
+	if stored.ID == uuid.Nil {
+		stored.ID = uuid.New()
+	}
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = time.Now()
+	}
+	if stored.Version == 0 {
+		stored.Version = 1
+	}
+
+	if _, exists := r.products[stored.ID]; exists {
+		return apperror.Wrap(apperror.Conflict, fmt.Errorf("product %s already exists", stored.ID), "resource already exists")
+	}
+	if err := r.checkConstraints(&stored); err != nil {
+		return err
+	}
+
+	r.products[stored.ID] = stored
+	*product = stored
+
+	return nil
+}
+
+// UpsertBySKU creates the product or replaces the one with the same SKU, like the
+// INSERT ... ON CONFLICT (sku) statement of productRepo.
+func (r *memoryProductRepo) UpsertBySKU(_ context.Context, product *entity.Product, expectedVersion *int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, found := r.findBySKU(product.SKU)
+	if !found {
+		stored := entity.Product{
+			ID:        uuid.New(),
+			SKU:       product.SKU,
+			Name:      product.Name,
+			Qty:       product.Qty,
+			Price:     product.Price,
+			CreatedAt: time.Now(),
+			Version:   1,
+		}
+		if err := r.checkConstraints(&stored); err != nil {
+			return false, err
+		}
+		r.products[stored.ID] = stored
+		*product = stored
+
+		return true, nil
+	}
+
+	switch {
+	case expectedVersion == nil:
+		return false, entity.ErrProductVersionRequired
+	case *expectedVersion != 0 && *expectedVersion != existing.Version:
+		return false, entity.ErrProductVersionMismatch
+	}
+
+	now := time.Now()
+	existing.Name = product.Name
+	existing.Qty = product.Qty
+	existing.Price = product.Price
+	existing.DeletedAt = nil
+	existing.UpdatedAt = &now
+	existing.Version++
+	if err := r.checkConstraints(&existing); err != nil {
+		return false, err
+	}
+	r.products[existing.ID] = existing
+	*product = existing
+
+	return false, nil
+}
+
+// GetByID returns a copy of the stored product. ForUpdate locks nothing: the memory
+// TxManager gives no isolation to protect.
+func (r *memoryProductRepo) GetByID(_ context.Context, query dto.GetProductQuery) (*entity.Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	product, ok := r.products[query.ID]
+	if !ok || (product.DeletedAt != nil && !query.IncludeDeleted) {
+		return nil, entity.ErrProductNotFound
+	}
+
+	return &product, nil
+}
+
+// List filters, sorts and pages the products the way productRepo's keyset query does.
+func (r *memoryProductRepo) List(_ context.Context, query dto.ListProductsQuery) ([]entity.Product, error) {
+	r.mu.RLock()
+	products := make([]entity.Product, 0, len(r.products))
+	for _, p := range r.products {
+		if matchesProductFilters(&p, query) {
+			products = append(products, p)
+		}
+	}
+	r.mu.RUnlock()
+
+	compare := func(a, b *entity.Product) int {
+		c := 0
+		switch query.SortBy {
+		case dto.SortByName:
+			c = strings.Compare(a.Name, b.Name)
+		case dto.SortByPrice:
+			c = cmp.Compare(a.Price.Amount, b.Price.Amount)
+		}
+		if c == 0 {
+			c = a.CreatedAt.Compare(b.CreatedAt)
+		}
+		if c == 0 {
+			c = strings.Compare(a.ID.String(), b.ID.String())
+		}
+		if query.Order != dto.SortAsc {
+			c = -c
+		}
+		return c
+	}
+	slices.SortFunc(products, func(a, b entity.Product) int { return compare(&a, &b) })
+
+	if query.Cursor != nil {
+		last := entity.Product{
+			ID:        query.Cursor.ID,
+			Name:      query.Cursor.Name,
+			Price:     entity.Money{Amount: query.Cursor.Price},
+			CreatedAt: query.Cursor.CreatedAt,
+		}
+		start, _ := slices.BinarySearchFunc(products, &last, func(p entity.Product, last *entity.Product) int {
+			if compare(&p, last) <= 0 {
+				return -1
+			}
+			return 1
+		})
+		products = products[start:]
+	}
+
+	if query.Limit > 0 && len(products) > query.Limit {
+		products = products[:query.Limit]
+	}
+
+	return products, nil
+}
+
+// Update replaces the mutable fields of a live product whose version still matches.
+func (r *memoryProductRepo) Update(_ context.Context, product *entity.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.products[product.ID]
+	if !ok || stored.DeletedAt != nil {
+		return entity.ErrProductNotFound
+	}
+	if stored.Version != product.Version {
+		return entity.ErrProductVersionMismatch
+	}
+
+	now := time.Now()
+	stored.SKU = product.SKU
+	stored.Name = product.Name
+	stored.Qty = product.Qty
+	stored.Price = product.Price
+	stored.UpdatedAt = &now
+	stored.Version++
+	if err := r.checkConstraints(&stored); err != nil {
+		return err
+	}
+
+	r.products[stored.ID] = stored
+	*product = stored
+
+	return nil
+}
+
+// Delete soft-deletes a live product.
+func (r *memoryProductRepo) Delete(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.products[id]
+	if !ok || stored.DeletedAt != nil {
+		return entity.ErrProductNotFound
+	}
+
+	now := time.Now()
+	stored.DeletedAt = &now
+	stored.UpdatedAt = &now
+	stored.Version++
+	r.products[id] = stored
+
+	return nil
+}
+
+// Restore brings a soft-deleted product back.
+func (r *memoryProductRepo) Restore(_ context.Context, id uuid.UUID) (*entity.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.products[id]
+	if !ok || stored.DeletedAt == nil {
+		return nil, entity.ErrProductNotFound
+	}
+
+	now := time.Now()
+	stored.DeletedAt = nil
+	stored.UpdatedAt = &now
+	stored.Version++
+	r.products[id] = stored
+
+	return &stored, nil
+}
+
+// findBySKU returns the product, deleted or not, holding sku. Like NULLs in the unique
+// index, the empty SKU never matches. The caller must hold r.mu.
+func (r *memoryProductRepo) findBySKU(sku entity.SKU) (entity.Product, bool) {
+	if sku == "" {
+		return entity.Product{}, false
+	}
+	for _, p := range r.products {
+		if p.SKU == sku {
+			return p, true
+		}
+	}
+
+	return entity.Product{}, false
+}
+
+// checkConstraints applies the CHECK, length and unique constraints of the products
+// table, reporting violations as translateError reports the Postgres ones.
+// The caller must hold r.mu.
+func (r *memoryProductRepo) checkConstraints(p *entity.Product) error {
+	var violation string
+	switch {
+	case p.Qty < 0:
+		violation = "qty must be >= 0"
+	case p.Price.Amount <= 0:
+		violation = "price must be > 0"
+	case !currencyPattern.MatchString(p.Price.Currency):
+		violation = "currency must be 3 uppercase letters"
+	case p.SKU != "" && !p.SKU.IsValid():
+		violation = "sku has an invalid format"
+	case utf8.RuneCountInString(p.Name) > maxProductNameLength:
+		violation = "name is longer than 255 characters"
+	}
+	if violation != "" {
+		return apperror.Wrap(apperror.Invalid, fmt.Errorf("products: %s", violation), "value violates a database constraint")
+	}
+
+	if other, found := r.findBySKU(p.SKU); found && other.ID != p.ID {
+		return entity.ErrProductSKUTaken
+	}
+
+	return nil
+}
+
+// matchesProductFilters is the in-memory counterpart of applyProductFilters and the soft-delete scope.
+func matchesProductFilters(p *entity.Product, query dto.ListProductsQuery) bool {
+	switch {
+	case p.DeletedAt != nil && !query.IncludeDeleted:
+		return false
+	case query.NamePrefix != "" && !strings.HasPrefix(strings.ToLower(p.Name), strings.ToLower(query.NamePrefix)):
+		return false
+	case query.Currency != "" && p.Price.Currency != query.Currency:
+		return false
+	case query.MinPrice != nil && p.Price.Amount < *query.MinPrice:
+		return false
+	case query.MaxPrice != nil && p.Price.Amount > *query.MaxPrice:
+		return false
+	case query.MinQty != nil && p.Qty < *query.MinQty:
+		return false
+	case query.MaxQty != nil && p.Qty > *query.MaxQty:
+		return false
+	}
+
+	return true
+}
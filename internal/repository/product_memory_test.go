@@ -0,0 +1,344 @@
+package repository_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryProductRepo_Create(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewMemoryProductRepository()
+	ctx := t.Context()
+
+	product := &entity.Product{SKU: "TSHIRT-RED", Name: "T-shirt", Qty: 1, Price: entity.NewMoney(1999, "USD")}
+	require.NoError(t, repo.Create(ctx, product))
+	assert.NotEqual(t, uuid.Nil, product.ID)
+	assert.False(t, product.CreatedAt.IsZero())
+	assert.Equal(t, int64(1), product.Version)
+
+	got, err := repo.GetByID(ctx, dto.GetProductQuery{ID: product.ID})
+	require.NoError(t, err)
+	assert.Equal(t, product, got)
+
+	// The returned product is a copy: changing it does not change the stored one.
+	got.Name = "changed"
+	again, err := repo.GetByID(ctx, dto.GetProductQuery{ID: product.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "T-shirt", again.Name)
+
+	_, err = repo.GetByID(ctx, dto.GetProductQuery{ID: uuid.New()})
+	assert.ErrorIs(t, err, entity.ErrProductNotFound)
+}
+
+func TestMemoryProductRepo_Constraints(t *testing.T) {
+	t.Parallel()
+
+	valid := func() entity.Product {
+		return entity.Product{Name: "Mug", Qty: 0, Price: entity.NewMoney(500, "EUR")}
+	}
+
+	tests := []struct {
+		name        string
+		mutate      func(p *entity.Product)
+		expectedErr error
+	}{
+		{name: "zero quantity is allowed", mutate: func(*entity.Product) {}},
+		{name: "negative quantity", mutate: func(p *entity.Product) { p.Qty = -1 }, expectedErr: apperror.Invalid},
+		{name: "zero price", mutate: func(p *entity.Product) { p.Price.Amount = 0 }, expectedErr: apperror.Invalid},
+		{name: "lowercase currency", mutate: func(p *entity.Product) { p.Price.Currency = "eur" }, expectedErr: apperror.Invalid},
+		{name: "malformed sku", mutate: func(p *entity.Product) { p.SKU = "a b" }, expectedErr: apperror.Invalid},
+		{name: "name too long", mutate: func(p *entity.Product) { p.Name = strings.Repeat("é", 256) }, expectedErr: apperror.Invalid},
+		{name: "sku taken", mutate: func(p *entity.Product) { p.SKU = "MUG-1" }, expectedErr: entity.ErrProductSKUTaken},
+		{name: "sku taken by a deleted product", mutate: func(p *entity.Product) { p.SKU = "MUG-GONE" }, expectedErr: entity.ErrProductSKUTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			repo := repository.NewMemoryProductRepository()
+			ctx := t.Context()
+			taken := valid()
+			taken.SKU = "MUG-1"
+			require.NoError(t, repo.Create(ctx, &taken))
+			gone := valid()
+			gone.SKU = "MUG-GONE"
+			require.NoError(t, repo.Create(ctx, &gone))
+			require.NoError(t, repo.Delete(ctx, gone.ID))
+
+			product := valid()
+			tt.mutate(&product)
+
+			// Act
+			err := repo.Create(ctx, &product)
+
+			// Assert
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.expectedErr)
+			products, listErr := repo.List(ctx, dto.ListProductsQuery{IncludeDeleted: true})
+			require.NoError(t, listErr)
+			assert.Len(t, products, 2, "a rejected product must not be stored")
+		})
+	}
+}
+
+func TestMemoryProductRepo_UpsertBySKU(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewMemoryProductRepository()
+	ctx := t.Context()
+
+	product := &entity.Product{SKU: "LAMP-1", Name: "Lamp", Qty: 2, Price: entity.NewMoney(3000, "USD")}
+	created, err := repo.UpsertBySKU(ctx, product, nil)
+	require.NoError(t, err)
+	assert.True(t, created)
+	id := product.ID
+
+	// An existing product is only replaced at the version the caller read.
+	replacement := &entity.Product{SKU: "LAMP-1", Name: "Desk lamp", Qty: 5, Price: entity.NewMoney(3500, "USD")}
+	_, err = repo.UpsertBySKU(ctx, replacement, nil)
+	assert.ErrorIs(t, err, entity.ErrProductVersionRequired)
+	stale := int64(7)
+	_, err = repo.UpsertBySKU(ctx, replacement, &stale)
+	assert.ErrorIs(t, err, entity.ErrProductVersionMismatch)
+
+	// A deleted product is replaced and restored rather than duplicated.
+	require.NoError(t, repo.Delete(ctx, id))
+	current := int64(2)
+	created, err = repo.UpsertBySKU(ctx, replacement, &current)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, id, replacement.ID)
+	assert.Equal(t, int64(3), replacement.Version)
+	assert.Nil(t, replacement.DeletedAt)
+	assert.NotNil(t, replacement.UpdatedAt)
+
+	got, err := repo.GetByID(ctx, dto.GetProductQuery{ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, "Desk lamp", got.Name)
+
+	// A replacement violating a constraint leaves the stored product untouched.
+	_, err = repo.UpsertBySKU(ctx, &entity.Product{SKU: "LAMP-1", Name: "Lamp", Qty: -1, Price: entity.NewMoney(1, "USD")}, new(int64))
+	assert.ErrorIs(t, err, apperror.Invalid)
+	got, err = repo.GetByID(ctx, dto.GetProductQuery{ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, 5, got.Qty)
+}
+
+func TestMemoryProductRepo_Update(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewMemoryProductRepository()
+	ctx := t.Context()
+
+	first := &entity.Product{SKU: "PEN-1", Name: "Pen", Qty: 1, Price: entity.NewMoney(100, "USD")}
+	second := &entity.Product{SKU: "PEN-2", Name: "Pencil", Qty: 1, Price: entity.NewMoney(50, "USD")}
+	require.NoError(t, repo.Create(ctx, first))
+	require.NoError(t, repo.Create(ctx, second))
+
+	// Success bumps the version.
+	update := *first
+	update.Qty = 7
+	require.NoError(t, repo.Update(ctx, &update))
+	assert.Equal(t, int64(2), update.Version)
+	assert.NotNil(t, update.UpdatedAt)
+
+	// A stale version is rejected.
+	stale := *first
+	assert.ErrorIs(t, repo.Update(ctx, &stale), entity.ErrProductVersionMismatch)
+
+	// Taking another product's SKU is rejected.
+	taken := update
+	taken.SKU = second.SKU
+	assert.ErrorIs(t, repo.Update(ctx, &taken), entity.ErrProductSKUTaken)
+
+	// Unknown and deleted products are not found.
+	unknown := update
+	unknown.ID = uuid.New()
+	assert.ErrorIs(t, repo.Update(ctx, &unknown), entity.ErrProductNotFound)
+
+	require.NoError(t, repo.Delete(ctx, second.ID))
+	deleted := *second
+	assert.ErrorIs(t, repo.Update(ctx, &deleted), entity.ErrProductNotFound)
+}
+
+func TestMemoryProductRepo_DeleteAndRestore(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewMemoryProductRepository()
+	ctx := t.Context()
+
+	product := &entity.Product{Name: "Chair", Qty: 1, Price: entity.NewMoney(9000, "USD")}
+	require.NoError(t, repo.Create(ctx, product))
+
+	_, err := repo.Restore(ctx, product.ID)
+	assert.ErrorIs(t, err, entity.ErrProductNotFound, "a live product cannot be restored")
+
+	require.NoError(t, repo.Delete(ctx, product.ID))
+	assert.ErrorIs(t, repo.Delete(ctx, product.ID), entity.ErrProductNotFound)
+
+	_, err = repo.GetByID(ctx, dto.GetProductQuery{ID: product.ID})
+	assert.ErrorIs(t, err, entity.ErrProductNotFound)
+	deleted, err := repo.GetByID(ctx, dto.GetProductQuery{ID: product.ID, IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.NotNil(t, deleted.DeletedAt)
+
+	restored, err := repo.Restore(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	assert.Equal(t, int64(3), restored.Version)
+}
+
+func TestMemoryProductRepo_ListFilters(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	repo := repository.NewMemoryProductRepository()
+	ctx := t.Context()
+	for _, p := range []entity.Product{
+		{Name: "Apple", Qty: 10, Price: entity.NewMoney(100, "USD")},
+		{Name: "apricot", Qty: 0, Price: entity.NewMoney(300, "USD")},
+		{Name: "Banana", Qty: 5, Price: entity.NewMoney(200, "EUR")},
+	} {
+		require.NoError(t, repo.Create(ctx, &p))
+	}
+	gone := &entity.Product{Name: "Avocado", Qty: 1, Price: entity.NewMoney(400, "USD")}
+	require.NoError(t, repo.Create(ctx, gone))
+	require.NoError(t, repo.Delete(ctx, gone.ID))
+
+	ptr := func(v int64) *int64 { return &v }
+	qty := func(v int) *int { return &v }
+
+	tests := []struct {
+		name     string
+		query    dto.ListProductsQuery
+		expected []string
+	}{
+		{name: "no filter", query: dto.ListProductsQuery{SortBy: dto.SortByName, Order: dto.SortAsc}, expected: []string{"Apple", "Banana", "apricot"}},
+		{name: "name prefix ignores case", query: dto.ListProductsQuery{NamePrefix: "AP"}, expected: []string{"Apple", "apricot"}},
+		{name: "currency", query: dto.ListProductsQuery{Currency: "EUR"}, expected: []string{"Banana"}},
+		{name: "price range", query: dto.ListProductsQuery{Currency: "USD", MinPrice: ptr(150), MaxPrice: ptr(300)}, expected: []string{"apricot"}},
+		{name: "quantity range", query: dto.ListProductsQuery{MinQty: qty(1), MaxQty: qty(5)}, expected: []string{"Banana"}},
+		{name: "include deleted", query: dto.ListProductsQuery{NamePrefix: "av", IncludeDeleted: true}, expected: []string{"Avocado"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Act
+			products, err := repo.List(ctx, tt.query)
+
+			// Assert
+			require.NoError(t, err)
+			names := make([]string, 0, len(products))
+			for _, p := range products {
+				names = append(names, p.Name)
+			}
+			if tt.query.SortBy == "" {
+				assert.ElementsMatch(t, tt.expected, names)
+			} else {
+				assert.Equal(t, tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestMemoryProductRepo_ListPages(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: prices repeat so paging must fall back to (created_at, id) to break ties.
+	repo := repository.NewMemoryProductRepository()
+	ctx := t.Context()
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range 7 {
+		p := &entity.Product{
+			Name:      fmt.Sprintf("product-%d", i),
+			Qty:       i,
+			Price:     entity.NewMoney(int64(100*(i%3+1)), "USD"),
+			CreatedAt: created.Add(time.Duration(i%2) * time.Hour),
+		}
+		require.NoError(t, repo.Create(ctx, p))
+	}
+
+	for _, sortBy := range []dto.ProductSortField{dto.SortByCreatedAt, dto.SortByName, dto.SortByPrice} {
+		for _, order := range []dto.SortOrder{dto.SortAsc, dto.SortDesc} {
+			t.Run(string(sortBy)+" "+string(order), func(t *testing.T) {
+				t.Parallel()
+
+				all, err := repo.List(ctx, dto.ListProductsQuery{SortBy: sortBy, Order: order})
+				require.NoError(t, err)
+				require.Len(t, all, 7)
+
+				// Act: walk the pages, resuming after the last product of each.
+				var paged []entity.Product
+				query := dto.ListProductsQuery{SortBy: sortBy, Order: order, Limit: 3}
+				for {
+					page, err := repo.List(ctx, query)
+					require.NoError(t, err)
+					paged = append(paged, page...)
+					if len(page) < query.Limit {
+						break
+					}
+					last := page[len(page)-1]
+					query.Cursor = &dto.ProductCursor{
+						SortBy: sortBy, Order: order,
+						Name: last.Name, Price: last.Price.Amount, CreatedAt: last.CreatedAt, ID: last.ID,
+					}
+				}
+
+				// Assert
+				assert.Equal(t, all, paged)
+			})
+		}
+	}
+}
+
+func TestMemoryProductRepo_ConcurrentUse(t *testing.T) {
+	t.Parallel()
+
+	repo := repository.NewMemoryProductRepository()
+	ctx := t.Context()
+
+	// Every writer races for the same SKU: exactly one wins.
+	const writers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := &entity.Product{SKU: "RACE-1", Name: fmt.Sprintf("racer-%d", i), Qty: i, Price: entity.NewMoney(100, "USD")}
+			errs <- repo.Create(ctx, p)
+			_, _ = repo.List(ctx, dto.ListProductsQuery{})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, entity.ErrProductSKUTaken)
+	}
+	assert.Equal(t, 1, succeeded)
+}
@@ -4,12 +4,26 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/DucTran999/go-clean-archx/internal/dto"
 	"github.com/DucTran999/go-clean-archx/internal/entity"
 	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// skuUniqueIndex is the unique index on products.sku; see the add_products_sku migration.
+const skuUniqueIndex = "idx_products_sku"
+
+// liveProduct matches products that are not soft-deleted. Every statement on live
+// products states it explicitly; nothing adds it behind the repository's back.
+const liveProduct = "deleted_at IS NULL"
+
 // productRepo is the GORM-based implementation of the ProductRepository interface.
 type productRepo struct {
 	db *gorm.DB
@@ -23,6 +37,257 @@ func NewProductRepository(db *gorm.DB) port.ProductRepository {
 }
 
 // Create inserts a new product record into the database.
+// A SKU already used by another product is reported as entity.ErrProductSKUTaken.
 func (r *productRepo) Create(ctx context.Context, product *entity.Product) error {
-	return r.db.WithContext(ctx).Create(product).Error
+	err := conn(ctx, r.db).Create(product).Error
+	if isUniqueViolation(err, skuUniqueIndex) {
+		return entity.ErrProductSKUTaken
+	}
+
+	return translateError(err)
+}
+
+// upsertBySKUQuery inserts a product or, when its SKU exists, overwrites the stored
+// product in place, bringing it back if it was soft-deleted. xmax is 0 only for a
+// freshly inserted row version, which tells the two outcomes apart in one round trip.
+// The %s condition guards the overwrite; when it does not hold, no row is returned.
+const upsertBySKUQuery = `INSERT INTO products (sku, name, qty, price, currency) VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (sku) DO UPDATE SET
+	name = EXCLUDED.name,
+	qty = EXCLUDED.qty,
+	price = EXCLUDED.price,
+	currency = EXCLUDED.currency,
+	deleted_at = NULL,
+	version = products.version + 1
+WHERE %s
+RETURNING *, (xmax = 0) AS inserted`
+
+// UpsertBySKU creates the product or updates the one with the same SKU in a single
+// atomic statement, so concurrent requests cannot create duplicates, and the version
+// check cannot race another write.
+func (r *productRepo) UpsertBySKU(ctx context.Context, product *entity.Product, expectedVersion *int64) (bool, error) {
+	var row struct {
+		entity.Product
+
+		Inserted bool
+	}
+
+	args := []any{product.SKU, product.Name, product.Qty, product.Price.Amount, product.Price.Currency}
+	condition := "false"
+	switch {
+	case expectedVersion == nil:
+	case *expectedVersion == 0:
+		condition = "true"
+	default:
+		condition = "products.version = ?"
+		args = append(args, *expectedVersion)
+	}
+
+	result := conn(ctx, r.db).Raw(fmt.Sprintf(upsertBySKUQuery, condition), args...).Scan(&row)
+	if result.Error != nil {
+		return false, translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// The SKU exists and the condition kept it from being replaced.
+		if expectedVersion == nil {
+			return false, entity.ErrProductVersionRequired
+		}
+		return false, entity.ErrProductVersionMismatch
+	}
+
+	*product = row.Product
+
+	return row.Inserted, nil
+}
+
+// GetByID fetches a single product by its primary key.
+// gorm.ErrRecordNotFound is translated into entity.ErrProductNotFound (kind NotFound)
+// so callers never depend on GORM-specific errors. ForUpdate adds FOR UPDATE.
+func (r *productRepo) GetByID(ctx context.Context, query dto.GetProductQuery) (*entity.Product, error) {
+	var product entity.Product
+
+	db := r.scoped(ctx, query.IncludeDeleted)
+	if query.ForUpdate {
+		db = db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate})
+	}
+	err := db.First(&product, "id = ?", query.ID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, entity.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &product, nil
+}
+
+// List returns a page of products using keyset pagination over (sort column, created_at, id).
+// Unlike OFFSET paging, the cost of a page does not grow with its position in the catalog.
+func (r *productRepo) List(ctx context.Context, query dto.ListProductsQuery) ([]entity.Product, error) {
+	products := make([]entity.Product, 0)
+
+	db := applyProductFilters(r.scoped(ctx, query.IncludeDeleted), query)
+
+	direction, comparator := "DESC", "<"
+	if query.Order == dto.SortAsc {
+		direction, comparator = "ASC", ">"
+	}
+
+	// Sort columns come from a fixed whitelist, never from raw user input.
+	switch query.SortBy {
+	case dto.SortByName, dto.SortByPrice:
+		column := string(query.SortBy)
+		if query.Cursor != nil {
+			value := any(query.Cursor.Name)
+			if query.SortBy == dto.SortByPrice {
+				value = query.Cursor.Price
+			}
+			db = db.Where("("+column+", created_at, id) "+comparator+" (?, ?, ?)", value, query.Cursor.CreatedAt, query.Cursor.ID)
+		}
+		db = db.Order(column + " " + direction)
+	default:
+		if query.Cursor != nil {
+			db = db.Where("(created_at, id) "+comparator+" (?, ?)", query.Cursor.CreatedAt, query.Cursor.ID)
+		}
+	}
+	db = db.Order("created_at " + direction).Order("id " + direction)
+
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	if err := db.Find(&products).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	return products, nil
+}
+
+// Update writes the product's mutable columns and reads the row back with RETURNING,
+// so fields maintained by the database are reflected in the given product.
+// UpdateColumns is used on purpose: GORM's autoUpdateTime would otherwise stamp
+// updated_at with the application clock, while the set_updated_at trigger owns it.
+//
+// The write only applies while the stored version still equals product.Version and
+// increments it, so an update based on a stale read can never overwrite a newer one.
+func (r *productRepo) Update(ctx context.Context, product *entity.Product) error {
+	result := conn(ctx, r.db).
+		Model(product).
+		Clauses(clause.Returning{}).
+		Where(liveProduct).
+		Where("version = ?", product.Version).
+		UpdateColumns(map[string]any{
+			"sku":      product.SKU,
+			"name":     product.Name,
+			"qty":      product.Qty,
+			"price":    product.Price.Amount,
+			"currency": product.Price.Currency,
+			"version":  gorm.Expr("version + 1"),
+		})
+	if isUniqueViolation(result.Error, skuUniqueIndex) {
+		return entity.ErrProductSKUTaken
+	}
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return r.explainMissedWrite(ctx, product.ID)
+	}
+
+	return nil
+}
+
+// explainMissedWrite tells why a conditional write to a live product matched no row:
+// either the product does not exist (anymore), or its version has moved on.
+func (r *productRepo) explainMissedWrite(ctx context.Context, id uuid.UUID) error {
+	var count int64
+	err := conn(ctx, r.db).Model(&entity.Product{}).Where(liveProduct).Where("id = ?", id).Count(&count).Error
+	if err != nil {
+		return translateError(err)
+	}
+	if count == 0 {
+		return entity.ErrProductNotFound
+	}
+
+	return entity.ErrProductVersionMismatch
+}
+
+// Delete soft-deletes a live product; the version is bumped like on any other write.
+func (r *productRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).
+		Model(&entity.Product{}).
+		Where(liveProduct).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{
+			"deleted_at": time.Now(),
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrProductNotFound
+	}
+
+	return nil
+}
+
+// Restore clears deleted_at on a soft-deleted product and returns the restored row.
+func (r *productRepo) Restore(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	var product entity.Product
+
+	result := conn(ctx, r.db).
+		Model(&product).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		UpdateColumns(map[string]any{
+			"deleted_at": nil,
+			"version":    gorm.Expr("version + 1"),
+		})
+	if result.Error != nil {
+		return nil, translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, entity.ErrProductNotFound
+	}
+
+	return &product, nil
+}
+
+// applyProductFilters narrows a product query by name prefix, currency, price range and qty range.
+// Prices are compared in minor units, which is only meaningful within one currency.
+func applyProductFilters(db *gorm.DB, query dto.ListProductsQuery) *gorm.DB {
+	if query.NamePrefix != "" {
+		db = db.Where("name ILIKE ?", likeEscaper.Replace(query.NamePrefix)+"%")
+	}
+	if query.Currency != "" {
+		db = db.Where("currency = ?", query.Currency)
+	}
+	if query.MinPrice != nil {
+		db = db.Where("price >= ?", *query.MinPrice)
+	}
+	if query.MaxPrice != nil {
+		db = db.Where("price <= ?", *query.MaxPrice)
+	}
+	if query.MinQty != nil {
+		db = db.Where("qty >= ?", *query.MinQty)
+	}
+	if query.MaxQty != nil {
+		db = db.Where("qty <= ?", *query.MaxQty)
+	}
+
+	return db
+}
+
+// likeEscaper escapes LIKE wildcards so a name prefix is matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// scoped returns a session bound to ctx that includes soft-deleted rows only when asked to.
+func (r *productRepo) scoped(ctx context.Context, includeDeleted bool) *gorm.DB {
+	db := conn(ctx, r.db)
+	if includeDeleted {
+		return db
+	}
+
+	return db.Where(liveProduct)
 }
@@ -1,13 +1,20 @@
 package repository_test
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
 	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
 	"github.com/DucTran999/go-clean-archx/internal/repository"
 	"github.com/DucTran999/go-clean-archx/test/datatest"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -40,16 +47,20 @@ func TestProductRepo_CreateFailed(t *testing.T) {
 	product := &entity.Product{
 		Name:  "Mock Product",
 		Qty:   5,
-		Price: 99.99,
+		Price: entity.NewMoney(9999, "USD"),
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectQuery(`INSERT INTO "products"`).
 		WithArgs(
+			nil, // sku: an empty SKU is stored as NULL
 			product.Name,
 			product.Qty,
-			product.Price,
-			sqlmock.AnyArg(),
+			product.Price.Amount,
+			product.Price.Currency,
+			sqlmock.AnyArg(), // updated_at
+			sqlmock.AnyArg(), // deleted_at
+			int64(1),         // version
 		).
 		WillReturnError(datatest.ErrUnexpectedDB)
 	mock.ExpectRollback()
@@ -70,18 +81,23 @@ func TestProductRepo_CreateSuccess(t *testing.T) {
 	repo := repository.NewProductRepository(db)
 
 	product := &entity.Product{
+		SKU:   "TEST-001",
 		Name:  "Test Product",
 		Qty:   10,
-		Price: 99.99,
+		Price: entity.NewMoney(9999, "USD"),
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectQuery(`INSERT INTO "products"`).
 		WithArgs(
+			"TEST-001",
 			product.Name,
 			product.Qty,
-			product.Price,
-			sqlmock.AnyArg(),
+			product.Price.Amount,
+			product.Price.Currency,
+			sqlmock.AnyArg(), // updated_at
+			sqlmock.AnyArg(), // deleted_at
+			int64(1),         // version
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(datatest.FakeProductID))
 	mock.ExpectCommit()
@@ -93,3 +109,718 @@ func TestProductRepo_CreateSuccess(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestProductRepo_CreateDuplicateSKU(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewProductRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "products"`).
+		WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_products_sku"})
+	mock.ExpectRollback()
+
+	// Act
+	err := repo.Create(t.Context(), &entity.Product{SKU: "TEST-001", Name: "Twin", Qty: 1, Price: datatest.FakePrice})
+
+	// Assert
+	assert.ErrorIs(t, err, entity.ErrProductSKUTaken)
+	assert.ErrorIs(t, err, apperror.Conflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_UpsertBySKU(t *testing.T) {
+	t.Parallel()
+
+	anyVersion, version3 := int64(0), int64(3)
+	columns := []string{"id", "sku", "name", "qty", "price", "currency", "inserted"}
+
+	tests := []struct {
+		name            string
+		expectedVersion *int64
+		setupMock       func(mock sqlmock.Sqlmock)
+		expectedCreated bool
+		expectedErr     error
+	}{
+		{
+			name: "inserted",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO products \(sku, name, qty, price, currency\) VALUES \(\$1, \$2, \$3, \$4, \$5\)\s+`+
+					`ON CONFLICT \(sku\) DO UPDATE SET .*deleted_at = NULL,\s+version = products.version \+ 1\s+`+
+					`WHERE false\s+RETURNING \*, \(xmax = 0\) AS inserted`).
+					WithArgs("HAT-001", "Hat", 4, int64(4950), "USD").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(datatest.FakeProductID, "HAT-001", "Hat", 4, 4950, "USD", true))
+			},
+			expectedCreated: true,
+		},
+		{
+			name:            "replaced whatever its version",
+			expectedVersion: &anyVersion,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO products .* ON CONFLICT \(sku\) DO UPDATE .*WHERE true\s+RETURNING`).
+					WithArgs("HAT-001", "Hat", 4, int64(4950), "USD").
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(datatest.FakeProductID, "HAT-001", "Hat", 4, 4950, "USD", false))
+			},
+			expectedCreated: false,
+		},
+		{
+			name:            "replaced at the expected version",
+			expectedVersion: &version3,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO products .* ON CONFLICT \(sku\) DO UPDATE .*WHERE products.version = \$6\s+RETURNING`).
+					WithArgs("HAT-001", "Hat", 4, int64(4950), "USD", int64(3)).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(datatest.FakeProductID, "HAT-001", "Hat", 4, 4950, "USD", false))
+			},
+			expectedCreated: false,
+		},
+		{
+			name:            "version mismatch",
+			expectedVersion: &version3,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO products .*WHERE products.version = \$6`).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedErr: entity.ErrProductVersionMismatch,
+		},
+		{
+			name: "existing sku without a version",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO products .*WHERE false`).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedErr: entity.ErrProductVersionRequired,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO products`).
+					WillReturnError(datatest.ErrUnexpectedDB)
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock := newMockDB(t)
+			repo := repository.NewProductRepository(db)
+			tt.setupMock(mock)
+
+			product := &entity.Product{SKU: "HAT-001", Name: "Hat", Qty: 4, Price: datatest.FakePrice}
+
+			// Act
+			created, err := repo.UpsertBySKU(t.Context(), product, tt.expectedVersion)
+
+			// Assert
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCreated, created)
+				assert.Equal(t, datatest.FakeProductID, product.ID)
+				assert.Equal(t, datatest.FakePrice, product.Price)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepo_GetByID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "products" WHERE deleted_at IS NULL AND id = \$1`).
+					WithArgs(datatest.FakeProductID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "qty", "price"}).
+						AddRow(datatest.FakeProductID, "Stored Product", 3, 4950))
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "products" WHERE deleted_at IS NULL AND id = \$1`).
+					WithArgs(datatest.FakeProductID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			expectedErr: entity.ErrProductNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "products" WHERE deleted_at IS NULL AND id = \$1`).
+					WithArgs(datatest.FakeProductID, 1).
+					WillReturnError(datatest.ErrUnexpectedDB)
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock := newMockDB(t)
+			repo := repository.NewProductRepository(db)
+			tt.setupMock(mock)
+
+			// Act
+			got, err := repo.GetByID(t.Context(), dto.GetProductQuery{ID: datatest.FakeProductID})
+
+			// Assert
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, datatest.FakeProductID, got.ID)
+				assert.Equal(t, "Stored Product", got.Name)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepo_ListSuccess(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewProductRepository(db)
+
+	mock.ExpectQuery(`SELECT \* FROM "products" WHERE deleted_at IS NULL ORDER BY created_at DESC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "qty", "price"}).
+			AddRow(datatest.FakeProductID, "Stored Product", 3, 4950))
+
+	// Act
+	products, err := repo.List(t.Context(), dto.ListProductsQuery{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.Equal(t, datatest.FakeProductID, products[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_ListFailed(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewProductRepository(db)
+
+	mock.ExpectQuery(`SELECT \* FROM "products" WHERE deleted_at IS NULL ORDER BY created_at DESC`).
+		WillReturnError(datatest.ErrUnexpectedDB)
+
+	// Act
+	products, err := repo.List(t.Context(), dto.ListProductsQuery{})
+
+	// Assert
+	assert.ErrorIs(t, err, datatest.ErrUnexpectedDB)
+	assert.Nil(t, products)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_Update(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE "products" SET "currency"=\$1,"name"=\$2,"price"=\$3,"qty"=\$4,"sku"=\$5,"version"=version \+ 1 `+
+					`WHERE deleted_at IS NULL AND version = \$6 AND "id" = \$7 RETURNING \*`).
+					WithArgs("USD", "Renamed Product", int64(5990), 7, "HAT-001", int64(2), datatest.FakeProductID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "qty", "price", "currency", "updated_at", "version"}).
+						AddRow(datatest.FakeProductID, "Renamed Product", 7, 5990, "USD", time.Now(), 3))
+				mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE "products"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "products" WHERE deleted_at IS NULL AND id = \$1`).
+					WithArgs(datatest.FakeProductID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+			expectedErr: entity.ErrProductNotFound,
+		},
+		{
+			name: "stale version",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE "products"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "products"`).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+			expectedErr: entity.ErrProductVersionMismatch,
+		},
+		{
+			name: "sku taken by another product",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE "products"`).
+					WillReturnError(&pgconn.PgError{Code: "23505", ConstraintName: "idx_products_sku"})
+				mock.ExpectRollback()
+			},
+			expectedErr: entity.ErrProductSKUTaken,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE "products"`).
+					WillReturnError(datatest.ErrUnexpectedDB)
+				mock.ExpectRollback()
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock := newMockDB(t)
+			repo := repository.NewProductRepository(db)
+			tt.setupMock(mock)
+
+			product := &entity.Product{
+				ID:      datatest.FakeProductID,
+				SKU:     "HAT-001",
+				Name:    "Renamed Product",
+				Qty:     7,
+				Price:   entity.NewMoney(5990, "USD"),
+				Version: 2,
+			}
+
+			// Act
+			err := repo.Update(t.Context(), product)
+
+			// Assert
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, product.UpdatedAt)
+				assert.Equal(t, int64(3), product.Version)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepo_GetByIDIncludeDeleted(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewProductRepository(db)
+
+	deletedAt := time.Now()
+	mock.ExpectQuery(`SELECT \* FROM "products" WHERE id = \$1 ORDER BY`).
+		WithArgs(datatest.FakeProductID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "qty", "price", "deleted_at"}).
+			AddRow(datatest.FakeProductID, "Stored Product", 3, 4950, deletedAt))
+
+	// Act
+	got, err := repo.GetByID(t.Context(), dto.GetProductQuery{ID: datatest.FakeProductID, IncludeDeleted: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, got.DeletedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_GetByIDForUpdate(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewProductRepository(db)
+
+	mock.ExpectQuery(`SELECT \* FROM "products" WHERE deleted_at IS NULL AND id = \$1 .* FOR UPDATE$`).
+		WithArgs(datatest.FakeProductID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "qty", "price", "version"}).
+			AddRow(datatest.FakeProductID, "Stored Product", 3, 4950, 2))
+
+	// Act
+	got, err := repo.GetByID(t.Context(), dto.GetProductQuery{ID: datatest.FakeProductID, ForUpdate: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), got.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_ListIncludeDeleted(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewProductRepository(db)
+
+	mock.ExpectQuery(`SELECT \* FROM "products" ORDER BY created_at DESC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "qty", "price"}).
+			AddRow(datatest.FakeProductID, "Stored Product", 3, 4950))
+
+	// Act
+	products, err := repo.List(t.Context(), dto.ListProductsQuery{IncludeDeleted: true})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_Delete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "products" SET "deleted_at"=\$1,"version"=version \+ 1 WHERE deleted_at IS NULL AND id = \$2`).
+					WithArgs(sqlmock.AnyArg(), datatest.FakeProductID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "products" SET "deleted_at"`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			expectedErr: entity.ErrProductNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "products" SET "deleted_at"`).
+					WillReturnError(datatest.ErrUnexpectedDB)
+				mock.ExpectRollback()
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock := newMockDB(t)
+			repo := repository.NewProductRepository(db)
+			tt.setupMock(mock)
+
+			// Act
+			err := repo.Delete(t.Context(), datatest.FakeProductID)
+
+			// Assert
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepo_Restore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "success",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE "products" SET "deleted_at"=\$1,"version"=version \+ 1 WHERE id = \$2 AND deleted_at IS NOT NULL RETURNING \*`).
+					WithArgs(nil, datatest.FakeProductID).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "qty", "price"}).
+						AddRow(datatest.FakeProductID, "Stored Product", 3, 4950))
+				mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "not deleted or missing",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE "products" SET "deleted_at"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+			},
+			expectedErr: entity.ErrProductNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`UPDATE "products" SET "deleted_at"`).
+					WillReturnError(datatest.ErrUnexpectedDB)
+				mock.ExpectRollback()
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock := newMockDB(t)
+			repo := repository.NewProductRepository(db)
+			tt.setupMock(mock)
+
+			// Act
+			got, err := repo.Restore(t.Context(), datatest.FakeProductID)
+
+			// Assert
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, datatest.FakeProductID, got.ID)
+				assert.Nil(t, got.DeletedAt)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepo_ListWithCursorAndFilters(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewProductRepository(db)
+
+	minPrice, maxPrice := int64(1000), int64(10000)
+	minQty := 1
+	cursorTime := time.Now()
+
+	mock.ExpectQuery(`SELECT \* FROM "products" WHERE deleted_at IS NULL AND name ILIKE \$1 AND currency = \$2 AND price >= \$3 `+
+		`AND price <= \$4 AND qty >= \$5 AND \(price, created_at, id\) > \(\$6, \$7, \$8\) `+
+		`ORDER BY price ASC,created_at ASC,id ASC LIMIT \$9`).
+		WithArgs(`50\%\_off%`, "USD", minPrice, maxPrice, minQty, int64(2000), cursorTime, datatest.FakeProductID, 11).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "qty", "price"}).
+			AddRow(datatest.FakeProductID, "50%_off Hat", 3, 4950))
+
+	// Act
+	products, err := repo.List(t.Context(), dto.ListProductsQuery{
+		Limit:      11,
+		SortBy:     dto.SortByPrice,
+		Order:      dto.SortAsc,
+		NamePrefix: "50%_off",
+		Currency:   "USD",
+		MinPrice:   &minPrice,
+		MaxPrice:   &maxPrice,
+		MinQty:     &minQty,
+		Cursor: &dto.ProductCursor{
+			SortBy:    dto.SortByPrice,
+			Order:     dto.SortAsc,
+			Price:     2000,
+			CreatedAt: cursorTime,
+			ID:        datatest.FakeProductID,
+		},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_ListDefaultSortWithCursor(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewProductRepository(db)
+
+	cursorTime := time.Now()
+
+	mock.ExpectQuery(`SELECT \* FROM "products" WHERE deleted_at IS NULL AND \(created_at, id\) < \(\$1, \$2\) `+
+		`ORDER BY created_at DESC,id DESC LIMIT \$3`).
+		WithArgs(cursorTime, datatest.FakeProductID, 21).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// Act
+	products, err := repo.List(t.Context(), dto.ListProductsQuery{
+		Limit:  21,
+		SortBy: dto.SortByCreatedAt,
+		Order:  dto.SortDesc,
+		Cursor: &dto.ProductCursor{CreatedAt: cursorTime, ID: datatest.FakeProductID},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, products)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductRepo_WithinTx(t *testing.T) {
+	t.Parallel()
+
+	newProduct := func() *entity.Product {
+		return &entity.Product{Name: "Mock Product", Qty: 10, Price: datatest.FakePrice}
+	}
+
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		unitOfWork  func(ctx context.Context, txm port.TxManager, repo port.ProductRepository) error
+		expectedErr error
+	}{
+		{
+			name: "writes of several calls commit together",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "products"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(datatest.FakeProductID))
+				mock.ExpectExec(`UPDATE "products" SET "deleted_at"`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			unitOfWork: func(ctx context.Context, _ port.TxManager, repo port.ProductRepository) error {
+				if err := repo.Create(ctx, newProduct()); err != nil {
+					return err
+				}
+				return repo.Delete(ctx, datatest.FakeProductID)
+			},
+		},
+		{
+			name: "a failed write rolls back the earlier ones",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "products"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(datatest.FakeProductID))
+				mock.ExpectExec(`UPDATE "products" SET "deleted_at"`).
+					WillReturnError(datatest.ErrUnexpectedDB)
+				mock.ExpectRollback()
+			},
+			unitOfWork: func(ctx context.Context, _ port.TxManager, repo port.ProductRepository) error {
+				if err := repo.Create(ctx, newProduct()); err != nil {
+					return err
+				}
+				return repo.Delete(ctx, datatest.FakeProductID)
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+		{
+			name: "nested failure only rolls back to its savepoint",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(`SAVEPOINT sp[0-9]+`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`INSERT INTO "products"`).
+					WillReturnError(datatest.ErrUnexpectedDB)
+				mock.ExpectExec(`ROLLBACK TO SAVEPOINT sp[0-9]+`).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`INSERT INTO "products"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(datatest.FakeProductID))
+				mock.ExpectCommit()
+			},
+			unitOfWork: func(ctx context.Context, txm port.TxManager, repo port.ProductRepository) error {
+				err := txm.WithinTx(ctx, func(ctx context.Context) error {
+					return repo.Create(ctx, newProduct())
+				})
+				if !errors.Is(err, datatest.ErrUnexpectedDB) {
+					return errors.New("nested error was not returned")
+				}
+				return repo.Create(ctx, newProduct())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock := newMockDB(t)
+			txm := repository.NewTxManager(db)
+			repo := repository.NewProductRepository(db)
+			tt.setupMock(mock)
+
+			// Act
+			err := txm.WithinTx(t.Context(), func(ctx context.Context) error {
+				return tt.unitOfWork(ctx, txm, repo)
+			})
+
+			// Assert
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductRepo_WithinTxRollsBackOnPanic(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	txm := repository.NewTxManager(db)
+	repo := repository.NewProductRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "products"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(datatest.FakeProductID))
+	mock.ExpectRollback()
+
+	// Act & Assert
+	assert.Panics(t, func() {
+		_ = txm.WithinTx(t.Context(), func(ctx context.Context) error {
+			if err := repo.Create(ctx, &entity.Product{Name: "Mock Product", Qty: 10, Price: datatest.FakePrice}); err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
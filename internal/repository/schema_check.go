@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// SchemaDriftKind classifies a difference found by CheckSchema.
+type SchemaDriftKind string
+
+// Kinds of schema drift.
+const (
+	DriftMissingTable      SchemaDriftKind = "missing table"
+	DriftMissingColumn     SchemaDriftKind = "missing column"
+	DriftTypeMismatch      SchemaDriftKind = "type mismatch"
+	DriftMissingConstraint SchemaDriftKind = "missing constraint"
+	DriftMissingIndex      SchemaDriftKind = "missing index"
+)
+
+// SchemaDrift is something the GORM tags of a model declare that the database lacks.
+type SchemaDrift struct {
+	Kind   SchemaDriftKind
+	Table  string
+	Column string // empty for table-level drift
+	Detail string
+}
+
+// String formats the drift as "table.column: kind: detail".
+func (d SchemaDrift) String() string {
+	target := d.Table
+	if d.Column != "" {
+		target += "." + d.Column
+	}
+	if d.Detail == "" {
+		return fmt.Sprintf("%s: %s", target, d.Kind)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", target, d.Kind, d.Detail)
+}
+
+// SchemaModels returns the models stored by this package, whose tables CheckSchema verifies.
+// A model added to a repository must be registered here.
+func SchemaModels() []any {
+	return []any{
+		&entity.Product{},
+		&entity.WebhookSubscription{},
+		&entity.WebhookDelivery{},
+		&outboxMessage{},
+		&idempotencyKey{},
+	}
+}
+
+// liveColumn is a column of the database, as reported by information_schema.
+type liveColumn struct {
+	ColumnName             string
+	DataType               string
+	CharacterMaximumLength *int
+	IsNullable             string
+}
+
+// liveIndex is an index of the database, its columns joined by commas.
+type liveIndex struct {
+	IsUnique  bool
+	IsPrimary bool
+	Columns   string
+}
+
+// CheckSchema compares the tables of models, as their GORM tags describe them, with the
+// live database: missing tables and columns, column types, NOT NULL and CHECK constraints,
+// primary keys, unique and plain indexes. Only what the tags declare is checked; columns,
+// constraints and indexes that exist only in the database are not reported.
+//
+// Columns without an explicit type tag only need a type GORM can scan the field from,
+// e.g. any integer type for an int field.
+func CheckSchema(ctx context.Context, db *gorm.DB, models ...any) ([]SchemaDrift, error) {
+	var drifts []SchemaDrift
+	cache := &sync.Map{}
+	for _, model := range models {
+		sch, err := schema.Parse(model, cache, db.NamingStrategy)
+		if err != nil {
+			return nil, fmt.Errorf("parse schema of %T: %w", model, err)
+		}
+
+		found, err := checkTable(ctx, db.WithContext(ctx), sch)
+		if err != nil {
+			return nil, fmt.Errorf("check table %s: %w", sch.Table, err)
+		}
+		drifts = append(drifts, found...)
+	}
+
+	return drifts, nil
+}
+
+func checkTable(ctx context.Context, db *gorm.DB, sch *schema.Schema) ([]SchemaDrift, error) {
+	var columns []liveColumn
+	err := db.Raw(`SELECT column_name, data_type, character_maximum_length, is_nullable
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = ?`, sch.Table).Scan(&columns).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return []SchemaDrift{{Kind: DriftMissingTable, Table: sch.Table}}, nil
+	}
+
+	var checks []string
+	err = db.Raw(`SELECT pg_get_constraintdef(oid) FROM pg_constraint
+		WHERE conrelid = to_regclass(?) AND contype = 'c'`, sch.Table).Scan(&checks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []liveIndex
+	err = db.Raw(`SELECT ix.indisunique AS is_unique, ix.indisprimary AS is_primary,
+			array_to_string(ARRAY(
+				SELECT a.attname FROM unnest(ix.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+				JOIN pg_attribute a ON a.attrelid = ix.indrelid AND a.attnum = k.attnum
+				ORDER BY k.ord
+			), ',') AS columns
+		FROM pg_index ix
+		WHERE ix.indrelid = to_regclass(?)`, sch.Table).Scan(&indexes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	drifts := checkColumns(sch, columns)
+	drifts = append(drifts, checkConstraints(sch, checks)...)
+	drifts = append(drifts, checkIndexes(sch, indexes)...)
+
+	return drifts, nil
+}
+
+func checkColumns(sch *schema.Schema, columns []liveColumn) []SchemaDrift {
+	byName := make(map[string]liveColumn, len(columns))
+	for _, c := range columns {
+		byName[c.ColumnName] = c
+	}
+
+	var drifts []SchemaDrift
+	for _, field := range sch.Fields {
+		if field.DBName == "" {
+			continue
+		}
+		column, ok := byName[field.DBName]
+		if !ok {
+			drifts = append(drifts, SchemaDrift{Kind: DriftMissingColumn, Table: sch.Table, Column: field.DBName})
+			continue
+		}
+
+		if want, ok := typeMatches(field, column); !ok {
+			drifts = append(drifts, SchemaDrift{
+				Kind: DriftTypeMismatch, Table: sch.Table, Column: field.DBName,
+				Detail: fmt.Sprintf("want %s, got %s", want, liveType(column)),
+			})
+		}
+		if (field.NotNull || field.PrimaryKey) && column.IsNullable == "YES" {
+			drifts = append(drifts, SchemaDrift{
+				Kind: DriftMissingConstraint, Table: sch.Table, Column: field.DBName, Detail: "NOT NULL",
+			})
+		}
+	}
+
+	return drifts
+}
+
+func checkConstraints(sch *schema.Schema, checks []string) []SchemaDrift {
+	live := make(map[string]bool, len(checks))
+	for _, def := range checks {
+		live[normalizeCheck(def)] = true
+	}
+
+	expected := sch.ParseCheckConstraints()
+	names := make([]string, 0, len(expected))
+	for name := range expected {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var drifts []SchemaDrift
+	for _, name := range names {
+		chk := expected[name]
+		if live[normalizeCheck(chk.Constraint)] {
+			continue
+		}
+		column := ""
+		if chk.Field != nil {
+			column = chk.Field.DBName
+		}
+		drifts = append(drifts, SchemaDrift{
+			Kind: DriftMissingConstraint, Table: sch.Table, Column: column, Detail: "CHECK (" + chk.Constraint + ")",
+		})
+	}
+
+	return drifts
+}
+
+func checkIndexes(sch *schema.Schema, indexes []liveIndex) []SchemaDrift {
+	var drifts []SchemaDrift
+	has := func(columns []string, accept func(liveIndex) bool) bool {
+		want := strings.Join(columns, ",")
+		return slices.ContainsFunc(indexes, func(ix liveIndex) bool {
+			return ix.Columns == want && accept(ix)
+		})
+	}
+
+	if len(sch.PrimaryFieldDBNames) > 0 &&
+		!has(sch.PrimaryFieldDBNames, func(ix liveIndex) bool { return ix.IsPrimary }) {
+		drifts = append(drifts, SchemaDrift{
+			Kind: DriftMissingConstraint, Table: sch.Table,
+			Detail: "PRIMARY KEY (" + strings.Join(sch.PrimaryFieldDBNames, ", ") + ")",
+		})
+	}
+
+	for _, field := range sch.Fields {
+		if field.Unique && !has([]string{field.DBName}, func(ix liveIndex) bool { return ix.IsUnique }) {
+			drifts = append(drifts, SchemaDrift{
+				Kind: DriftMissingConstraint, Table: sch.Table, Column: field.DBName, Detail: "UNIQUE",
+			})
+		}
+	}
+
+	expected := sch.ParseIndexes()
+	slices.SortFunc(expected, func(a, b *schema.Index) int { return strings.Compare(a.Name, b.Name) })
+	for _, idx := range expected {
+		columns := make([]string, 0, len(idx.Fields))
+		for _, opt := range idx.Fields {
+			if opt.Field == nil {
+				columns = nil // expression index: cannot be compared by columns
+				break
+			}
+			columns = append(columns, opt.DBName)
+		}
+		if columns == nil {
+			continue
+		}
+
+		unique := strings.EqualFold(idx.Class, "UNIQUE")
+		if has(columns, func(ix liveIndex) bool { return !unique || ix.IsUnique }) {
+			continue
+		}
+
+		drift := SchemaDrift{Kind: DriftMissingIndex, Table: sch.Table, Detail: idx.Name + " (" + strings.Join(columns, ", ") + ")"}
+		if unique {
+			drift.Kind = DriftMissingConstraint
+			drift.Detail = "UNIQUE INDEX " + drift.Detail
+		}
+		drifts = append(drifts, drift)
+	}
+
+	return drifts
+}
+
+// typeFamilies lists the database types a field without a type tag can be scanned from.
+var typeFamilies = map[schema.DataType][]string{
+	schema.Bool:   {"boolean"},
+	schema.Int:    {"smallint", "integer", "bigint"},
+	schema.Uint:   {"smallint", "integer", "bigint"},
+	schema.Float:  {"real", "double precision", "numeric"},
+	schema.String: {"text", "character varying", "character"},
+	schema.Time:   {"timestamp with time zone", "timestamp without time zone", "date"},
+	schema.Bytes:  {"bytea"},
+}
+
+// typeAliases maps the PostgreSQL type spellings accepted in tags to information_schema names.
+var typeAliases = map[string]string{
+	"varchar":     "character varying",
+	"char":        "character",
+	"timestamptz": "timestamp with time zone",
+	"timestamp":   "timestamp without time zone",
+	"int":         "integer",
+	"int2":        "smallint",
+	"int4":        "integer",
+	"int8":        "bigint",
+	"bool":        "boolean",
+	"float4":      "real",
+	"float8":      "double precision",
+	"decimal":     "numeric",
+}
+
+// typePattern splits a type tag such as varchar(255) into its name and length.
+var typePattern = regexp.MustCompile(`^\s*([a-z0-9 ]+?)\s*(?:\(\s*(\d+)\s*(?:,\s*\d+\s*)?\))?\s*$`)
+
+// typeMatches reports whether column can hold field, and describes the expected type.
+func typeMatches(field *schema.Field, column liveColumn) (string, bool) {
+	if tag := strings.ToLower(field.TagSettings["TYPE"]); tag != "" {
+		parts := typePattern.FindStringSubmatch(tag)
+		if parts == nil {
+			return tag, true // not a plain type name; trust the tag
+		}
+		name := parts[1]
+		if alias, ok := typeAliases[name]; ok {
+			name = alias
+		}
+		if name != column.DataType {
+			return tag, false
+		}
+		if parts[2] != "" && column.CharacterMaximumLength != nil &&
+			parts[2] != strconv.Itoa(*column.CharacterMaximumLength) {
+			return tag, false
+		}
+		return tag, true
+	}
+
+	family, ok := typeFamilies[field.DataType]
+	if !ok {
+		return string(field.DataType), true // custom type without a tag: nothing to compare with
+	}
+
+	return strings.Join(family, " or "), slices.Contains(family, column.DataType)
+}
+
+func liveType(column liveColumn) string {
+	if column.CharacterMaximumLength != nil {
+		return fmt.Sprintf("%s(%d)", column.DataType, *column.CharacterMaximumLength)
+	}
+
+	return column.DataType
+}
+
+// checkNoise is what normalizeCheck drops: the CHECK keyword, parentheses, whitespace
+// and the casts PostgreSQL adds when it stores an expression.
+var checkNoise = regexp.MustCompile(`(?i)^\s*check\b|::[a-z_]+( varying| with(out)? time zone| precision)?|[()\s]`)
+
+// normalizeCheck reduces a CHECK expression to a form in which the tag and the
+// definition returned by pg_get_constraintdef compare equal, e.g. "qty>=0".
+func normalizeCheck(def string) string {
+	return strings.ToLower(checkNoise.ReplaceAllString(def, ""))
+}
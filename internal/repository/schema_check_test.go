@@ -0,0 +1,153 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/dbtest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// migratedProductColumns are the products columns as the migrations create them.
+func migratedProductColumns() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"column_name", "data_type", "character_maximum_length", "is_nullable"}).
+		AddRow("id", "uuid", nil, "NO").
+		AddRow("name", "character varying", 255, "NO").
+		AddRow("qty", "integer", nil, "NO").
+		AddRow("price", "bigint", nil, "NO").
+		AddRow("created_at", "timestamp with time zone", nil, "NO").
+		AddRow("updated_at", "timestamp with time zone", nil, "YES").
+		AddRow("deleted_at", "timestamp with time zone", nil, "YES").
+		AddRow("currency", "character", 3, "NO").
+		AddRow("sku", "character varying", 64, "YES").
+		AddRow("version", "bigint", nil, "NO")
+}
+
+func migratedProductChecks() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"pg_get_constraintdef"}).
+		AddRow("CHECK ((qty >= 0))").
+		AddRow("CHECK ((price > 0))").
+		AddRow("CHECK ((currency ~ '^[A-Z]{3}$'::text))").
+		AddRow("CHECK ((version > 0))")
+}
+
+func migratedProductIndexes() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"is_unique", "is_primary", "columns"}).
+		AddRow(true, true, "id").
+		AddRow(false, false, "deleted_at").
+		AddRow(false, false, "created_at,id").
+		AddRow(true, false, "sku")
+}
+
+func expectIntrospection(mock sqlmock.Sqlmock, table string, columns, checks, indexes *sqlmock.Rows) {
+	mock.ExpectQuery(`FROM information_schema.columns`).WithArgs(table).WillReturnRows(columns)
+	if checks == nil {
+		return
+	}
+	mock.ExpectQuery(`FROM pg_constraint`).WithArgs(table).WillReturnRows(checks)
+	mock.ExpectQuery(`FROM pg_index`).WithArgs(table).WillReturnRows(indexes)
+}
+
+func TestCheckSchema_NoDrift(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	expectIntrospection(mock, "products", migratedProductColumns(), migratedProductChecks(), migratedProductIndexes())
+
+	// Act
+	drifts, err := repository.CheckSchema(t.Context(), db, &entity.Product{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckSchema_Drift(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: sku is missing, qty became text, name nullable and char(3) became char(2);
+	// the qty check, the sku unique index and the deleted_at index are gone.
+	db, mock := newMockDB(t)
+	columns := sqlmock.NewRows([]string{"column_name", "data_type", "character_maximum_length", "is_nullable"}).
+		AddRow("id", "uuid", nil, "NO").
+		AddRow("name", "character varying", 255, "YES").
+		AddRow("qty", "text", nil, "NO").
+		AddRow("price", "bigint", nil, "NO").
+		AddRow("created_at", "timestamp without time zone", nil, "NO").
+		AddRow("updated_at", "timestamp with time zone", nil, "YES").
+		AddRow("deleted_at", "timestamp with time zone", nil, "YES").
+		AddRow("currency", "character", 2, "NO").
+		AddRow("version", "bigint", nil, "NO")
+	checks := sqlmock.NewRows([]string{"pg_get_constraintdef"}).AddRow("CHECK ((price > 0))")
+	indexes := sqlmock.NewRows([]string{"is_unique", "is_primary", "columns"}).
+		AddRow(true, true, "id").
+		AddRow(false, false, "sku")
+	expectIntrospection(mock, "products", columns, checks, indexes)
+
+	// Act
+	drifts, err := repository.CheckSchema(t.Context(), db, &entity.Product{})
+
+	// Assert
+	require.NoError(t, err)
+	got := make([]string, 0, len(drifts))
+	for _, d := range drifts {
+		got = append(got, d.String())
+	}
+	assert.Equal(t, []string{
+		"products.sku: missing column",
+		"products.name: missing constraint: NOT NULL",
+		"products.qty: type mismatch: want smallint or integer or bigint, got text",
+		"products.currency: type mismatch: want char(3), got character(2)",
+		"products.qty: missing constraint: CHECK (qty >= 0)",
+		"products: missing index: idx_products_deleted_at (deleted_at)",
+		"products: missing constraint: UNIQUE INDEX idx_products_sku (sku)",
+	}, got, "timestamps with or without time zone both hold a time.Time")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckSchema_MissingTable(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	expectIntrospection(mock, "products", sqlmock.NewRows([]string{"column_name"}), nil, nil)
+
+	// Act
+	drifts, err := repository.CheckSchema(t.Context(), db, &entity.Product{})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []repository.SchemaDrift{{Kind: repository.DriftMissingTable, Table: "products"}}, drifts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckSchema_QueryFailed(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	mock.ExpectQuery(`FROM information_schema.columns`).WillReturnError(datatest.ErrUnexpectedDB)
+
+	// Act
+	_, err := repository.CheckSchema(t.Context(), db, repository.SchemaModels()...)
+
+	// Assert
+	assert.ErrorIs(t, err, datatest.ErrUnexpectedDB)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestSchemaMatchesMigrations guards against the GORM tags and the migrations drifting
+// apart. It needs a PostgreSQL database, see dbtest.EnvDatabaseURL.
+func TestSchemaMatchesMigrations(t *testing.T) {
+	db := dbtest.Open(t)
+	dbtest.Migrate(t, db)
+
+	dbtest.RequireSchemaInSync(t, db)
+}
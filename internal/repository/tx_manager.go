@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"gorm.io/gorm"
+)
+
+// txKey is the context key under which txManager stores the ambient transaction.
+type txKey struct{}
+
+// txState is the ambient transaction carried by the context. Nested transactions
+// share the afterCommit hooks of the outermost one, which is the only real commit.
+type txState struct {
+	db          *gorm.DB
+	afterCommit *[]func()
+}
+
+// txManager is the GORM-based implementation of port.TxManager.
+type txManager struct {
+	db *gorm.DB
+}
+
+// NewTxManager creates a TxManager whose transactions are joined by the repositories
+// of this package built on the same database.
+func NewTxManager(db *gorm.DB) port.TxManager {
+	return &txManager{
+		db: db,
+	}
+}
+
+// WithinTx runs fn in a transaction carried by the context. GORM turns a transaction
+// opened on an ongoing one into a savepoint, which gives nesting for free.
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	parent, nested := ctx.Value(txKey{}).(*txState)
+
+	hooks := &[]func(){}
+	if nested {
+		hooks = parent.afterCommit
+	}
+
+	err := conn(ctx, m.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, &txState{db: tx, afterCommit: hooks}))
+	})
+	if err == nil && !nested {
+		for _, hook := range *hooks {
+			hook()
+		}
+	}
+
+	return err
+}
+
+// conn returns the transaction carried by ctx, or db when there is none, bound to ctx.
+// Repositories must reach the database through it to take part in WithinTx.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*txState); ok {
+		return tx.db.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
+
+// inTx reports whether ctx carries a transaction, whose reads may see uncommitted writes.
+func inTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*txState)
+	return ok
+}
+
+// afterCommit runs fn once the transaction carried by ctx commits, or right away when
+// there is none. A hook registered in a nested transaction also runs if only that
+// savepoint was rolled back, so hooks must be harmless when nothing changed.
+func afterCommit(ctx context.Context, fn func()) {
+	tx, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		fn()
+		return
+	}
+
+	*tx.afterCommit = append(*tx.afterCommit, fn)
+}
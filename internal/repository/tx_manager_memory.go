@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/DucTran999/go-clean-archx/internal/port"
+)
+
+// memoryTxManager is the TxManager of the in-memory repositories. They apply every write
+// immediately, so there is nothing to commit and nothing can be rolled back: writes made
+// before fn fails are kept.
+type memoryTxManager struct{}
+
+// NewMemoryTxManager creates a TxManager for the in-memory repositories. It runs fn
+// directly and does not give atomicity; use it for tests and demos only.
+func NewMemoryTxManager() port.TxManager {
+	return memoryTxManager{}
+}
+
+// WithinTx runs fn with ctx unchanged.
+func (memoryTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
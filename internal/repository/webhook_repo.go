@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// webhookRepo is the GORM-based implementation of port.WebhookRepository.
+type webhookRepo struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a WebhookRepository backed by the webhook_subscriptions
+// and webhook_deliveries tables. It joins the transaction of TxManager.
+func NewWebhookRepository(db *gorm.DB) port.WebhookRepository {
+	return &webhookRepo{
+		db: db,
+	}
+}
+
+// CreateSubscription inserts a subscription.
+func (r *webhookRepo) CreateSubscription(ctx context.Context, sub *entity.WebhookSubscription) error {
+	return translateError(conn(ctx, r.db).Create(sub).Error)
+}
+
+// GetSubscription fetches a subscription by its primary key.
+func (r *webhookRepo) GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	var sub entity.WebhookSubscription
+
+	err := conn(ctx, r.db).First(&sub, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, entity.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return &sub, nil
+}
+
+// ListSubscriptions returns every subscription. There are few of them, so they are not paged.
+func (r *webhookRepo) ListSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	subs := make([]entity.WebhookSubscription, 0)
+	if err := conn(ctx, r.db).Order("created_at, id").Find(&subs).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	return subs, nil
+}
+
+// UpdateSubscription writes the subscription's settings and reads the row back with RETURNING.
+func (r *webhookRepo) UpdateSubscription(ctx context.Context, sub *entity.WebhookSubscription) error {
+	result := conn(ctx, r.db).
+		Model(sub).
+		Clauses(clause.Returning{}).
+		UpdateColumns(map[string]any{
+			"url":         sub.URL,
+			"event_types": sub.EventTypes,
+			"secret":      sub.Secret,
+			"active":      sub.Active,
+			"updated_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// DeleteSubscription removes a subscription; the foreign key cascades to its deliveries.
+func (r *webhookRepo) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).Delete(&entity.WebhookSubscription{}, "id = ?", id)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return entity.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// ListSubscribers matches the event type against the JSON array of each active subscription.
+func (r *webhookRepo) ListSubscribers(ctx context.Context, eventType entity.EventType) ([]entity.WebhookSubscription, error) {
+	subs := make([]entity.WebhookSubscription, 0)
+	err := conn(ctx, r.db).
+		Where("active AND event_types @> ?", entity.EventTypes{eventType}).
+		Order("created_at, id").
+		Find(&subs).Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return subs, nil
+}
+
+// AddDeliveries inserts deliveries, skipping those already stored for the same event
+// and subscription (see the uq_webhook_deliveries_event constraint).
+func (r *webhookRepo) AddDeliveries(ctx context.Context, deliveries ...entity.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return translateError(conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&deliveries).Error)
+}
+
+// claimDueDeliveriesQuery locks only the delivery rows: subscriptions stay writable
+// while their deliveries are in flight.
+const claimDueDeliveriesQuery = `SELECT d.*, s.url, s.secret
+FROM webhook_deliveries d
+JOIN webhook_subscriptions s ON s.id = d.subscription_id
+WHERE d.status = 'pending' AND d.next_attempt_at <= now()
+ORDER BY d.next_attempt_at, d.id
+LIMIT ?
+FOR UPDATE OF d SKIP LOCKED`
+
+// ClaimDueDeliveries selects due deliveries with FOR UPDATE SKIP LOCKED, along with
+// the URL and secret of their subscription, and moves their next_attempt_at forward
+// by lease. The row locks only last for the claiming transaction; the lease keeps the
+// deliveries out of other claims while they are sent.
+func (r *webhookRepo) ClaimDueDeliveries(
+	ctx context.Context, limit int, lease time.Duration,
+) ([]dto.WebhookDispatch, error) {
+	var rows []struct {
+		entity.WebhookDelivery
+
+		URL    string
+		Secret string
+	}
+	if err := conn(ctx, r.db).Raw(claimDueDeliveriesQuery, limit).Scan(&rows).Error; err != nil {
+		return nil, translateError(err)
+	}
+	if len(rows) == 0 {
+		return []dto.WebhookDispatch{}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+	}
+	err := conn(ctx, r.db).
+		Model(&entity.WebhookDelivery{}).
+		Where("id IN ?", ids).
+		UpdateColumn("next_attempt_at", gorm.Expr("now() + ? * interval '1 millisecond'", lease.Milliseconds())).
+		Error
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	dispatches := make([]dto.WebhookDispatch, 0, len(rows))
+	for _, row := range rows {
+		dispatches = append(dispatches, dto.WebhookDispatch{
+			Delivery: row.WebhookDelivery,
+			URL:      row.URL,
+			Secret:   row.Secret,
+		})
+	}
+
+	return dispatches, nil
+}
+
+// UpdateDelivery writes the delivery state left by the last attempt.
+func (r *webhookRepo) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	return translateError(conn(ctx, r.db).
+		Model(delivery).
+		UpdateColumns(map[string]any{
+			"status":           delivery.Status,
+			"attempts":         delivery.Attempts,
+			"next_attempt_at":  delivery.NextAttemptAt,
+			"last_status_code": delivery.LastStatusCode,
+			"last_error":       delivery.LastError,
+			"delivered_at":     delivery.DeliveredAt,
+		}).Error)
+}
+
+// ListDeliveries returns the newest deliveries of a subscription, optionally filtered by status.
+func (r *webhookRepo) ListDeliveries(ctx context.Context, query dto.ListWebhookDeliveriesQuery) ([]entity.WebhookDelivery, error) {
+	deliveries := make([]entity.WebhookDelivery, 0)
+
+	db := conn(ctx, r.db).Where("subscription_id = ?", query.SubscriptionID)
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	if err := db.Order("created_at DESC, id DESC").Find(&deliveries).Error; err != nil {
+		return nil, translateError(err)
+	}
+
+	return deliveries, nil
+}
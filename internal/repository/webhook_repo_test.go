@@ -0,0 +1,228 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookRepo_GetSubscription(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		setupMock   func(mock sqlmock.Sqlmock)
+		expectedURL string
+		expectedErr error
+	}{
+		{
+			name: "found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "webhook_subscriptions" WHERE id = \$1`).
+					WithArgs(datatest.FakeProductID, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "url", "event_types", "secret", "active"}).
+						AddRow(datatest.FakeProductID, "https://example.com/hook", `["product.created"]`, "0123456789abcdef", true))
+			},
+			expectedURL: "https://example.com/hook",
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "webhook_subscriptions"`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			expectedErr: entity.ErrWebhookNotFound,
+		},
+		{
+			name: "database error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT \* FROM "webhook_subscriptions"`).
+					WillReturnError(datatest.ErrUnexpectedDB)
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			db, mock := newMockDB(t)
+			repo := repository.NewWebhookRepository(db)
+			tt.setupMock(mock)
+
+			// Act
+			sub, err := repo.GetSubscription(t.Context(), datatest.FakeProductID)
+
+			// Assert
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedURL, sub.URL)
+				assert.Equal(t, entity.EventTypes{entity.EventProductCreated}, sub.EventTypes)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWebhookRepo_UpdateAndDeleteMissingSubscription(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewWebhookRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE "webhook_subscriptions" SET .* WHERE "id" = \$6 RETURNING \*`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "webhook_subscriptions" WHERE id = \$1`).
+		WithArgs(datatest.FakeProductID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// Act
+	updateErr := repo.UpdateSubscription(t.Context(), &entity.WebhookSubscription{
+		ID:         datatest.FakeProductID,
+		URL:        "https://example.com/hook",
+		EventTypes: entity.EventTypes{entity.EventProductCreated},
+	})
+	deleteErr := repo.DeleteSubscription(t.Context(), datatest.FakeProductID)
+
+	// Assert
+	require.ErrorIs(t, updateErr, entity.ErrWebhookNotFound)
+	require.ErrorIs(t, deleteErr, entity.ErrWebhookNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_ListSubscribers(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewWebhookRepository(db)
+
+	mock.ExpectQuery(`SELECT \* FROM "webhook_subscriptions" WHERE active AND event_types @> \$1 ORDER BY created_at, id`).
+		WithArgs(`["product.deleted"]`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url", "event_types", "active"}).
+			AddRow(datatest.FakeProductID, "https://example.com/hook", `["product.deleted","product.created"]`, true))
+
+	// Act
+	subs, err := repo.ListSubscribers(t.Context(), entity.EventProductDeleted)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.True(t, subs[0].EventTypes.Contains(entity.EventProductDeleted))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_AddDeliveriesIgnoresDuplicates(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewWebhookRepository(db)
+
+	sub := &entity.WebhookSubscription{ID: datatest.FakeProductID}
+	delivery := entity.NewWebhookDelivery(sub, entity.NewProductDeleted(datatest.FakeProductID), time.Now())
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "webhook_deliveries" .* ON CONFLICT DO NOTHING RETURNING`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}))
+	mock.ExpectCommit()
+
+	// Act
+	err := repo.AddDeliveries(t.Context(), delivery)
+
+	// Assert
+	require.NoError(t, err)
+	require.NoError(t, repo.AddDeliveries(t.Context()))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_ClaimDueDeliveries(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	txm := repository.NewTxManager(db)
+	repo := repository.NewWebhookRepository(db)
+
+	deliveryID := datatest.FakeProductID
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT d\.\*, s\.url, s\.secret\s+FROM webhook_deliveries d\s+` +
+		`JOIN webhook_subscriptions s ON s\.id = d\.subscription_id\s+` +
+		`WHERE d\.status = 'pending' AND d\.next_attempt_at <= now\(\)\s+` +
+		`ORDER BY d\.next_attempt_at, d\.id\s+LIMIT \$1\s+FOR UPDATE OF d SKIP LOCKED`).
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_type", "payload", "status", "attempts", "url", "secret"}).
+			AddRow(deliveryID, "product.deleted", `{}`, "pending", 2, "https://example.com/hook", "0123456789abcdef"))
+	mock.ExpectExec(`UPDATE "webhook_deliveries" SET "next_attempt_at"=now\(\) \+ \$1 \* interval '1 millisecond' `+
+		`WHERE id IN \(\$2\)`).
+		WithArgs(int64(30000), deliveryID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// Act
+	var got []dto.WebhookDispatch
+	err := txm.WithinTx(t.Context(), func(ctx context.Context) error {
+		var err error
+		got, err = repo.ClaimDueDeliveries(ctx, 10, 30*time.Second)
+		return err
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []dto.WebhookDispatch{{
+		Delivery: entity.WebhookDelivery{
+			ID:        deliveryID,
+			EventType: entity.EventProductDeleted,
+			Payload:   `{}`,
+			Status:    entity.DeliveryPending,
+			Attempts:  2,
+		},
+		URL:    "https://example.com/hook",
+		Secret: "0123456789abcdef",
+	}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookRepo_ListDeliveries(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	db, mock := newMockDB(t)
+	repo := repository.NewWebhookRepository(db)
+
+	mock.ExpectQuery(`SELECT \* FROM "webhook_deliveries" WHERE subscription_id = \$1 AND status = \$2 `+
+		`ORDER BY created_at DESC, id DESC LIMIT \$3`).
+		WithArgs(datatest.FakeProductID, "dead", 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "attempts", "last_error"}).
+			AddRow(datatest.FakeProductID, "dead", 8, "connection refused"))
+
+	// Act
+	deliveries, err := repo.ListDeliveries(t.Context(), dto.ListWebhookDeliveriesQuery{
+		SubscriptionID: datatest.FakeProductID,
+		Status:         entity.DeliveryDead,
+		Limit:          20,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "connection refused", deliveries[0].LastError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,33 @@
+// Package requestid carries the correlation ID of a request through context.Context.
+//
+// The ID is set once by the HTTP middleware and read wherever a log record or an
+// error response must be linked back to the request, including the usecase and
+// repository layers, which only ever see the context.
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type contextKey struct{}
+
+// New generates a new request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// WithID returns a copy of ctx carrying id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or "" when there is none.
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
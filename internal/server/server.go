@@ -0,0 +1,159 @@
+// Package server runs the HTTP delivery layer with a graceful lifecycle.
+//
+// On shutdown the server stops accepting new connections, waits for in-flight
+// requests to finish within a configurable deadline, and only then releases
+// the resources registered with OnClose (e.g. the database pool), so no request
+// ever loses its dependencies halfway through.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+)
+
+// readHeaderTimeout protects the server against slow-loris clients.
+const readHeaderTimeout = 10 * time.Second
+
+// Options tunes the shutdown sequence.
+type Options struct {
+	// ShutdownTimeout bounds how long in-flight requests may take to drain.
+	ShutdownTimeout time.Duration
+
+	// ShutdownDelay keeps serving for a while after the shutdown signal, while
+	// OnShutdown hooks report the instance as not ready. This gives load balancers
+	// time to stop routing new traffic here before the listener is closed.
+	ShutdownDelay time.Duration
+
+	// Logger receives lifecycle events. It defaults to slog.Default.
+	Logger port.Logger
+}
+
+// Server wraps an http.Server with signal-friendly startup and shutdown.
+type Server struct {
+	httpServer *http.Server
+	opts       Options
+	onShutdown []func()
+	closers    []closer
+}
+
+// closer is a named resource released after the server has drained.
+type closer struct {
+	name  string
+	close func() error
+}
+
+// New creates a Server that serves handler on addr.
+func New(addr string, handler http.Handler, opts Options) *Server {
+	if opts.Logger == nil {
+		opts.Logger = logger.FromSlog(slog.Default())
+	}
+
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              addr,
+			Handler:           handler,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+		opts: opts,
+	}
+}
+
+// OnShutdown registers a hook that runs as soon as shutdown starts,
+// before connections stop being accepted (e.g. failing the readiness probe).
+func (s *Server) OnShutdown(fn func()) {
+	s.onShutdown = append(s.onShutdown, fn)
+}
+
+// OnClose registers a resource to release once requests have drained.
+// Resources are closed in reverse registration order, like deferred calls.
+func (s *Server) OnClose(name string, fn func() error) {
+	s.closers = append(s.closers, closer{name: name, close: fn})
+}
+
+// Run listens on the configured address and serves until ctx is cancelled,
+// then shuts down gracefully. See Serve.
+func (s *Server) Run(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", s.httpServer.Addr, err)
+	}
+
+	return s.Serve(ctx, ln)
+}
+
+// Serve accepts connections on ln until ctx is cancelled (typically by SIGINT/SIGTERM)
+// or the server fails. It then drains in-flight requests and closes registered resources.
+// It returns nil after a clean shutdown.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		s.opts.Logger.Info(ctx, "server started", "addr", ln.Addr().String())
+		serveErr <- s.httpServer.Serve(ln)
+	}()
+
+	var runErr error
+	select {
+	case err := <-serveErr:
+		// The server stopped on its own; there is nothing left to drain.
+		if !errors.Is(err, http.ErrServerClosed) {
+			runErr = fmt.Errorf("serve: %w", err)
+		}
+	case <-ctx.Done():
+		runErr = s.shutdown()
+	}
+
+	return errors.Join(runErr, s.close())
+}
+
+// shutdown stops accepting connections and waits for in-flight requests.
+func (s *Server) shutdown() error {
+	// The serve context is already cancelled, so lifecycle logs use a fresh one.
+	logCtx := context.Background()
+
+	s.opts.Logger.Info(logCtx, "shutdown signal received")
+	for _, fn := range s.onShutdown {
+		fn()
+	}
+
+	if s.opts.ShutdownDelay > 0 {
+		s.opts.Logger.Info(logCtx, "reporting not ready before closing listener", "delay", s.opts.ShutdownDelay)
+		time.Sleep(s.opts.ShutdownDelay)
+	}
+
+	s.opts.Logger.Info(logCtx, "draining in-flight requests", "timeout", s.opts.ShutdownTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.opts.ShutdownTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.opts.Logger.Error(logCtx, "drain deadline exceeded, closing remaining connections", "error", err)
+		return errors.Join(fmt.Errorf("drain requests: %w", err), s.httpServer.Close())
+	}
+
+	s.opts.Logger.Info(logCtx, "all in-flight requests completed")
+	return nil
+}
+
+// close releases registered resources in reverse order and reports every failure.
+func (s *Server) close() error {
+	var errs []error
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		c := s.closers[i]
+		if err := c.close(); err != nil {
+			s.opts.Logger.Error(context.Background(), "failed to close resource", "resource", c.name, "error", err)
+			errs = append(errs, fmt.Errorf("close %s: %w", c.name, err))
+			continue
+		}
+		s.opts.Logger.Info(context.Background(), "resource closed", "resource", c.name)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,214 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer serves handler on a random local port and returns its base URL,
+// the function that triggers shutdown, and a channel yielding Serve's result.
+func startServer(t *testing.T, srv *server.Server) (string, context.CancelFunc, <-chan error) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+
+	return "http://" + ln.Addr().String(), cancel, done
+}
+
+func TestServer_InFlightRequestCompletesDuringShutdown(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: a handler that blocks until the test releases it
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, "created")
+	})
+
+	var dbClosed atomic.Bool
+	var closedBeforeResponse atomic.Bool
+	srv := server.New("", handler, server.Options{ShutdownTimeout: 5 * time.Second})
+	srv.OnClose("database", func() error {
+		dbClosed.Store(true)
+		return nil
+	})
+
+	baseURL, shutdown, done := startServer(t, srv)
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	resCh := make(chan result, 1)
+	go func() {
+		resp, err := http.Post(baseURL+"/products", "application/json", nil) //nolint:noctx
+		if err != nil {
+			resCh <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		closedBeforeResponse.Store(dbClosed.Load())
+		resCh <- result{status: resp.StatusCode, body: string(body)}
+	}()
+	<-started
+
+	// Act: shut down while the request is still being processed
+	shutdown()
+
+	// The server must wait for the in-flight request instead of returning.
+	select {
+	case err := <-done:
+		t.Fatalf("server returned before draining: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.False(t, dbClosed.Load(), "database must stay open while requests drain")
+
+	close(release)
+
+	// Assert
+	res := <-resCh
+	require.NoError(t, res.err)
+	assert.Equal(t, http.StatusCreated, res.status)
+	assert.Equal(t, "created", res.body)
+	assert.False(t, closedBeforeResponse.Load())
+
+	require.NoError(t, <-done)
+	assert.True(t, dbClosed.Load(), "database is closed after draining")
+}
+
+func TestServer_RejectsNewConnectionsAfterShutdown(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	srv := server.New("", http.NotFoundHandler(), server.Options{ShutdownTimeout: time.Second})
+	baseURL, shutdown, done := startServer(t, srv)
+
+	// Act
+	shutdown()
+	require.NoError(t, <-done)
+
+	// Assert
+	_, err := http.Get(baseURL) //nolint:noctx
+	assert.Error(t, err)
+}
+
+func TestServer_DrainDeadlineExceeded(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: a request that outlives the drain deadline
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		close(started)
+		<-release
+	})
+
+	var dbClosed atomic.Bool
+	srv := server.New("", handler, server.Options{ShutdownTimeout: 50 * time.Millisecond})
+	srv.OnClose("database", func() error {
+		dbClosed.Store(true)
+		return nil
+	})
+	baseURL, shutdown, done := startServer(t, srv)
+
+	go func() {
+		resp, err := http.Get(baseURL) //nolint:noctx
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+
+	// Act
+	shutdown()
+
+	// Assert: the deadline is reported and resources are still released
+	err := <-done
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, dbClosed.Load())
+}
+
+func TestServer_CloseErrorsAreReported(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	errClose := errors.New("close failed")
+	var order []string
+	srv := server.New("", http.NotFoundHandler(), server.Options{ShutdownTimeout: time.Second})
+	srv.OnClose("database", func() error {
+		order = append(order, "database")
+		return errClose
+	})
+	srv.OnClose("cache", func() error {
+		order = append(order, "cache")
+		return nil
+	})
+	_, shutdown, done := startServer(t, srv)
+
+	// Act
+	shutdown()
+
+	// Assert: resources close in reverse order and failures surface
+	assert.ErrorIs(t, <-done, errClose)
+	assert.Equal(t, []string{"cache", "database"}, order)
+}
+
+func TestServer_ReportsNotReadyBeforeClosingListener(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: readiness flips as soon as shutdown starts, while the listener stays open
+	var shuttingDown atomic.Bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if shuttingDown.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	srv := server.New("", handler, server.Options{
+		ShutdownTimeout: time.Second,
+		ShutdownDelay:   300 * time.Millisecond,
+	})
+	hookCalled := make(chan struct{})
+	srv.OnShutdown(func() {
+		shuttingDown.Store(true)
+		close(hookCalled)
+	})
+	baseURL, shutdown, done := startServer(t, srv)
+
+	// Act
+	shutdown()
+	<-hookCalled
+
+	// Assert: still serving during the delay, but reporting not ready
+	resp, err := http.Get(baseURL + "/readyz") //nolint:noctx
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	require.NoError(t, <-done)
+}
@@ -10,27 +10,45 @@ import (
 	"github.com/DucTran999/go-clean-archx/internal/dto"
 	"github.com/DucTran999/go-clean-archx/internal/entity"
 	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
 )
 
 // productUsecase implements the ProductUsecase interface and handles
 // business logic related to product operations.
 type productUsecase struct {
 	productRepo port.ProductRepository
+	outbox      port.OutboxRepository
+	txManager   port.TxManager
+	logger      port.Logger
 }
 
-// NewProductUsecase returns a productUsecase instance with the given repository.
-func NewProductUsecase(productRepo port.ProductRepository) port.ProductUsecase {
+// NewProductUsecase returns a productUsecase instance with the given repositories.
+// Every write records a domain event in outbox within the same txManager transaction,
+// so an event is published if and only if the change it describes was committed.
+// Records written through logger carry the correlation fields attached to ctx by the caller.
+func NewProductUsecase(
+	productRepo port.ProductRepository, outbox port.OutboxRepository, txManager port.TxManager, logger port.Logger,
+) port.ProductUsecase {
 	return &productUsecase{
 		productRepo: productRepo,
+		outbox:      outbox,
+		txManager:   txManager,
+		logger:      logger,
 	}
 }
 
 // CreateProduct handles the creation of a new product.
 func (uc *productUsecase) CreateProduct(ctx context.Context, input dto.CreateProductInput) (*entity.Product, error) {
+	price, err := resolvePrice(input.Price, "")
+	if err != nil {
+		return nil, fmt.Errorf("product validation failed: %w", err)
+	}
+
 	product := entity.Product{
+		SKU:   entity.NormalizeSKU(input.SKU),
 		Name:  input.Name,
 		Qty:   input.Qty,
-		Price: input.Price,
+		Price: price,
 	}
 
 	// Validate domain rules.
@@ -42,10 +60,350 @@ func (uc *productUsecase) CreateProduct(ctx context.Context, input dto.CreatePro
 		return nil, fmt.Errorf("product validation failed: %w", err)
 	}
 
-	// Persist the new product
-	if err := uc.productRepo.Create(ctx, &product); err != nil {
-		return nil, fmt.Errorf("failed to create product: %w", err)
+	// Persist the new product together with its event.
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.Create(ctx, &product); err != nil {
+			return fmt.Errorf("failed to create product: %w", err)
+		}
+		return uc.recordEvents(ctx, entity.NewProductCreated(&product))
+	})
+	if err != nil {
+		return nil, err
 	}
+	uc.logger.Info(ctx, "product created", "product_id", product.ID)
 
 	return &product, nil
 }
+
+// UpsertProductBySKU creates or replaces the product identified by input.SKU.
+// The repository does both in one atomic statement, so concurrent requests always end
+// with exactly one product carrying that SKU, and checks input.ExpectedVersion in that
+// same statement, so a replace based on a stale read is rejected.
+func (uc *productUsecase) UpsertProductBySKU(ctx context.Context, input dto.UpsertProductInput) (*entity.Product, bool, error) {
+	price, err := resolvePrice(input.Price, "")
+	if err != nil {
+		return nil, false, fmt.Errorf("product validation failed: %w", err)
+	}
+
+	product := entity.Product{
+		SKU:   entity.NormalizeSKU(input.SKU),
+		Name:  input.Name,
+		Qty:   input.Qty,
+		Price: price,
+	}
+
+	// The SKU is optional on other writes but is the key of this one.
+	if product.SKU == "" {
+		return nil, false, fmt.Errorf("product validation failed: %w", &entity.ValidationError{Fields: []entity.FieldError{{
+			Field: "sku", Code: entity.CodeRequired, Message: "sku cannot be empty",
+		}}})
+	}
+	if err := product.IsValid(); err != nil {
+		return nil, false, fmt.Errorf("product validation failed: %w", err)
+	}
+
+	var created bool
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = uc.productRepo.UpsertBySKU(ctx, &product, input.ExpectedVersion)
+		if err != nil {
+			return fmt.Errorf("failed to upsert product: %w", err)
+		}
+
+		event := entity.NewProductUpdated(&product)
+		if created {
+			event = entity.NewProductCreated(&product)
+		}
+		return uc.recordEvents(ctx, event)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	msg := "product updated"
+	if created {
+		msg = "product created"
+	}
+	uc.logger.Info(ctx, msg, "product_id", product.ID, "sku", product.SKU)
+
+	return &product, created, nil
+}
+
+// GetByID returns the product identified by id.
+// entity.ErrProductNotFound is kept in the error chain so the delivery layer can map it.
+func (uc *productUsecase) GetByID(ctx context.Context, query dto.GetProductQuery) (*entity.Product, error) {
+	product, err := uc.productRepo.GetByID(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return product, nil
+}
+
+// List returns a page of products using keyset pagination.
+// One extra row is fetched to find out whether another page exists without a COUNT query.
+func (uc *productUsecase) List(ctx context.Context, query dto.ListProductsQuery) (*dto.ProductPage, error) {
+	query, err := normalizeListQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	fetch := query
+	fetch.Limit = query.Limit + 1
+
+	products, err := uc.productRepo.List(ctx, fetch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	page := &dto.ProductPage{Items: products}
+	if len(products) > query.Limit {
+		page.Items = products[:query.Limit]
+		page.HasMore = true
+
+		last := page.Items[len(page.Items)-1]
+		page.NextCursor = &dto.ProductCursor{
+			SortBy:    query.SortBy,
+			Order:     query.Order,
+			Name:      last.Name,
+			Price:     last.Price.Amount,
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		}
+	}
+
+	return page, nil
+}
+
+// UpdateProduct replaces all mutable fields of a product and re-validates it.
+func (uc *productUsecase) UpdateProduct(ctx context.Context, input dto.UpdateProductInput) (*entity.Product, error) {
+	return uc.changeProduct(ctx, input.ID, input.ExpectedVersion, func(product *entity.Product) error {
+		price, err := resolvePrice(input.Price, product.Price.Currency)
+		if err != nil {
+			return fmt.Errorf("product validation failed: %w", err)
+		}
+
+		if input.SKU != "" {
+			product.SKU = entity.NormalizeSKU(input.SKU)
+		}
+		product.Name = input.Name
+		product.Qty = input.Qty
+		product.Price = price
+
+		return nil
+	})
+}
+
+// PatchProduct applies only the provided fields on top of the stored product
+// and re-validates the merged result.
+func (uc *productUsecase) PatchProduct(ctx context.Context, input dto.PatchProductInput) (*entity.Product, error) {
+	return uc.changeProduct(ctx, input.ID, input.ExpectedVersion, func(product *entity.Product) error {
+		if input.SKU != nil {
+			// An explicit empty SKU removes it.
+			product.SKU = entity.NormalizeSKU(*input.SKU)
+		}
+		if input.Name != nil {
+			product.Name = *input.Name
+		}
+		if input.Qty != nil {
+			product.Qty = *input.Qty
+		}
+		if input.Price != nil {
+			price, err := patchPrice(*input.Price, product.Price)
+			if err != nil {
+				return fmt.Errorf("product validation failed: %w", err)
+			}
+			product.Price = price
+		}
+
+		return nil
+	})
+}
+
+// changeProduct applies change to the stored product and saves it, in one transaction.
+// The product is read with ForUpdate, so the version check compares against the stored
+// row rather than a cached copy, and no other write can land between check and save.
+//
+// The merged product is validated against domain rules before it is persisted, so an
+// update can never store a product that CreateProduct would have rejected.
+func (uc *productUsecase) changeProduct(
+	ctx context.Context, id uuid.UUID, expectedVersion int64, change func(product *entity.Product) error,
+) (*entity.Product, error) {
+	var product *entity.Product
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		product, err = uc.productRepo.GetByID(ctx, dto.GetProductQuery{ID: id, ForUpdate: true})
+		if err != nil {
+			return fmt.Errorf("failed to get product: %w", err)
+		}
+		if err := checkVersion(product, expectedVersion); err != nil {
+			return err
+		}
+		if err := change(product); err != nil {
+			return err
+		}
+		if err := product.IsValid(); err != nil {
+			return fmt.Errorf("product validation failed: %w", err)
+		}
+
+		if err := uc.productRepo.Update(ctx, product); err != nil {
+			return fmt.Errorf("failed to update product: %w", err)
+		}
+		return uc.recordEvents(ctx, entity.NewProductUpdated(product))
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.Info(ctx, "product updated", "product_id", product.ID)
+
+	return product, nil
+}
+
+// checkVersion rejects an update based on a stale read. The repository repeats the
+// check atomically on write, which also catches a change racing this request.
+func checkVersion(product *entity.Product, expected int64) error {
+	if expected != 0 && product.Version != expected {
+		return fmt.Errorf("failed to update product: %w", entity.ErrProductVersionMismatch)
+	}
+
+	return nil
+}
+
+// DeleteProduct soft-deletes a product so it is hidden from reads but kept for reporting.
+func (uc *productUsecase) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.productRepo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete product: %w", err)
+		}
+		return uc.recordEvents(ctx, entity.NewProductDeleted(id))
+	})
+	if err != nil {
+		return err
+	}
+	uc.logger.Info(ctx, "product deleted", "product_id", id)
+
+	return nil
+}
+
+// RestoreProduct brings a soft-deleted product back into the catalog.
+func (uc *productUsecase) RestoreProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	var product *entity.Product
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		product, err = uc.productRepo.Restore(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to restore product: %w", err)
+		}
+		// Consumers see a restored product as changed: it is back with deletedAt cleared.
+		return uc.recordEvents(ctx, entity.NewProductUpdated(product))
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.logger.Info(ctx, "product restored", "product_id", id)
+
+	return product, nil
+}
+
+// recordEvents adds events to the outbox. Called inside WithinTx, they are committed
+// or rolled back together with the change that raised them.
+func (uc *productUsecase) recordEvents(ctx context.Context, events ...entity.Event) error {
+	if err := uc.outbox.Add(ctx, events...); err != nil {
+		return fmt.Errorf("failed to record events: %w", err)
+	}
+
+	return nil
+}
+
+// resolvePrice turns a client price into entity.Money without going through floating point.
+// currentCurrency is kept when input omits a currency; when it is empty too (a new product),
+// entity.DefaultCurrency applies. Malformed amounts are reported as a *entity.ValidationError,
+// like any other invalid field, and a missing amount is left for IsValid to reject.
+func resolvePrice(input dto.PriceInput, currentCurrency string) (entity.Money, error) {
+	currency := input.Currency
+	if currency == "" {
+		currency = currentCurrency
+	}
+	if currency == "" {
+		currency = entity.DefaultCurrency
+	}
+
+	switch {
+	case input.Minor != nil && input.Amount != "":
+		return entity.Money{}, &entity.ValidationError{Fields: []entity.FieldError{{
+			Field: "price", Code: entity.CodeFormat, Message: "price must be given either as a decimal amount or in minor units, not both",
+		}}}
+	case input.Minor != nil:
+		return entity.NewMoney(*input.Minor, currency), nil
+	case input.Amount != "":
+		price, err := entity.ParseMoney(input.Amount, currency)
+		if err != nil {
+			return entity.Money{}, &entity.ValidationError{Fields: []entity.FieldError{entity.MoneyFieldError("price", err)}}
+		}
+		return price, nil
+	default:
+		return entity.NewMoney(0, currency), nil
+	}
+}
+
+// patchPrice applies a partial price update. A currency may only change together
+// with the amount: re-labelling a stored amount would silently change its value.
+func patchPrice(input dto.PriceInput, current entity.Money) (entity.Money, error) {
+	if input.Amount != "" || input.Minor != nil {
+		return resolvePrice(input, current.Currency)
+	}
+	if input.Currency != "" && input.Currency != current.Currency {
+		return entity.Money{}, &entity.ValidationError{Fields: []entity.FieldError{{
+			Field: "price", Code: entity.CodeRequired, Message: "price is required when changing currency",
+		}}}
+	}
+
+	return current, nil
+}
+
+// normalizeListQuery applies listing defaults and rejects queries that cannot be answered.
+// Like IsValid for entities, it guards the usecase regardless of the delivery mechanism.
+func normalizeListQuery(query dto.ListProductsQuery) (dto.ListProductsQuery, error) {
+	if query.Limit <= 0 {
+		query.Limit = dto.DefaultListLimit
+	}
+	if query.Limit > dto.MaxListLimit {
+		return query, fmt.Errorf("%w: limit must not exceed %d", dto.ErrInvalidListQuery, dto.MaxListLimit)
+	}
+
+	if query.SortBy == "" {
+		query.SortBy = dto.SortByCreatedAt
+	}
+	switch query.SortBy {
+	case dto.SortByCreatedAt, dto.SortByName, dto.SortByPrice:
+	default:
+		return query, fmt.Errorf("%w: unsupported sort field %q", dto.ErrInvalidListQuery, query.SortBy)
+	}
+
+	if query.Order == "" {
+		query.Order = dto.SortDesc
+	}
+	if query.Order != dto.SortAsc && query.Order != dto.SortDesc {
+		return query, fmt.Errorf("%w: unsupported sort order %q", dto.ErrInvalidListQuery, query.Order)
+	}
+
+	if (query.MinPrice != nil || query.MaxPrice != nil) && query.Currency == "" {
+		return query, fmt.Errorf("%w: a price range requires a currency", dto.ErrInvalidListQuery)
+	}
+	if query.SortBy == dto.SortByPrice && query.Currency == "" {
+		return query, fmt.Errorf("%w: sorting by price requires a currency", dto.ErrInvalidListQuery)
+	}
+	if query.MinPrice != nil && query.MaxPrice != nil && *query.MinPrice > *query.MaxPrice {
+		return query, fmt.Errorf("%w: min price must not exceed max price", dto.ErrInvalidListQuery)
+	}
+	if query.MinQty != nil && query.MaxQty != nil && *query.MinQty > *query.MaxQty {
+		return query, fmt.Errorf("%w: min qty must not exceed max qty", dto.ErrInvalidListQuery)
+	}
+
+	// A cursor only makes sense for the ordering it was issued for.
+	if query.Cursor != nil && (query.Cursor.SortBy != query.SortBy || query.Cursor.Order != query.Order) {
+		return query, fmt.Errorf("%w: cursor does not match the requested sort", dto.ErrInvalidListQuery)
+	}
+
+	return query, nil
+}
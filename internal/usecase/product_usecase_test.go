@@ -1,52 +1,110 @@
 package usecase_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/DucTran999/go-clean-archx/internal/dto"
 	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
 	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/repository"
 	"github.com/DucTran999/go-clean-archx/internal/usecase"
 	"github.com/DucTran999/go-clean-archx/test/datatest"
 	"github.com/DucTran999/go-clean-archx/test/mockbuilder"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// newProductUsecase wires repo into a usecase whose writes run inline and whose outbox
+// accepts any event; TestProductEvents covers what is recorded.
+func newProductUsecase(t *testing.T, repo port.ProductRepository) port.ProductUsecase {
+	t.Helper()
+	outbox := mockbuilder.NewOutboxRepoBuilder(t).AddAny().Build()
+	return usecase.NewProductUsecase(repo, outbox, mockbuilder.NewTxManagerBuilder(t).Build(), logger.NewNop())
+}
+
 func TestCreateProduct(t *testing.T) {
 	t.Parallel()
+
+	minor := int64(100)
+
 	tests := []struct {
-		name        string
-		input       dto.CreateProductInput
-		expectedErr error
-		setupUT     func(t *testing.T) port.ProductUsecase // set up under test
+		name          string
+		input         dto.CreateProductInput
+		expectedPrice entity.Money
+		expectedErr   error
+		setupUT       func(t *testing.T) port.ProductUsecase // set up under test
 	}{
 		{
-			name:  "success",
-			input: dto.CreateProductInput{Name: "Book", Qty: 5, Price: 20},
+			name:          "success",
+			input:         dto.CreateProductInput{Name: "Book", Qty: 5, Price: dto.PriceInput{Amount: "20"}},
+			expectedPrice: entity.NewMoney(2000, entity.DefaultCurrency),
 			setupUT: func(t *testing.T) port.ProductUsecase {
 				t.Helper()
 				mRepo := mockbuilder.NewProductRepoBuilder(t).CreateProductSuccess().Build()
-				return usecase.NewProductUsecase(mRepo)
+				return newProductUsecase(t, mRepo)
 			},
 			expectedErr: nil,
 		},
 		{
 			name:  "invalid product",
-			input: dto.CreateProductInput{Name: "cool hat", Qty: 10, Price: 0},
+			input: dto.CreateProductInput{Name: "cool hat", Qty: 10, Price: dto.PriceInput{Amount: "0"}},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductInvalid,
+		},
+		{
+			name:          "sku is normalized",
+			input:         dto.CreateProductInput{SKU: " hat-01 ", Name: "Hat", Qty: 1, Price: dto.PriceInput{Amount: "49.50"}},
+			expectedPrice: datatest.FakePrice,
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).CreateProductSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+		},
+		{
+			name:  "sku already taken",
+			input: dto.CreateProductInput{SKU: "HAT-01", Name: "Hat", Qty: 1, Price: dto.PriceInput{Amount: "49.50"}},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).CreateProductSKUTaken().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductSKUTaken,
+		},
+		{
+			name:  "price with more decimals than the currency allows",
+			input: dto.CreateProductInput{Name: "Gum", Qty: 1, Price: dto.PriceInput{Amount: "0.105"}},
 			setupUT: func(t *testing.T) port.ProductUsecase {
 				t.Helper()
 				mRepo := mockbuilder.NewProductRepoBuilder(t).Build()
-				return usecase.NewProductUsecase(mRepo)
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductInvalid,
+		},
+		{
+			name:  "both decimal and minor price sent",
+			input: dto.CreateProductInput{Name: "Gum", Qty: 1, Price: dto.PriceInput{Amount: "1", Minor: &minor}},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).Build()
+				return newProductUsecase(t, mRepo)
 			},
 			expectedErr: entity.ErrProductInvalid,
 		},
 		{
 			name:  "failed cause db error",
-			input: dto.CreateProductInput{Name: "Laptop", Qty: 10, Price: 999.99},
+			input: dto.CreateProductInput{Name: "Laptop", Qty: 10, Price: dto.PriceInput{Amount: "999.99"}},
 			setupUT: func(t *testing.T) port.ProductUsecase {
 				t.Helper()
 				mRepo := mockbuilder.NewProductRepoBuilder(t).CreateProductErrorDB().Build()
-				return usecase.NewProductUsecase(mRepo)
+				return newProductUsecase(t, mRepo)
 			},
 			expectedErr: datatest.ErrUnexpectedDB,
 		},
@@ -69,9 +127,791 @@ func TestCreateProduct(t *testing.T) {
 				assert.NotNil(t, got)
 				assert.Equal(t, tt.input.Name, got.Name)
 				assert.Equal(t, tt.input.Qty, got.Qty)
-				assert.Equal(t, tt.input.Price, got.Price)
+				assert.Equal(t, tt.expectedPrice, got.Price)
+				assert.Equal(t, entity.NormalizeSKU(tt.input.SKU), got.SKU)
+				assert.Equal(t, datatest.FakeProductID, got.ID)
+			}
+		})
+	}
+}
+
+func TestUpsertProductBySKU(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		input           dto.UpsertProductInput
+		expectedCreated bool
+		expectedErr     error
+		setupUT         func(t *testing.T) port.ProductUsecase
+	}{
+		{
+			name:            "created",
+			input:           dto.UpsertProductInput{SKU: "hat-01", Name: "Hat", Qty: 2, Price: dto.PriceInput{Amount: "49.50"}},
+			expectedCreated: true,
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).UpsertBySKUSuccess(true).Build()
+				return newProductUsecase(t, mRepo)
+			},
+		},
+		{
+			name:            "updated in place",
+			input:           dto.UpsertProductInput{SKU: "HAT-01", Name: "Hat", Qty: 2, Price: dto.PriceInput{Amount: "49.50"}},
+			expectedCreated: false,
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).UpsertBySKUSuccess(false).Build()
+				return newProductUsecase(t, mRepo)
+			},
+		},
+		{
+			name:  "blank sku",
+			input: dto.UpsertProductInput{SKU: "  ", Name: "Hat", Qty: 2, Price: dto.PriceInput{Amount: "49.50"}},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				return newProductUsecase(t, mockbuilder.NewProductRepoBuilder(t).Build())
+			},
+			expectedErr: entity.ErrProductInvalid,
+		},
+		{
+			name:  "malformed sku",
+			input: dto.UpsertProductInput{SKU: "HAT/01", Name: "Hat", Qty: 2, Price: dto.PriceInput{Amount: "49.50"}},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				return newProductUsecase(t, mockbuilder.NewProductRepoBuilder(t).Build())
+			},
+			expectedErr: entity.ErrProductInvalid,
+		},
+		{
+			name:  "failed cause db error",
+			input: dto.UpsertProductInput{SKU: "HAT-01", Name: "Hat", Qty: 2, Price: dto.PriceInput{Amount: "49.50"}},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).UpsertBySKUErrorDB().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := tt.setupUT(t)
+
+			got, created, err := uc.UpsertProductBySKU(t.Context(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCreated, created)
+			assert.Equal(t, entity.SKU("HAT-01"), got.SKU)
+			assert.Equal(t, datatest.FakePrice, got.Price)
+			assert.Equal(t, datatest.FakeProductID, got.ID)
+		})
+	}
+}
+
+func TestGetByID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		expectedErr error
+		setupUT     func(t *testing.T) port.ProductUsecase
+	}{
+		{
+			name: "success",
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "product not found",
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDNotFound().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductNotFound,
+		},
+		{
+			name: "failed cause db error",
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDErrorDB().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := tt.setupUT(t)
+
+			got, err := uc.GetByID(t.Context(), dto.GetProductQuery{ID: datatest.FakeProductID})
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, datatest.FakeProductID, got.ID)
+			}
+		})
+	}
+}
+
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	minPrice, maxPrice := int64(5000), int64(1000)
+
+	tests := []struct {
+		name            string
+		query           dto.ListProductsQuery
+		expectedLen     int
+		expectedHasMore bool
+		expectedErr     error
+		setupUT         func(t *testing.T) port.ProductUsecase
+	}{
+		{
+			name:        "last page",
+			query:       dto.ListProductsQuery{Limit: 5},
+			expectedLen: 2,
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).ListSuccess(2).Build()
+				return newProductUsecase(t, mRepo)
+			},
+		},
+		{
+			name:            "more pages available",
+			query:           dto.ListProductsQuery{Limit: 2},
+			expectedLen:     2,
+			expectedHasMore: true,
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).ListSuccess(3).Build()
+				return newProductUsecase(t, mRepo)
+			},
+		},
+		{
+			name:  "defaults are applied",
+			query: dto.ListProductsQuery{},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).ListExpectQuery(dto.ListProductsQuery{
+					Limit:  dto.DefaultListLimit + 1,
+					SortBy: dto.SortByCreatedAt,
+					Order:  dto.SortDesc,
+				}).Build()
+				return newProductUsecase(t, mRepo)
+			},
+		},
+		{
+			name:  "limit too large",
+			query: dto.ListProductsQuery{Limit: dto.MaxListLimit + 1},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				return newProductUsecase(t, mockbuilder.NewProductRepoBuilder(t).Build())
+			},
+			expectedErr: dto.ErrInvalidListQuery,
+		},
+		{
+			name:  "unsupported sort field",
+			query: dto.ListProductsQuery{SortBy: "qty"},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				return newProductUsecase(t, mockbuilder.NewProductRepoBuilder(t).Build())
+			},
+			expectedErr: dto.ErrInvalidListQuery,
+		},
+		{
+			name:  "inverted price range",
+			query: dto.ListProductsQuery{Currency: "USD", MinPrice: &minPrice, MaxPrice: &maxPrice},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				return newProductUsecase(t, mockbuilder.NewProductRepoBuilder(t).Build())
+			},
+			expectedErr: dto.ErrInvalidListQuery,
+		},
+		{
+			name:  "price sort without currency",
+			query: dto.ListProductsQuery{SortBy: dto.SortByPrice},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				return newProductUsecase(t, mockbuilder.NewProductRepoBuilder(t).Build())
+			},
+			expectedErr: dto.ErrInvalidListQuery,
+		},
+		{
+			name: "cursor issued for another sort",
+			query: dto.ListProductsQuery{
+				SortBy: dto.SortByName,
+				Cursor: &dto.ProductCursor{SortBy: dto.SortByPrice, Order: dto.SortDesc, ID: datatest.FakeProductID},
+			},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				return newProductUsecase(t, mockbuilder.NewProductRepoBuilder(t).Build())
+			},
+			expectedErr: dto.ErrInvalidListQuery,
+		},
+		{
+			name: "failed cause db error",
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).ListErrorDB().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := tt.setupUT(t)
+
+			got, err := uc.List(t.Context(), tt.query)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, got.Items, tt.expectedLen)
+			assert.Equal(t, tt.expectedHasMore, got.HasMore)
+			if tt.expectedHasMore {
+				last := got.Items[len(got.Items)-1]
+				assert.Equal(t, last.ID, got.NextCursor.ID)
+				assert.Equal(t, last.CreatedAt, got.NextCursor.CreatedAt)
+			} else {
+				assert.Nil(t, got.NextCursor)
+			}
+		})
+	}
+}
+
+func TestUpdateProduct(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		input         dto.UpdateProductInput
+		expectedPrice entity.Money
+		expectedErr   error
+		setupUT       func(t *testing.T) port.ProductUsecase
+	}{
+		{
+			name:          "success keeps the stored currency",
+			input:         dto.UpdateProductInput{ID: datatest.FakeProductID, Name: "Notebook", Qty: 8, Price: dto.PriceInput{Amount: "12.5"}},
+			expectedPrice: entity.NewMoney(1250, "USD"),
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().UpdateSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "success with another currency",
+			input: dto.UpdateProductInput{
+				ID: datatest.FakeProductID, Name: "Notebook", Qty: 8, Price: dto.PriceInput{Amount: "1500", Currency: "JPY"},
+			},
+			expectedPrice: entity.NewMoney(1500, "JPY"),
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().UpdateSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "success with the current version",
+			input: dto.UpdateProductInput{
+				ID: datatest.FakeProductID, Name: "Notebook", Qty: 8, Price: dto.PriceInput{Amount: "12.5"}, ExpectedVersion: 1,
+			},
+			expectedPrice: entity.NewMoney(1250, "USD"),
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().UpdateSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "stale version",
+			input: dto.UpdateProductInput{
+				ID: datatest.FakeProductID, Name: "Notebook", Qty: 8, Price: dto.PriceInput{Amount: "12.5"}, ExpectedVersion: 2,
+			},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductVersionMismatch,
+		},
+		{
+			name: "modified concurrently",
+			input: dto.UpdateProductInput{
+				ID: datatest.FakeProductID, Name: "Notebook", Qty: 8, Price: dto.PriceInput{Amount: "12.5"}, ExpectedVersion: 1,
+			},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().UpdateVersionMismatch().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductVersionMismatch,
+		},
+		{
+			name:  "product not found",
+			input: dto.UpdateProductInput{ID: datatest.FakeProductID, Name: "Notebook", Qty: 8, Price: dto.PriceInput{Amount: "12.5"}},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDNotFound().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductNotFound,
+		},
+		{
+			name:  "invalid product",
+			input: dto.UpdateProductInput{ID: datatest.FakeProductID, Name: "Notebook", Qty: 8, Price: dto.PriceInput{Amount: "0"}},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductInvalid,
+		},
+		{
+			name:  "failed cause db error",
+			input: dto.UpdateProductInput{ID: datatest.FakeProductID, Name: "Notebook", Qty: 8, Price: dto.PriceInput{Amount: "12.5"}},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().UpdateErrorDB().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := tt.setupUT(t)
+
+			got, err := uc.UpdateProduct(t.Context(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.input.Name, got.Name)
+				assert.Equal(t, tt.input.Qty, got.Qty)
+				assert.Equal(t, tt.expectedPrice, got.Price)
+			}
+		})
+	}
+}
+
+func TestPatchProduct(t *testing.T) {
+	t.Parallel()
+
+	name := "Renamed"
+	zeroQty := 0
+	zeroPrice := dto.PriceInput{Amount: "0"}
+	eurOnly := dto.PriceInput{Currency: "EUR"}
+
+	tests := []struct {
+		name        string
+		input       dto.PatchProductInput
+		expected    entity.Product
+		expectedErr error
+		setupUT     func(t *testing.T) port.ProductUsecase
+	}{
+		{
+			name:     "only name is changed",
+			input:    dto.PatchProductInput{ID: datatest.FakeProductID, Name: &name},
+			expected: entity.Product{Name: "Renamed", Qty: 3, Price: datatest.FakePrice},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().UpdateSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+		},
+		{
+			name:     "explicit zero qty is applied",
+			input:    dto.PatchProductInput{ID: datatest.FakeProductID, Qty: &zeroQty},
+			expected: entity.Product{Name: "Stored Product", Qty: 0, Price: datatest.FakePrice},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().UpdateSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+		},
+		{
+			name:  "merged result is invalid",
+			input: dto.PatchProductInput{ID: datatest.FakeProductID, Price: &zeroPrice},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductInvalid,
+		},
+		{
+			name:  "currency change without a price",
+			input: dto.PatchProductInput{ID: datatest.FakeProductID, Price: &eurOnly},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductInvalid,
+		},
+		{
+			name:  "stale version",
+			input: dto.PatchProductInput{ID: datatest.FakeProductID, Name: &name, ExpectedVersion: 2},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductVersionMismatch,
+		},
+		{
+			name:  "product not found",
+			input: dto.PatchProductInput{ID: datatest.FakeProductID, Name: &name},
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).GetByIDNotFound().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := tt.setupUT(t)
+
+			got, err := uc.PatchProduct(t.Context(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected.Name, got.Name)
+				assert.Equal(t, tt.expected.Qty, got.Qty)
+				assert.Equal(t, tt.expected.Price, got.Price)
+			}
+		})
+	}
+}
+
+func TestDeleteProduct(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		expectedErr error
+		setupUT     func(t *testing.T) port.ProductUsecase
+	}{
+		{
+			name: "success",
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).DeleteSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "product not found",
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).DeleteNotFound().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: entity.ErrProductNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := tt.setupUT(t)
+
+			err := uc.DeleteProduct(t.Context(), datatest.FakeProductID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRestoreProduct(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		expectedErr error
+		setupUT     func(t *testing.T) port.ProductUsecase
+	}{
+		{
+			name: "success",
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).RestoreSuccess().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "failed cause db error",
+			setupUT: func(t *testing.T) port.ProductUsecase {
+				t.Helper()
+				mRepo := mockbuilder.NewProductRepoBuilder(t).RestoreErrorDB().Build()
+				return newProductUsecase(t, mRepo)
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uc := tt.setupUT(t)
+
+			got, err := uc.RestoreProduct(t.Context(), datatest.FakeProductID)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
 				assert.Equal(t, datatest.FakeProductID, got.ID)
 			}
 		})
 	}
 }
+
+func TestProductEvents(t *testing.T) {
+	t.Parallel()
+
+	validInput := dto.CreateProductInput{Name: "Book", Qty: 5, Price: dto.PriceInput{Amount: "20"}}
+	upsertInput := dto.UpsertProductInput{SKU: "BOOK-01", Name: "Book", Qty: 5, Price: dto.PriceInput{Amount: "20"}}
+
+	tests := []struct {
+		name        string
+		setupRepo   func(b *mockbuilder.ProductRepoBuilder)
+		setupOutbox func(b *mockbuilder.OutboxRepoBuilder)
+		act         func(ctx context.Context, uc port.ProductUsecase) error
+		expectedErr error
+	}{
+		{
+			name:        "create records product.created",
+			setupRepo:   func(b *mockbuilder.ProductRepoBuilder) { b.CreateProductSuccess() },
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) { b.AddExpectsEvent(entity.EventProductCreated) },
+			act: func(ctx context.Context, uc port.ProductUsecase) error {
+				_, err := uc.CreateProduct(ctx, validInput)
+				return err
+			},
+		},
+		{
+			name:        "upsert inserting records product.created",
+			setupRepo:   func(b *mockbuilder.ProductRepoBuilder) { b.UpsertBySKUSuccess(true) },
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) { b.AddExpectsEvent(entity.EventProductCreated) },
+			act: func(ctx context.Context, uc port.ProductUsecase) error {
+				_, _, err := uc.UpsertProductBySKU(ctx, upsertInput)
+				return err
+			},
+		},
+		{
+			name:        "upsert replacing records product.updated",
+			setupRepo:   func(b *mockbuilder.ProductRepoBuilder) { b.UpsertBySKUSuccess(false) },
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) { b.AddExpectsEvent(entity.EventProductUpdated) },
+			act: func(ctx context.Context, uc port.ProductUsecase) error {
+				_, _, err := uc.UpsertProductBySKU(ctx, upsertInput)
+				return err
+			},
+		},
+		{
+			name:        "update records product.updated",
+			setupRepo:   func(b *mockbuilder.ProductRepoBuilder) { b.GetByIDSuccess().UpdateSuccess() },
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) { b.AddExpectsEvent(entity.EventProductUpdated) },
+			act: func(ctx context.Context, uc port.ProductUsecase) error {
+				_, err := uc.UpdateProduct(ctx, dto.UpdateProductInput{
+					ID: datatest.FakeProductID, Name: "Book", Qty: 1, Price: dto.PriceInput{Amount: "20"},
+				})
+				return err
+			},
+		},
+		{
+			name:        "delete records product.deleted",
+			setupRepo:   func(b *mockbuilder.ProductRepoBuilder) { b.DeleteSuccess() },
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) { b.AddExpectsEvent(entity.EventProductDeleted) },
+			act: func(ctx context.Context, uc port.ProductUsecase) error {
+				return uc.DeleteProduct(ctx, datatest.FakeProductID)
+			},
+		},
+		{
+			name:        "restore records product.updated",
+			setupRepo:   func(b *mockbuilder.ProductRepoBuilder) { b.RestoreSuccess() },
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) { b.AddExpectsEvent(entity.EventProductUpdated) },
+			act: func(ctx context.Context, uc port.ProductUsecase) error {
+				_, err := uc.RestoreProduct(ctx, datatest.FakeProductID)
+				return err
+			},
+		},
+		{
+			name:        "failed write records nothing",
+			setupRepo:   func(b *mockbuilder.ProductRepoBuilder) { b.CreateProductErrorDB() },
+			setupOutbox: func(*mockbuilder.OutboxRepoBuilder) {},
+			act: func(ctx context.Context, uc port.ProductUsecase) error {
+				_, err := uc.CreateProduct(ctx, validInput)
+				return err
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+		{
+			name:        "failure to record the event fails the write",
+			setupRepo:   func(b *mockbuilder.ProductRepoBuilder) { b.DeleteSuccess() },
+			setupOutbox: func(b *mockbuilder.OutboxRepoBuilder) { b.AddErrorDB() },
+			act: func(ctx context.Context, uc port.ProductUsecase) error {
+				return uc.DeleteProduct(ctx, datatest.FakeProductID)
+			},
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := mockbuilder.NewProductRepoBuilder(t)
+			tt.setupRepo(repo)
+			outbox := mockbuilder.NewOutboxRepoBuilder(t)
+			tt.setupOutbox(outbox)
+			uc := usecase.NewProductUsecase(repo.Build(), outbox.Build(), mockbuilder.NewTxManagerBuilder(t).Build(), logger.NewNop())
+
+			err := tt.act(t.Context(), uc)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestProductUsecase_MemoryStorage runs the usecase against the in-memory repositories
+// instead of mocks, so the flow is checked end to end without Postgres.
+func TestProductUsecase_MemoryStorage(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	outbox := repository.NewMemoryOutboxRepository()
+	uc := usecase.NewProductUsecase(
+		repository.NewMemoryProductRepository(), outbox, repository.NewMemoryTxManager(), logger.NewNop(),
+	)
+	ctx := t.Context()
+
+	// Act & Assert
+	var ids []uuid.UUID
+	for _, name := range []string{"Cup", "Bowl", "Plate"} {
+		product, err := uc.CreateProduct(ctx, dto.CreateProductInput{Name: name, Qty: 1, Price: dto.PriceInput{Amount: "2.50"}})
+		require.NoError(t, err)
+		ids = append(ids, product.ID)
+	}
+
+	_, err := uc.CreateProduct(ctx, dto.CreateProductInput{Name: "Spoon", Qty: 1, Price: dto.PriceInput{Amount: "1", Currency: "usd"}})
+	assert.ErrorIs(t, err, entity.ErrProductInvalid)
+
+	qty := 0
+	patched, err := uc.PatchProduct(ctx, dto.PatchProductInput{ID: ids[0], Qty: &qty, ExpectedVersion: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), patched.Version)
+	_, err = uc.PatchProduct(ctx, dto.PatchProductInput{ID: ids[0], Qty: &qty, ExpectedVersion: 1})
+	assert.ErrorIs(t, err, entity.ErrProductVersionMismatch)
+
+	require.NoError(t, uc.DeleteProduct(ctx, ids[1]))
+
+	first, err := uc.List(ctx, dto.ListProductsQuery{Limit: 1, SortBy: dto.SortByName, Order: dto.SortAsc})
+	require.NoError(t, err)
+	require.True(t, first.HasMore)
+	assert.Equal(t, "Cup", first.Items[0].Name)
+	second, err := uc.List(ctx, dto.ListProductsQuery{Limit: 1, SortBy: dto.SortByName, Order: dto.SortAsc, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	assert.False(t, second.HasMore)
+	assert.Equal(t, "Plate", second.Items[0].Name)
+
+	events, err := outbox.ClaimPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, events, 5, "three creates, a patch and a delete")
+}
+
+func TestProductUsecase_WritesIgnoreStaleCache(t *testing.T) {
+	t.Parallel()
+
+	// Arrange: two instances share the products but each has its own cache, so an
+	// update made by one does not evict the copy cached by the other.
+	products := repository.NewMemoryProductRepository()
+	newInstance := func() port.ProductUsecase {
+		cache, err := repository.NewLRUCache(10)
+		require.NoError(t, err)
+		repo := repository.NewCachedProductRepository(products, cache, repository.ProductCacheOptions{})
+		return usecase.NewProductUsecase(
+			repo, repository.NewMemoryOutboxRepository(), repository.NewMemoryTxManager(), logger.NewNop(),
+		)
+	}
+	first, second := newInstance(), newInstance()
+	ctx := t.Context()
+
+	created, err := first.CreateProduct(ctx, dto.CreateProductInput{Name: "Cup", Qty: 1, Price: dto.PriceInput{Amount: "2"}})
+	require.NoError(t, err)
+	cached, err := second.GetByID(ctx, dto.GetProductQuery{ID: created.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), cached.Version)
+
+	qty := 2
+	_, err = first.PatchProduct(ctx, dto.PatchProductInput{ID: created.ID, Qty: &qty, ExpectedVersion: 1})
+	require.NoError(t, err)
+
+	// Act: the second instance still serves version 1 to readers, but writes check
+	// the stored version.
+	qty = 3
+	patched, err := second.PatchProduct(ctx, dto.PatchProductInput{ID: created.ID, Qty: &qty, ExpectedVersion: 2})
+	require.NoError(t, err)
+	updated, err := second.UpdateProduct(ctx, dto.UpdateProductInput{
+		ID: created.ID, Name: "Mug", Qty: 4, Price: dto.PriceInput{Amount: "2"}, ExpectedVersion: 3,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), patched.Version)
+	assert.Equal(t, int64(4), updated.Version)
+}
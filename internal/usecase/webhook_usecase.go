@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/google/uuid"
+)
+
+// generatedSecretBytes is the entropy of a generated webhook secret (hex-encoded on the wire).
+const generatedSecretBytes = 24
+
+// webhookUsecase implements the WebhookUsecase interface.
+type webhookUsecase struct {
+	webhookRepo port.WebhookRepository
+	logger      port.Logger
+}
+
+// NewWebhookUsecase returns a webhookUsecase instance with the given repository.
+// Deliveries it queues are sent by a webhook.Dispatcher.
+func NewWebhookUsecase(webhookRepo port.WebhookRepository, logger port.Logger) port.WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepo: webhookRepo,
+		logger:      logger,
+	}
+}
+
+// CreateWebhook validates and stores a new, active subscription.
+func (uc *webhookUsecase) CreateWebhook(ctx context.Context, input dto.CreateWebhookInput) (*entity.WebhookSubscription, error) {
+	secret := input.Secret
+	if secret == "" {
+		var err error
+		if secret, err = generateSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	sub := entity.WebhookSubscription{
+		URL:        input.URL,
+		EventTypes: eventTypes(input.EventTypes),
+		Secret:     secret,
+		Active:     true,
+	}
+	if err := sub.IsValid(); err != nil {
+		return nil, fmt.Errorf("webhook validation failed: %w", err)
+	}
+
+	if err := uc.webhookRepo.CreateSubscription(ctx, &sub); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	uc.logger.Info(ctx, "webhook created", "webhook_id", sub.ID)
+
+	return &sub, nil
+}
+
+// GetWebhook retrieves a subscription by its ID.
+func (uc *webhookUsecase) GetWebhook(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	sub, err := uc.webhookRepo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return sub, nil
+}
+
+// ListWebhooks retrieves every subscription.
+func (uc *webhookUsecase) ListWebhooks(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	subs, err := uc.webhookRepo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return subs, nil
+}
+
+// UpdateWebhook replaces the settings of an existing subscription.
+// Deliveries already queued keep being sent to the subscription's current URL.
+func (uc *webhookUsecase) UpdateWebhook(ctx context.Context, input dto.UpdateWebhookInput) (*entity.WebhookSubscription, error) {
+	sub, err := uc.webhookRepo.GetSubscription(ctx, input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	sub.URL = input.URL
+	sub.EventTypes = eventTypes(input.EventTypes)
+	sub.Active = input.Active
+	if input.Secret != "" {
+		sub.Secret = input.Secret
+	}
+	if err := sub.IsValid(); err != nil {
+		return nil, fmt.Errorf("webhook validation failed: %w", err)
+	}
+
+	if err := uc.webhookRepo.UpdateSubscription(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	uc.logger.Info(ctx, "webhook updated", "webhook_id", sub.ID)
+
+	return sub, nil
+}
+
+// DeleteWebhook removes a subscription and its delivery log.
+func (uc *webhookUsecase) DeleteWebhook(ctx context.Context, id uuid.UUID) error {
+	if err := uc.webhookRepo.DeleteSubscription(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	uc.logger.Info(ctx, "webhook deleted", "webhook_id", id)
+
+	return nil
+}
+
+// ListDeliveries returns the latest deliveries of an existing subscription.
+// A missing limit means dto.DefaultDeliveryListLimit; larger ones are capped.
+func (uc *webhookUsecase) ListDeliveries(ctx context.Context, query dto.ListWebhookDeliveriesQuery) ([]entity.WebhookDelivery, error) {
+	// An unknown subscription is a 404, not an empty log.
+	if _, err := uc.webhookRepo.GetSubscription(ctx, query.SubscriptionID); err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = dto.DefaultDeliveryListLimit
+	}
+	query.Limit = min(query.Limit, dto.MaxDeliveryListLimit)
+
+	deliveries, err := uc.webhookRepo.ListDeliveries(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// HandleEvent fans event out into one delivery per subscriber. The repository ignores
+// deliveries it already holds, so the event can safely be handled again after a failure.
+func (uc *webhookUsecase) HandleEvent(ctx context.Context, event entity.Event) error {
+	subs, err := uc.webhookRepo.ListSubscribers(ctx, event.Type)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscribers: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	deliveries := make([]entity.WebhookDelivery, 0, len(subs))
+	for i := range subs {
+		deliveries = append(deliveries, entity.NewWebhookDelivery(&subs[i], event, now))
+	}
+
+	if err := uc.webhookRepo.AddDeliveries(ctx, deliveries...); err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries: %w", err)
+	}
+	uc.logger.Debug(ctx, "webhook deliveries queued", "event_id", event.ID, "event_type", event.Type, "count", len(deliveries))
+
+	return nil
+}
+
+// eventTypes converts the requested event types, dropping duplicates.
+func eventTypes(names []string) entity.EventTypes {
+	types := make(entity.EventTypes, 0, len(names))
+	for _, name := range names {
+		if t := entity.EventType(name); !types.Contains(t) {
+			types = append(types, t)
+		}
+	}
+
+	return types
+}
+
+// generateSecret returns a random secret for a subscription created without one.
+func generateSecret() (string, error) {
+	buf := make([]byte, generatedSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,287 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/usecase"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mockbuilder"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		input         dto.CreateWebhookInput
+		setupRepo     func(b *mockbuilder.WebhookRepoBuilder)
+		expectedTypes entity.EventTypes
+		expectedErr   error
+	}{
+		{
+			name: "success",
+			input: dto.CreateWebhookInput{
+				URL:        "https://partner.example.com/hooks",
+				EventTypes: []string{"product.created", "product.created", "product.deleted"},
+				Secret:     datatest.FakeWebhookSecret,
+			},
+			setupRepo:     func(b *mockbuilder.WebhookRepoBuilder) { b.CreateSubscriptionSuccess() },
+			expectedTypes: entity.EventTypes{entity.EventProductCreated, entity.EventProductDeleted},
+		},
+		{
+			name: "generated secret",
+			input: dto.CreateWebhookInput{
+				URL:        "https://partner.example.com/hooks",
+				EventTypes: []string{"product.updated"},
+			},
+			setupRepo:     func(b *mockbuilder.WebhookRepoBuilder) { b.CreateSubscriptionSuccess() },
+			expectedTypes: entity.EventTypes{entity.EventProductUpdated},
+		},
+		{
+			name: "unknown event type",
+			input: dto.CreateWebhookInput{
+				URL:        "https://partner.example.com/hooks",
+				EventTypes: []string{"order.created"},
+			},
+			setupRepo:   func(*mockbuilder.WebhookRepoBuilder) {},
+			expectedErr: entity.ErrWebhookInvalid,
+		},
+		{
+			name: "database error",
+			input: dto.CreateWebhookInput{
+				URL:        "https://partner.example.com/hooks",
+				EventTypes: []string{"product.updated"},
+			},
+			setupRepo:   func(b *mockbuilder.WebhookRepoBuilder) { b.CreateSubscriptionErrorDB() },
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			b := mockbuilder.NewWebhookRepoBuilder(t)
+			tt.setupRepo(b)
+			uc := usecase.NewWebhookUsecase(b.Build(), logger.NewNop())
+
+			// Act
+			sub, err := uc.CreateWebhook(t.Context(), tt.input)
+
+			// Assert
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, datatest.FakeWebhookID, sub.ID)
+			assert.True(t, sub.Active)
+			assert.Equal(t, tt.expectedTypes, sub.EventTypes)
+			assert.GreaterOrEqual(t, len(sub.Secret), entity.MinWebhookSecretLength)
+			if tt.input.Secret != "" {
+				assert.Equal(t, tt.input.Secret, sub.Secret)
+			}
+		})
+	}
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		input          dto.UpdateWebhookInput
+		setupRepo      func(b *mockbuilder.WebhookRepoBuilder)
+		expectedSecret string
+		expectedErr    error
+	}{
+		{
+			name: "empty secret keeps the current one",
+			input: dto.UpdateWebhookInput{
+				ID:         datatest.FakeWebhookID,
+				URL:        "https://partner.example.com/v2/hooks",
+				EventTypes: []string{"product.deleted"},
+			},
+			setupRepo: func(b *mockbuilder.WebhookRepoBuilder) {
+				b.GetSubscriptionSuccess().UpdateSubscriptionSuccess()
+			},
+			expectedSecret: datatest.FakeWebhookSecret,
+		},
+		{
+			name: "secret rotation",
+			input: dto.UpdateWebhookInput{
+				ID:         datatest.FakeWebhookID,
+				URL:        "https://partner.example.com/hooks",
+				EventTypes: []string{"product.deleted"},
+				Secret:     "another-long-secret",
+				Active:     true,
+			},
+			setupRepo: func(b *mockbuilder.WebhookRepoBuilder) {
+				b.GetSubscriptionSuccess().UpdateSubscriptionSuccess()
+			},
+			expectedSecret: "another-long-secret",
+		},
+		{
+			name: "invalid url",
+			input: dto.UpdateWebhookInput{
+				ID:         datatest.FakeWebhookID,
+				URL:        "partner.example.com",
+				EventTypes: []string{"product.deleted"},
+			},
+			setupRepo:   func(b *mockbuilder.WebhookRepoBuilder) { b.GetSubscriptionSuccess() },
+			expectedErr: entity.ErrWebhookInvalid,
+		},
+		{
+			name:        "not found",
+			input:       dto.UpdateWebhookInput{ID: uuid.New()},
+			setupRepo:   func(b *mockbuilder.WebhookRepoBuilder) { b.GetSubscriptionNotFound() },
+			expectedErr: entity.ErrWebhookNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			b := mockbuilder.NewWebhookRepoBuilder(t)
+			tt.setupRepo(b)
+			uc := usecase.NewWebhookUsecase(b.Build(), logger.NewNop())
+
+			// Act
+			sub, err := uc.UpdateWebhook(t.Context(), tt.input)
+
+			// Assert
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.input.URL, sub.URL)
+			assert.Equal(t, tt.input.Active, sub.Active)
+			assert.Equal(t, tt.expectedSecret, sub.Secret)
+		})
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	t.Parallel()
+
+	ok := usecase.NewWebhookUsecase(mockbuilder.NewWebhookRepoBuilder(t).DeleteSubscriptionSuccess().Build(), logger.NewNop())
+	require.NoError(t, ok.DeleteWebhook(t.Context(), datatest.FakeWebhookID))
+
+	missing := usecase.NewWebhookUsecase(mockbuilder.NewWebhookRepoBuilder(t).DeleteSubscriptionNotFound().Build(), logger.NewNop())
+	require.ErrorIs(t, missing.DeleteWebhook(t.Context(), uuid.New()), entity.ErrWebhookNotFound)
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		query       dto.ListWebhookDeliveriesQuery
+		setupRepo   func(b *mockbuilder.WebhookRepoBuilder)
+		expectedErr error
+	}{
+		{
+			name:  "default limit",
+			query: dto.ListWebhookDeliveriesQuery{SubscriptionID: datatest.FakeWebhookID},
+			setupRepo: func(b *mockbuilder.WebhookRepoBuilder) {
+				b.GetSubscriptionSuccess().ListDeliveriesExpectsLimit(dto.DefaultDeliveryListLimit)
+			},
+		},
+		{
+			name:  "limit is capped",
+			query: dto.ListWebhookDeliveriesQuery{SubscriptionID: datatest.FakeWebhookID, Limit: 10_000},
+			setupRepo: func(b *mockbuilder.WebhookRepoBuilder) {
+				b.GetSubscriptionSuccess().ListDeliveriesExpectsLimit(dto.MaxDeliveryListLimit)
+			},
+		},
+		{
+			name:        "unknown subscription",
+			query:       dto.ListWebhookDeliveriesQuery{SubscriptionID: uuid.New()},
+			setupRepo:   func(b *mockbuilder.WebhookRepoBuilder) { b.GetSubscriptionNotFound() },
+			expectedErr: entity.ErrWebhookNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			b := mockbuilder.NewWebhookRepoBuilder(t)
+			tt.setupRepo(b)
+			uc := usecase.NewWebhookUsecase(b.Build(), logger.NewNop())
+
+			// Act
+			deliveries, err := uc.ListDeliveries(t.Context(), tt.query)
+
+			// Assert
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, deliveries)
+		})
+	}
+}
+
+func TestHandleEvent(t *testing.T) {
+	t.Parallel()
+
+	event := entity.NewProductDeleted(datatest.FakeProductID)
+	other := uuid.New()
+
+	tests := []struct {
+		name        string
+		setupRepo   func(b *mockbuilder.WebhookRepoBuilder)
+		expectedErr error
+	}{
+		{
+			name: "one delivery per subscriber",
+			setupRepo: func(b *mockbuilder.WebhookRepoBuilder) {
+				b.ListSubscribersReturns(datatest.FakeWebhookID, other).
+					AddDeliveriesExpects(event, datatest.FakeWebhookID, other)
+			},
+		},
+		{
+			name:      "no subscriber",
+			setupRepo: func(b *mockbuilder.WebhookRepoBuilder) { b.ListSubscribersReturns() },
+		},
+		{
+			name:        "database error",
+			setupRepo:   func(b *mockbuilder.WebhookRepoBuilder) { b.ListSubscribersErrorDB() },
+			expectedErr: datatest.ErrUnexpectedDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			b := mockbuilder.NewWebhookRepoBuilder(t)
+			tt.setupRepo(b)
+			uc := usecase.NewWebhookUsecase(b.Build(), logger.NewNop())
+
+			// Act
+			err := uc.HandleEvent(t.Context(), event)
+
+			// Assert
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/internal/worker"
+)
+
+// Defaults applied to zero DispatcherOptions fields. With them, a delivery is
+// attempted 10 times over about an hour and a half before it is dead-lettered.
+const (
+	DefaultPollInterval = time.Second
+	DefaultBatchSize    = 20
+	DefaultMinBackoff   = 10 * time.Second
+	DefaultMaxBackoff   = 30 * time.Minute
+	DefaultMaxAttempts  = 10
+	DefaultLease        = 5 * time.Minute
+)
+
+// DispatcherOptions configures a Dispatcher.
+type DispatcherOptions struct {
+	// PollInterval is how long the dispatcher waits before polling again once no delivery is due.
+	PollInterval time.Duration
+
+	// BatchSize bounds how many deliveries are claimed at once. Deliveries of a batch
+	// are sent one after the other: BatchSize times the sender timeout at worst.
+	BatchSize int
+
+	// Lease is how long claimed deliveries are hidden from other dispatchers while they
+	// are being sent. It must outlast sending a whole batch; a delivery whose lease runs
+	// out before its outcome is recorded may be sent twice.
+	Lease time.Duration
+
+	// MinBackoff is the delay before retrying a delivery that failed once. It doubles
+	// with every further failure, up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// MaxAttempts is the number of attempts after which a failing delivery is dead-lettered.
+	MaxAttempts int
+
+	// Logger reports failed deliveries; nil discards them.
+	Logger port.Logger
+}
+
+// Dispatcher sends the due webhook deliveries and records the outcome of every attempt.
+//
+// No transaction is held while partner endpoints are called: a short one claims a batch
+// and leases it by pushing its next attempt forward, another records the outcomes. Every
+// instance of the service can therefore run one. A delivery whose outcome could not be
+// stored is sent again once its lease expires; the Webhook-Id header lets receivers drop
+// such duplicates.
+type Dispatcher struct {
+	txManager   port.TxManager
+	webhookRepo port.WebhookRepository
+	sender      port.WebhookSender
+	backoff     worker.Backoff
+	opts        DispatcherOptions
+}
+
+// NewDispatcher creates a Dispatcher sending the deliveries of webhookRepo through sender.
+func NewDispatcher(
+	txManager port.TxManager, webhookRepo port.WebhookRepository, sender port.WebhookSender, opts DispatcherOptions,
+) *Dispatcher {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = DefaultLease
+	}
+	if opts.Logger == nil {
+		opts.Logger = logger.NewNop()
+	}
+
+	return &Dispatcher{
+		txManager:   txManager,
+		webhookRepo: webhookRepo,
+		sender:      sender,
+		backoff: worker.NewBackoff(opts.MinBackoff, opts.MaxBackoff, worker.Backoff{
+			Min: DefaultMinBackoff,
+			Max: DefaultMaxBackoff,
+		}),
+		opts: opts,
+	}
+}
+
+// Run sends due deliveries until ctx is cancelled; see worker.Poll for when it polls.
+func (d *Dispatcher) Run(ctx context.Context) {
+	worker.Poll(ctx, worker.PollOptions{
+		Interval:     d.opts.PollInterval,
+		BatchSize:    d.opts.BatchSize,
+		Logger:       d.opts.Logger,
+		ErrorMessage: "failed to dispatch webhook deliveries",
+	}, d.DispatchBatch)
+}
+
+// DispatchBatch claims one batch of due deliveries, sends them and records the outcome
+// of every attempt. It returns how many deliveries were claimed, whether or not they succeeded.
+func (d *Dispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	var dispatches []dto.WebhookDispatch
+	err := d.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		dispatches, err = d.webhookRepo.ClaimDueDeliveries(ctx, d.opts.BatchSize, d.opts.Lease)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]entity.WebhookDelivery, 0, len(dispatches))
+	for _, dispatch := range dispatches {
+		deliveries = append(deliveries, d.send(ctx, dispatch))
+	}
+
+	err = d.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		for i := range deliveries {
+			if err := d.webhookRepo.UpdateDelivery(ctx, &deliveries[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return len(dispatches), fmt.Errorf("failed to record webhook deliveries: %w", err)
+	}
+
+	return len(dispatches), nil
+}
+
+// send makes one attempt at dispatch and returns its delivery updated with the outcome.
+func (d *Dispatcher) send(ctx context.Context, dispatch dto.WebhookDispatch) entity.WebhookDelivery {
+	delivery := dispatch.Delivery
+
+	statusCode, err := d.sender.Send(ctx, dispatch)
+	now := time.Now()
+	if err == nil {
+		delivery.Succeed(statusCode, now)
+		return delivery
+	}
+
+	delivery.Fail(statusCode, err.Error(), now, d.backoff.Delay(delivery.Attempts+1), d.opts.MaxAttempts)
+	d.opts.Logger.Warn(ctx, "failed to deliver webhook",
+		"delivery_id", delivery.ID, "subscription_id", delivery.SubscriptionID,
+		"attempts", delivery.Attempts, "status", delivery.Status, "error", err)
+
+	return delivery
+}
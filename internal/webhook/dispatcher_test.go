@@ -0,0 +1,265 @@
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/webhook"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mockbuilder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newReceiver starts a partner endpoint that checks the signature of every request
+// and answers with status. It counts the requests it accepted.
+func newReceiver(t *testing.T, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var accepted atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !webhook.Verify(datatest.FakeWebhookSecret, r.Header.Get(webhook.HeaderTimestamp),
+			r.Header.Get(webhook.HeaderSignature), body, time.Now(), time.Minute) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get(webhook.HeaderID) == "" || r.Header.Get(webhook.HeaderEvent) != string(entity.EventProductDeleted) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		accepted.Add(1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("receiver says hi"))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &accepted
+}
+
+func newDispatch(url, secret string, attempts int) dto.WebhookDispatch {
+	sub := mockbuilder.FakeWebhook()
+	delivery := entity.NewWebhookDelivery(sub, entity.NewProductDeleted(datatest.FakeProductID), time.Now())
+	delivery.ID = datatest.FakeProductID
+	delivery.Attempts = attempts
+
+	return dto.WebhookDispatch{Delivery: delivery, URL: url, Secret: secret}
+}
+
+func TestDispatcher_DispatchBatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		status           int
+		secret           string
+		attempts         int
+		expectedStatus   entity.WebhookDeliveryStatus
+		expectedCode     int
+		expectedAccepted int32
+		expectedRetryIn  time.Duration
+	}{
+		{
+			name:             "signed delivery succeeds",
+			status:           http.StatusNoContent,
+			secret:           datatest.FakeWebhookSecret,
+			expectedStatus:   entity.DeliverySucceeded,
+			expectedCode:     http.StatusNoContent,
+			expectedAccepted: 1,
+		},
+		{
+			name:             "server error is retried with backoff",
+			status:           http.StatusServiceUnavailable,
+			secret:           datatest.FakeWebhookSecret,
+			attempts:         2,
+			expectedStatus:   entity.DeliveryPending,
+			expectedCode:     http.StatusServiceUnavailable,
+			expectedAccepted: 1,
+			expectedRetryIn:  4 * time.Second,
+		},
+		{
+			name:            "rejected signature is retried",
+			status:          http.StatusOK,
+			secret:          "not-the-partner-secret",
+			expectedStatus:  entity.DeliveryPending,
+			expectedCode:    http.StatusUnauthorized,
+			expectedRetryIn: time.Second,
+		},
+		{
+			name:             "last attempt is dead-lettered",
+			status:           http.StatusInternalServerError,
+			secret:           datatest.FakeWebhookSecret,
+			attempts:         4,
+			expectedStatus:   entity.DeliveryDead,
+			expectedCode:     http.StatusInternalServerError,
+			expectedAccepted: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			receiver, accepted := newReceiver(t, tt.status)
+
+			var recorded []entity.WebhookDelivery
+			repo := mockbuilder.NewWebhookRepoBuilder(t).
+				ClaimDueDeliveriesReturns(newDispatch(receiver.URL, tt.secret, tt.attempts)).
+				UpdateDeliveryRecords(&recorded)
+
+			dispatcher := webhook.NewDispatcher(
+				mockbuilder.NewTxManagerBuilder(t).Build(), repo.Build(), newSender(),
+				webhook.DispatcherOptions{MinBackoff: time.Second, MaxBackoff: time.Minute, MaxAttempts: 5},
+			)
+
+			// Act
+			before := time.Now()
+			n, err := dispatcher.DispatchBatch(t.Context())
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+			assert.Equal(t, tt.expectedAccepted, accepted.Load())
+
+			require.Len(t, recorded, 1)
+			delivery := recorded[0]
+			assert.Equal(t, tt.expectedStatus, delivery.Status)
+			assert.Equal(t, tt.attempts+1, delivery.Attempts)
+			assert.Equal(t, tt.expectedCode, delivery.LastStatusCode)
+			if tt.expectedStatus == entity.DeliverySucceeded {
+				assert.Empty(t, delivery.LastError)
+				assert.NotNil(t, delivery.DeliveredAt)
+			} else {
+				assert.Contains(t, delivery.LastError, fmt.Sprintf("answered %d", tt.expectedCode))
+			}
+			if tt.expectedRetryIn > 0 {
+				assert.WithinDuration(t, before.Add(tt.expectedRetryIn), delivery.NextAttemptAt, 500*time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestDispatcher_UnreachableEndpoint(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	receiver, _ := newReceiver(t, http.StatusOK)
+	receiver.Close()
+
+	var recorded []entity.WebhookDelivery
+	repo := mockbuilder.NewWebhookRepoBuilder(t).
+		ClaimDueDeliveriesReturns(newDispatch(receiver.URL, datatest.FakeWebhookSecret, 0)).
+		UpdateDeliveryRecords(&recorded)
+	dispatcher := webhook.NewDispatcher(
+		mockbuilder.NewTxManagerBuilder(t).Build(), repo.Build(), newSender(), webhook.DispatcherOptions{},
+	)
+
+	// Act
+	_, err := dispatcher.DispatchBatch(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	assert.Equal(t, entity.DeliveryPending, recorded[0].Status)
+	assert.Zero(t, recorded[0].LastStatusCode)
+	assert.NotEmpty(t, recorded[0].LastError)
+}
+
+// txSpy runs units of work like the TxManager mock, and tells whether one is running.
+type txSpy struct {
+	open atomic.Bool
+}
+
+func (s *txSpy) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	s.open.Store(true)
+	defer s.open.Store(false)
+
+	return fn(ctx)
+}
+
+func TestDispatcher_SendsOutsideTransaction(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	var tx txSpy
+	var openDuringSend atomic.Bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		openDuringSend.Store(tx.open.Load())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(receiver.Close)
+
+	var recorded []entity.WebhookDelivery
+	repo := mockbuilder.NewWebhookRepoBuilder(t).
+		ClaimDueDeliveriesReturns(newDispatch(receiver.URL, datatest.FakeWebhookSecret, 0)).
+		UpdateDeliveryRecords(&recorded)
+	dispatcher := webhook.NewDispatcher(&tx, repo.Build(), newSender(), webhook.DispatcherOptions{})
+
+	// Act
+	_, err := dispatcher.DispatchBatch(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, openDuringSend.Load(), "a transaction was open while the partner was called")
+	require.Len(t, recorded, 1)
+	assert.Equal(t, entity.DeliverySucceeded, recorded[0].Status)
+}
+
+func TestDispatcher_ClaimFailure(t *testing.T) {
+	t.Parallel()
+
+	repo := mockbuilder.NewWebhookRepoBuilder(t).ClaimDueDeliveriesErrorDB()
+	dispatcher := webhook.NewDispatcher(
+		mockbuilder.NewTxManagerBuilder(t).Build(), repo.Build(), newSender(), webhook.DispatcherOptions{},
+	)
+
+	_, err := dispatcher.DispatchBatch(t.Context())
+
+	require.ErrorIs(t, err, datatest.ErrUnexpectedDB)
+}
+
+func TestDispatcher_RunStopsWithContext(t *testing.T) {
+	t.Parallel()
+
+	receiver, accepted := newReceiver(t, http.StatusOK)
+	var recorded []entity.WebhookDelivery
+	repo := mockbuilder.NewWebhookRepoBuilder(t).
+		ClaimDueDeliveriesReturns(newDispatch(receiver.URL, datatest.FakeWebhookSecret, 0)).
+		UpdateDeliveryRecords(&recorded)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	dispatcher := webhook.NewDispatcher(
+		mockbuilder.NewTxManagerBuilder(t).Build(), repo.Build(), newSender(),
+		webhook.DispatcherOptions{PollInterval: time.Hour},
+	)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dispatcher.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool { return accepted.Load() == 1 }, time.Second, time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("dispatcher did not stop after its context was cancelled")
+	}
+}
+
+// newSender returns a sender allowed to reach the loopback test receivers.
+func newSender() *webhook.HTTPSender {
+	return webhook.NewHTTPSender(webhook.SenderOptions{Timeout: time.Second, AllowPrivateNetworks: true})
+}
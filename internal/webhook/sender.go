@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+)
+
+// DefaultTimeout bounds a delivery attempt when SenderOptions.Timeout is zero.
+const DefaultTimeout = 10 * time.Second
+
+// maxDrainedBody is how much of a response body is read so the connection can be reused.
+const maxDrainedBody = 4 << 10
+
+// ErrBlockedAddress is returned when a webhook endpoint resolves to an address that
+// deliveries may not reach, such as loopback, private or link-local ones.
+var ErrBlockedAddress = errors.New("webhook endpoint resolves to a non-public address")
+
+// SenderOptions configures an HTTPSender.
+type SenderOptions struct {
+	// Timeout bounds a delivery attempt; zero means DefaultTimeout.
+	Timeout time.Duration
+
+	// AllowPrivateNetworks lets deliveries reach loopback, private and link-local
+	// addresses. Anyone able to create a subscription could otherwise make the service
+	// call its own internal endpoints, so only tests and local setups should set it.
+	AllowPrivateNetworks bool
+}
+
+// HTTPSender POSTs signed deliveries over HTTP.
+type HTTPSender struct {
+	client *http.Client
+}
+
+var _ port.WebhookSender = (*HTTPSender)(nil)
+
+// NewHTTPSender creates a sender configured by opts.
+// Redirects are not followed: a partner endpoint must answer by itself. The address
+// is checked after DNS resolution, at connect time, so a host name cannot be used
+// to slip a blocked address past the check; proxies are not used for the same reason.
+func NewHTTPSender(opts SenderOptions) *HTTPSender {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+
+	dialer := &net.Dialer{Timeout: opts.Timeout}
+	if !opts.AllowPrivateNetworks {
+		dialer.Control = rejectNonPublic
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   opts.Timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send POSTs the delivery payload, signed with the subscription secret.
+// Only the status line of a failed attempt is reported: the response body is never
+// kept, since it comes from an endpoint chosen by whoever created the subscription.
+func (s *HTTPSender) Send(ctx context.Context, dispatch dto.WebhookDispatch) (int, error) {
+	body := []byte(dispatch.Delivery.Payload)
+	sentAt := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dispatch.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, dispatch.Delivery.ID.String())
+	req.Header.Set(HeaderEvent, string(dispatch.Delivery.EventType))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(sentAt.Unix(), 10))
+	req.Header.Set(HeaderSignature, Sign(dispatch.Secret, sentAt, body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer res.Body.Close()
+
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxDrainedBody))
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("webhook endpoint answered %s", res.Status)
+	}
+
+	return res.StatusCode, nil
+}
+
+// rejectNonPublic is a net.Dialer Control function refusing connections to addresses
+// that are not publicly routable, e.g. 127.0.0.1, 10.0.0.0/8 or 169.254.169.254.
+func rejectNonPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, ip)
+	}
+
+	return nil
+}
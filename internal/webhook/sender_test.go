@@ -0,0 +1,66 @@
+package webhook_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/webhook"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSender_BlocksNonPublicAddresses(t *testing.T) {
+	t.Parallel()
+
+	receiver, accepted := newReceiver(t, http.StatusOK)
+	port := receiver.URL[strings.LastIndex(receiver.URL, ":"):]
+	// Parallel subtests finish before the cleanups of their parent run.
+	t.Cleanup(func() { assert.Zero(t, accepted.Load(), "no request reached the receiver") })
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "loopback", url: receiver.URL},
+		{name: "localhost", url: "http://localhost" + port},
+		{name: "ipv6 loopback", url: "http://[::1]" + port},
+		{name: "unspecified", url: "http://0.0.0.0" + port},
+		{name: "private", url: "http://10.0.0.1/hook"},
+		{name: "cloud metadata", url: "http://169.254.169.254/latest/meta-data/"},
+		{name: "ipv4-mapped loopback", url: "http://[::ffff:127.0.0.1]" + port},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Arrange
+			sender := webhook.NewHTTPSender(webhook.SenderOptions{})
+
+			// Act
+			code, err := sender.Send(t.Context(), newDispatch(tt.url, datatest.FakeWebhookSecret, 0))
+
+			// Assert
+			require.ErrorIs(t, err, webhook.ErrBlockedAddress)
+			assert.Zero(t, code)
+		})
+	}
+}
+
+func TestHTTPSender_ReportsStatusLineOnly(t *testing.T) {
+	t.Parallel()
+
+	// Arrange
+	receiver, _ := newReceiver(t, http.StatusInternalServerError)
+
+	// Act
+	code, err := newSender().Send(t.Context(), newDispatch(receiver.URL, datatest.FakeWebhookSecret, 0))
+
+	// Assert
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Contains(t, err.Error(), "500 Internal Server Error")
+	assert.NotContains(t, err.Error(), "receiver says hi", "the response body is never kept")
+}
@@ -0,0 +1,49 @@
+package webhook_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	const secret = "0123456789abcdef"
+	body := []byte(`{"id":"1"}`)
+	sentAt := time.Unix(1_700_000_000, 0)
+	ts := strconv.FormatInt(sentAt.Unix(), 10)
+	signature := webhook.Sign(secret, sentAt, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		expected  bool
+	}{
+		{name: "valid", secret: secret, timestamp: ts, signature: signature, body: body, now: sentAt, expected: true},
+		{name: "within tolerance", secret: secret, timestamp: ts, signature: signature, body: body, now: sentAt.Add(4 * time.Minute), expected: true},
+		{name: "replayed too late", secret: secret, timestamp: ts, signature: signature, body: body, now: sentAt.Add(time.Hour)},
+		{name: "tampered body", secret: secret, timestamp: ts, signature: signature, body: []byte(`{"id":"2"}`), now: sentAt},
+		{name: "other secret", secret: "fedcba9876543210", timestamp: ts, signature: signature, body: body, now: sentAt},
+		{name: "other timestamp", secret: secret, timestamp: "1700000001", signature: signature, body: body, now: sentAt},
+		{name: "malformed timestamp", secret: secret, timestamp: "yesterday", signature: signature, body: body, now: sentAt},
+		{name: "unknown version", secret: secret, timestamp: ts, signature: "v0=" + signature[3:], body: body, now: sentAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ok := webhook.Verify(tt.secret, tt.timestamp, tt.signature, tt.body, tt.now, 5*time.Minute)
+
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Package worker holds the building blocks of the background loops that drain
+// database-backed queues, such as the outbox relay and the webhook dispatcher:
+// a polling loop and the exponential backoff between retries of a failed item.
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/port"
+)
+
+// PollOptions configures Poll.
+type PollOptions struct {
+	// Interval is how long Poll waits after a batch that was not full or that failed.
+	Interval time.Duration
+
+	// BatchSize is the size of a full batch, which is followed by the next one right away.
+	BatchSize int
+
+	// Logger reports failed batches with ErrorMessage; it must not be nil.
+	Logger       port.Logger
+	ErrorMessage string
+}
+
+// Poll calls batch until ctx is cancelled. batch handles up to opts.BatchSize items
+// and returns how many it took; a full batch suggests a backlog, so the next one starts
+// without waiting. Errors caused by the cancellation of ctx are not logged.
+func Poll(ctx context.Context, opts PollOptions, batch func(ctx context.Context) (int, error)) {
+	for {
+		n, err := batch(ctx)
+		if err != nil && ctx.Err() == nil {
+			opts.Logger.Error(ctx, opts.ErrorMessage, "error", err)
+		}
+		if err == nil && n == opts.BatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// Backoff is an exponential backoff: Min before the first retry, doubling with
+// every further failure, up to Max.
+type Backoff struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// NewBackoff returns the backoff from minDelay to maxDelay, taking the bounds of
+// defaults for a zero minDelay or a maxDelay below minDelay.
+func NewBackoff(minDelay, maxDelay time.Duration, defaults Backoff) Backoff {
+	if minDelay <= 0 {
+		minDelay = defaults.Min
+	}
+	if maxDelay < minDelay {
+		maxDelay = max(defaults.Max, minDelay)
+	}
+
+	return Backoff{Min: minDelay, Max: maxDelay}
+}
+
+// Delay returns how long to wait before the next attempt of an item that failed attempts times.
+func (b Backoff) Delay(attempts int) time.Duration {
+	delay := b.Min
+	for i := 1; i < attempts && delay < b.Max; i++ {
+		delay *= 2
+	}
+
+	return min(delay, b.Max)
+}
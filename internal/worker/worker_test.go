@@ -0,0 +1,92 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/logger"
+	"github.com/DucTran999/go-clean-archx/internal/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBackoff(t *testing.T) {
+	defaults := worker.Backoff{Min: time.Second, Max: time.Minute}
+
+	tests := []struct {
+		name     string
+		minDelay time.Duration
+		maxDelay time.Duration
+		expected worker.Backoff
+	}{
+		{name: "zero bounds take the defaults", expected: defaults},
+		{
+			name:     "explicit bounds are kept",
+			minDelay: 2 * time.Second, maxDelay: 10 * time.Second,
+			expected: worker.Backoff{Min: 2 * time.Second, Max: 10 * time.Second},
+		},
+		{
+			name:     "max below min is raised",
+			minDelay: 2 * time.Hour, maxDelay: time.Second,
+			expected: worker.Backoff{Min: 2 * time.Hour, Max: 2 * time.Hour},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, worker.NewBackoff(tc.minDelay, tc.maxDelay, defaults))
+		})
+	}
+}
+
+func TestBackoff_Delay(t *testing.T) {
+	backoff := worker.Backoff{Min: time.Second, Max: 10 * time.Second}
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: time.Second},
+		{attempts: 2, expected: 2 * time.Second},
+		{attempts: 4, expected: 8 * time.Second},
+		{attempts: 5, expected: 10 * time.Second},
+		{attempts: 100, expected: 10 * time.Second},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, backoff.Delay(tc.attempts), "attempts=%d", tc.attempts)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	// Arrange: two full batches, then a failing one, then the context is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var sizes []int
+	results := []struct {
+		n   int
+		err error
+	}{{n: 3}, {n: 3}, {err: errors.New("boom")}, {n: 1}}
+
+	// Act
+	start := time.Now()
+	worker.Poll(ctx, worker.PollOptions{
+		Interval:     10 * time.Millisecond,
+		BatchSize:    3,
+		Logger:       logger.NewNop(),
+		ErrorMessage: "failed",
+	}, func(context.Context) (int, error) {
+		res := results[len(sizes)]
+		sizes = append(sizes, res.n)
+		if len(sizes) == len(results) {
+			cancel()
+		}
+		return res.n, res.err
+	})
+
+	// Assert: full batches run back to back, the failure waits one interval.
+	assert.Equal(t, []int{3, 3, 0, 1}, sizes)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+}
@@ -0,0 +1,5 @@
+-- Drop the index first (it depends on the column)
+DROP INDEX IF EXISTS idx_products_deleted_at;
+
+-- Remove the soft delete column
+ALTER TABLE products DROP COLUMN IF EXISTS deleted_at;
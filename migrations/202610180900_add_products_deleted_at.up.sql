@@ -0,0 +1,5 @@
+-- Soft delete: rows are flagged instead of removed so reporting keeps its history
+ALTER TABLE products ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Most reads filter on "deleted_at IS NULL"
+CREATE INDEX idx_products_deleted_at ON products (deleted_at);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_products_price_created_at_id;
+
+DROP INDEX IF EXISTS idx_products_name_created_at_id;
+
+DROP INDEX IF EXISTS idx_products_created_at_id;
@@ -0,0 +1,7 @@
+-- Keyset pagination indexes: each matches an ORDER BY used by product listing,
+-- so fetching any page is an index range scan instead of a sort over the table
+CREATE INDEX idx_products_created_at_id ON products (created_at, id);
+
+CREATE INDEX idx_products_name_created_at_id ON products (name, created_at, id);
+
+CREATE INDEX idx_products_price_created_at_id ON products (price, created_at, id);
@@ -0,0 +1,31 @@
+-- Minor units are converted back to major units with each currency's exponent, mirroring
+-- entity.currencyExponents (12345 JPY stays 12345, 12345 KWD becomes 12.345). The float
+-- column has no currency, so that is lost; codes missing here are refused instead of being
+-- assumed to have 2 decimal places.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM products
+        WHERE currency NOT IN (
+            'JPY', 'KRW', 'VND',
+            'BHD', 'JOD', 'KWD', 'OMR', 'TND',
+            'AUD', 'BRL', 'CAD', 'CHF', 'CNY', 'CZK', 'DKK', 'EUR', 'GBP', 'HKD', 'IDR', 'INR',
+            'MXN', 'MYR', 'NOK', 'NZD', 'PHP', 'PLN', 'SEK', 'SGD', 'THB', 'TWD', 'USD', 'ZAR'
+        )
+    ) THEN
+        RAISE EXCEPTION 'products has prices in a currency without a known exponent';
+    END IF;
+END $$;
+
+ALTER TABLE products
+    ALTER COLUMN price TYPE DOUBLE PRECISION USING price::DOUBLE PRECISION / CASE
+        WHEN currency IN ('JPY', 'KRW', 'VND') THEN 1
+        WHEN currency IN ('BHD', 'JOD', 'KWD', 'OMR', 'TND') THEN 1000
+        WHEN currency IN ('AUD', 'BRL', 'CAD', 'CHF', 'CNY', 'CZK', 'DKK', 'EUR', 'GBP', 'HKD',
+            'IDR', 'INR', 'MXN', 'MYR', 'NOK', 'NZD', 'PHP', 'PLN', 'SEK', 'SGD', 'THB', 'TWD',
+            'USD', 'ZAR') THEN 100
+    END;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_currency_check;
+
+ALTER TABLE products DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,13 @@
+-- Prices become exact integer minor units (cents for USD) with an ISO 4217 currency,
+-- replacing DOUBLE PRECISION, which cannot represent most decimal amounts exactly.
+-- Every existing price was entered in USD, so rows are converted with 2 decimal places.
+ALTER TABLE products ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE products ALTER COLUMN currency DROP DEFAULT;
+
+ALTER TABLE products ADD CONSTRAINT products_currency_check CHECK (currency ~ '^[A-Z]{3}$');
+
+-- Rewrites the table and rebuilds idx_products_price_created_at_id; the existing
+-- CHECK (price > 0) keeps applying to the new type, so sub-cent prices round up to 1 cent.
+ALTER TABLE products
+    ALTER COLUMN price TYPE BIGINT USING GREATEST(ROUND(price::NUMERIC * 100), 1)::BIGINT;
@@ -0,0 +1,6 @@
+-- Drop the index first (it depends on the column)
+DROP INDEX IF EXISTS idx_products_sku;
+
+ALTER TABLE products DROP CONSTRAINT IF EXISTS products_sku_check;
+
+ALTER TABLE products DROP COLUMN IF EXISTS sku;
@@ -0,0 +1,11 @@
+-- SKU is the business identifier clients use to make product writes idempotent.
+-- Products created before this migration have no SKU (NULL), and PostgreSQL treats
+-- NULLs as distinct, so they do not collide on the unique index.
+ALTER TABLE products ADD COLUMN sku VARCHAR(64);
+
+ALTER TABLE products ADD CONSTRAINT products_sku_check CHECK (sku ~ '^[A-Z0-9]([A-Z0-9-]*[A-Z0-9])?$' AND length(sku) >= 3);
+
+-- Deliberately not partial on deleted_at: a soft-deleted product keeps its SKU, so it can
+-- always be restored, and the upsert by SKU revives it instead of creating a twin.
+-- It is also the arbiter index of INSERT ... ON CONFLICT (sku).
+CREATE UNIQUE INDEX idx_products_sku ON products (sku);
@@ -0,0 +1,16 @@
+-- Remembers requests sent with an Idempotency-Key header so retries replay the
+-- original response. status, content_type and body stay NULL while the first
+-- request is in progress; the row then acts as a lock against concurrent duplicates.
+CREATE TABLE idempotency_keys (
+    key TEXT PRIMARY KEY,
+    fingerprint TEXT NOT NULL,
+    status INT,
+    content_type TEXT,
+    body BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+-- Expired rows are overwritten when their key is reused; the index keeps purging
+-- the others cheap (DELETE FROM idempotency_keys WHERE expires_at < now())
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
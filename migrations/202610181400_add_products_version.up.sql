@@ -0,0 +1,3 @@
+-- Optimistic locking: every write increments version, and conditional updates only
+-- apply when the version the client read is still the stored one
+ALTER TABLE products ADD COLUMN version BIGINT NOT NULL DEFAULT 1 CHECK (version > 0);
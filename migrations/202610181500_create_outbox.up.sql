@@ -0,0 +1,17 @@
+-- Transactional outbox: domain events are inserted in the same transaction as the
+-- product change that raised them, then published by the outbox relay. sent_at
+-- stays NULL until the event has been published.
+CREATE TABLE outbox (
+    id UUID PRIMARY KEY,
+    event_type VARCHAR(100) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT,
+    sent_at TIMESTAMPTZ
+);
+
+-- The relay only ever scans unsent events.
+CREATE INDEX idx_outbox_pending ON outbox (occurred_at) WHERE sent_at IS NULL;
@@ -0,0 +1,35 @@
+-- Webhook subscriptions: partner endpoints notified of catalog events. event_types
+-- is a JSON array of event type names, matched with the @> containment operator.
+CREATE TABLE webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    url TEXT NOT NULL,
+    event_types JSONB NOT NULL,
+    secret TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ
+);
+
+-- One row per event and subscription, kept after delivery as a log for debugging.
+-- The unique key makes fanning out the same event twice a no-op.
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_status_code INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMPTZ,
+    CONSTRAINT uq_webhook_deliveries_event UNIQUE (subscription_id, event_id)
+);
+
+-- The dispatcher only ever scans pending deliveries.
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+-- The delivery log lists a subscription's deliveries newest first.
+CREATE INDEX idx_webhook_deliveries_log ON webhook_deliveries (subscription_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- Identifies the request holding an in-progress key. Once a lock expires a retry takes
+-- the row over with a new token, so the original request can no longer complete or
+-- release it. Rows locked before this migration have none and simply expire.
+ALTER TABLE idempotency_keys ADD COLUMN lock_token UUID;
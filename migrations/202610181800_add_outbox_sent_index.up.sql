@@ -0,0 +1,2 @@
+-- The relay purges events once they have been sent for longer than the retention.
+CREATE INDEX idx_outbox_sent ON outbox (sent_at) WHERE sent_at IS NOT NULL;
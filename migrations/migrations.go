@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL schema migrations into the binary, so the migrate
+// subcommand can apply them without the files being deployed next to it.
+package migrations
+
+import "embed"
+
+// FS holds every <version>_<name>.up.sql and .down.sql file of this directory.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -6,7 +6,16 @@ package datatest
 import (
 	"errors"
 
+	"github.com/DucTran999/go-clean-archx/internal/apperror"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE and constraint name of ErrCheckViolation, which clients must never see.
+const (
+	CheckViolationCode       = "23514"
+	CheckViolationConstraint = "products_qty_check"
 )
 
 var (
@@ -14,6 +23,25 @@ var (
 	// It simulates a realistic UUID without relying on random generation.
 	FakeProductID = uuid.MustParse("4e3d9f02-8a7c-4b72-b10f-3fd88e2ecfaa")
 
+	// FakeWebhookID is the fixed identifier of the webhook subscription returned by mocks.
+	FakeWebhookID = uuid.MustParse("9b1c2f4e-5d6a-4e7b-8c9d-0a1b2c3d4e5f")
+
+	// FakeWebhookSecret is a secret long enough to pass webhook validation.
+	FakeWebhookSecret = "s3cr3t-s3cr3t-s3cr3t"
+
 	// ErrUnexpectedDB simulates a generic database error used in test scenarios.
 	ErrUnexpectedDB = errors.New("unexpected database error")
+
+	// ErrCheckViolation is a CHECK constraint violation as translated by the repository:
+	// Invalid, around a PostgreSQL error naming the table and constraint.
+	ErrCheckViolation = apperror.Wrap(apperror.Invalid, &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           CheckViolationCode,
+		Message:        `new row for relation "products" violates check constraint "` + CheckViolationConstraint + `"`,
+		TableName:      "products",
+		ConstraintName: CheckViolationConstraint,
+	}, "value violates a database constraint")
+
+	// FakePrice is the price of stored products returned by mocks: 49.50 USD.
+	FakePrice = entity.NewMoney(4950, "USD")
 )
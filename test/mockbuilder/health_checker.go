@@ -0,0 +1,61 @@
+// Package mockbuilder provides test builders for mocking dependencies,
+// such as repositories and external services.
+// It supports fluent-style setup of mock behaviors to simplify unit test configuration.
+package mockbuilder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+// HealthCheckerBuilder configures HealthChecker mocks for readiness tests.
+type HealthCheckerBuilder struct {
+	instance *mocks.HealthChecker
+}
+
+// NewHealthCheckerBuilder creates a builder for a checker reporting under the given name.
+// Name may be queried any number of times, including never (e.g. while shutting down).
+func NewHealthCheckerBuilder(t *testing.T, name string) *HealthCheckerBuilder {
+	t.Helper()
+	instance := mocks.NewHealthChecker(t)
+	instance.EXPECT().Name().Return(name).Maybe()
+
+	return &HealthCheckerBuilder{
+		instance: instance,
+	}
+}
+
+// Build returns the mocked HealthChecker.
+func (b *HealthCheckerBuilder) Build() port.HealthChecker {
+	return b.instance
+}
+
+// Healthy makes every check succeed.
+func (b *HealthCheckerBuilder) Healthy() *HealthCheckerBuilder {
+	b.instance.EXPECT().Check(mock.Anything).Return(nil)
+
+	return b
+}
+
+// Unhealthy makes every check fail with a simulated database error.
+func (b *HealthCheckerBuilder) Unhealthy() *HealthCheckerBuilder {
+	b.instance.EXPECT().Check(mock.Anything).Return(datatest.ErrUnexpectedDB)
+
+	return b
+}
+
+// Hanging makes the check block until its context is cancelled,
+// simulating a dependency that never answers.
+func (b *HealthCheckerBuilder) Hanging() *HealthCheckerBuilder {
+	b.instance.EXPECT().Check(mock.Anything).RunAndReturn(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	return b
+}
@@ -0,0 +1,124 @@
+package mockbuilder
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+// OutboxRepoBuilder configures OutboxRepository mocks.
+type OutboxRepoBuilder struct {
+	instance *mocks.OutboxRepository
+}
+
+// NewOutboxRepoBuilder creates a builder with a fresh OutboxRepository mock.
+func NewOutboxRepoBuilder(t *testing.T) *OutboxRepoBuilder {
+	t.Helper()
+	return &OutboxRepoBuilder{
+		instance: mocks.NewOutboxRepository(t),
+	}
+}
+
+// Build returns the mocked OutboxRepository.
+func (b *OutboxRepoBuilder) Build() port.OutboxRepository {
+	return b.instance
+}
+
+// AddAny accepts any single event, any number of times, for tests that are not about events.
+func (b *OutboxRepoBuilder) AddAny() *OutboxRepoBuilder {
+	b.instance.EXPECT().Add(mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	return b
+}
+
+// AddExpectsEvent expects exactly one event of the given type about the fake product.
+func (b *OutboxRepoBuilder) AddExpectsEvent(eventType entity.EventType) *OutboxRepoBuilder {
+	b.instance.EXPECT().
+		Add(mock.Anything, mock.MatchedBy(func(e entity.Event) bool {
+			return e.Type == eventType && e.AggregateID == datatest.FakeProductID
+		})).
+		Return(nil).
+		Once()
+
+	return b
+}
+
+// AddErrorDB configures the mock to simulate a database failure while recording an event.
+func (b *OutboxRepoBuilder) AddErrorDB() *OutboxRepoBuilder {
+	b.instance.EXPECT().Add(mock.Anything, mock.Anything).Return(datatest.ErrUnexpectedDB)
+
+	return b
+}
+
+// ClaimPendingReturns makes the next claim return the given messages.
+func (b *OutboxRepoBuilder) ClaimPendingReturns(messages ...dto.OutboxMessage) *OutboxRepoBuilder {
+	b.instance.EXPECT().
+		ClaimPending(mock.Anything, mock.AnythingOfType("int")).
+		Return(slices.Clone(messages), nil).
+		Once()
+
+	return b
+}
+
+// ClaimPendingErrorDB configures the mock to simulate a database failure while claiming events.
+func (b *OutboxRepoBuilder) ClaimPendingErrorDB() *OutboxRepoBuilder {
+	b.instance.EXPECT().
+		ClaimPending(mock.Anything, mock.AnythingOfType("int")).
+		Return(nil, datatest.ErrUnexpectedDB).
+		Once()
+
+	return b
+}
+
+// MarkSentExpects expects the event with the given ID to be marked as sent.
+func (b *OutboxRepoBuilder) MarkSentExpects(event entity.Event) *OutboxRepoBuilder {
+	b.instance.EXPECT().MarkSent(mock.Anything, event.ID).Return(nil).Once()
+
+	return b
+}
+
+// PurgeSentAny accepts any number of purges, finding nothing to delete, for tests
+// running the relay loop.
+func (b *OutboxRepoBuilder) PurgeSentAny() *OutboxRepoBuilder {
+	b.instance.EXPECT().
+		PurgeSent(mock.Anything, mock.AnythingOfType("time.Duration"), mock.AnythingOfType("int")).
+		Return(0, nil).
+		Maybe()
+
+	return b
+}
+
+// PurgeSentExpects expects a purge of the events sent more than retention ago, in
+// batches of limit, and reports n deleted events.
+func (b *OutboxRepoBuilder) PurgeSentExpects(retention time.Duration, limit, n int) *OutboxRepoBuilder {
+	b.instance.EXPECT().PurgeSent(mock.Anything, retention, limit).Return(n, nil).Once()
+
+	return b
+}
+
+// PurgeSentErrorDB configures the mock to simulate a database failure while purging events.
+func (b *OutboxRepoBuilder) PurgeSentErrorDB() *OutboxRepoBuilder {
+	b.instance.EXPECT().
+		PurgeSent(mock.Anything, mock.AnythingOfType("time.Duration"), mock.AnythingOfType("int")).
+		Return(0, datatest.ErrUnexpectedDB).
+		Once()
+
+	return b
+}
+
+// MarkFailedExpects expects the event with the given ID to be retried after retryIn.
+func (b *OutboxRepoBuilder) MarkFailedExpects(event entity.Event, retryIn time.Duration) *OutboxRepoBuilder {
+	b.instance.EXPECT().
+		MarkFailed(mock.Anything, event.ID, retryIn, mock.AnythingOfType("string")).
+		Return(nil).
+		Once()
+
+	return b
+}
@@ -5,6 +5,8 @@ package mockbuilder
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -60,13 +62,89 @@ func (b *ProductUsecaseBuilder) CreateProductSuccess() *ProductUsecaseBuilder {
 		Run(func(_ context.Context, input dto.CreateProductInput) {
 			product.Name = input.Name
 			product.Qty = input.Qty
-			product.Price = input.Price
+			product.Price = datatest.FakePrice
 		}).
 		Return(&product, nil)
 
 	return b
 }
 
+// CreateProductExpectsPrice sets up the mock to succeed only when the input carries the given price,
+// which lets controller tests check how price fields are read from the payload.
+func (b *ProductUsecaseBuilder) CreateProductExpectsPrice(price dto.PriceInput) *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		CreateProduct(mock.Anything, mock.MatchedBy(func(input dto.CreateProductInput) bool {
+			return reflect.DeepEqual(input.Price, price)
+		})).
+		Return(&entity.Product{ID: datatest.FakeProductID, Price: datatest.FakePrice}, nil)
+
+	return b
+}
+
+// CreateProductReturnsSKUTaken sets up the mock to report that the requested SKU already exists.
+func (b *ProductUsecaseBuilder) CreateProductReturnsSKUTaken() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		CreateProduct(mock.Anything, mock.AnythingOfType("dto.CreateProductInput")).
+		Return(nil, entity.ErrProductSKUTaken)
+
+	return b
+}
+
+// UpsertProductBySKUSuccess sets up the mock to echo the input as the stored product,
+// reporting created as the outcome of the upsert. The stored version follows the
+// expected one, so callers can check which precondition was passed down.
+func (b *ProductUsecaseBuilder) UpsertProductBySKUSuccess(created bool) *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		UpsertProductBySKU(mock.Anything, mock.AnythingOfType("dto.UpsertProductInput")).
+		RunAndReturn(func(_ context.Context, input dto.UpsertProductInput) (*entity.Product, bool, error) {
+			version := int64(1)
+			if input.ExpectedVersion != nil {
+				version = *input.ExpectedVersion + 1
+			}
+			return &entity.Product{
+				ID:      datatest.FakeProductID,
+				SKU:     entity.NormalizeSKU(input.SKU),
+				Name:    input.Name,
+				Qty:     input.Qty,
+				Price:   datatest.FakePrice,
+				Version: version,
+			}, created, nil
+		})
+
+	return b
+}
+
+// UpsertProductBySKUReturnsInvalid sets up the mock to return a domain validation error.
+func (b *ProductUsecaseBuilder) UpsertProductBySKUReturnsInvalid() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		UpsertProductBySKU(mock.Anything, mock.AnythingOfType("dto.UpsertProductInput")).
+		Return(nil, false, entity.ErrProductInvalid)
+
+	return b
+}
+
+// UpsertProductBySKUReturnsVersionRequired configures the mock to report that the SKU
+// exists and the request did not say which version it replaces.
+func (b *ProductUsecaseBuilder) UpsertProductBySKUReturnsVersionRequired() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		UpsertProductBySKU(mock.Anything, mock.MatchedBy(func(input dto.UpsertProductInput) bool {
+			return input.ExpectedVersion == nil
+		})).
+		Return(nil, false, entity.ErrProductVersionRequired)
+
+	return b
+}
+
+// UpsertProductBySKUReturnsVersionMismatch configures the mock to report that the product
+// has been modified since the client read it.
+func (b *ProductUsecaseBuilder) UpsertProductBySKUReturnsVersionMismatch() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		UpsertProductBySKU(mock.Anything, mock.AnythingOfType("dto.UpsertProductInput")).
+		Return(nil, false, fmt.Errorf("failed to upsert product: %w", entity.ErrProductVersionMismatch))
+
+	return b
+}
+
 // CreateProductReturnErrDB configures the mock to simulate a database failure during product creation.
 func (b *ProductUsecaseBuilder) CreateProductReturnErrDB() *ProductUsecaseBuilder {
 	b.instance.EXPECT().
@@ -75,3 +153,214 @@ func (b *ProductUsecaseBuilder) CreateProductReturnErrDB() *ProductUsecaseBuilde
 
 	return b
 }
+
+// CreateProductReturnsCheckViolation configures the mock to fail as the repository does
+// when the database rejects the product with a CHECK constraint.
+func (b *ProductUsecaseBuilder) CreateProductReturnsCheckViolation() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		CreateProduct(mock.Anything, mock.AnythingOfType("dto.CreateProductInput")).
+		Return(nil, fmt.Errorf("failed to create product: %w", datatest.ErrCheckViolation))
+
+	return b
+}
+
+// GetByIDSuccess sets up the mock to return a product carrying the fixed fake ID.
+func (b *ProductUsecaseBuilder) GetByIDSuccess() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		GetByID(mock.Anything, mock.AnythingOfType("dto.GetProductQuery")).
+		Return(&entity.Product{
+			ID:        datatest.FakeProductID,
+			Name:      "Stored Product",
+			Qty:       3,
+			Price:     datatest.FakePrice,
+			CreatedAt: time.Now(),
+			Version:   1,
+		}, nil)
+
+	return b
+}
+
+// GetByIDNotFound configures the mock to report that the requested product does not exist.
+func (b *ProductUsecaseBuilder) GetByIDNotFound() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		GetByID(mock.Anything, mock.AnythingOfType("dto.GetProductQuery")).
+		Return(nil, entity.ErrProductNotFound)
+
+	return b
+}
+
+// GetByIDReturnErrDB configures the mock to simulate a database failure while fetching a product.
+func (b *ProductUsecaseBuilder) GetByIDReturnErrDB() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		GetByID(mock.Anything, mock.AnythingOfType("dto.GetProductQuery")).
+		Return(nil, datatest.ErrUnexpectedDB)
+
+	return b
+}
+
+// GetByIDPanics configures the mock to panic while fetching a product, to exercise recovery.
+func (b *ProductUsecaseBuilder) GetByIDPanics() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		GetByID(mock.Anything, mock.AnythingOfType("dto.GetProductQuery")).
+		RunAndReturn(func(context.Context, dto.GetProductQuery) (*entity.Product, error) {
+			panic("unexpected nil pointer")
+		})
+
+	return b
+}
+
+// ListSuccess sets up the mock to return a single product page that has a next page.
+func (b *ProductUsecaseBuilder) ListSuccess() *ProductUsecaseBuilder {
+	createdAt := time.Now()
+
+	b.instance.EXPECT().
+		List(mock.Anything, mock.AnythingOfType("dto.ListProductsQuery")).
+		Return(&dto.ProductPage{
+			Items: []entity.Product{
+				{ID: datatest.FakeProductID, Name: "Stored Product", Qty: 3, Price: datatest.FakePrice, CreatedAt: createdAt},
+			},
+			NextCursor: &dto.ProductCursor{
+				SortBy:    dto.SortByCreatedAt,
+				Order:     dto.SortDesc,
+				CreatedAt: createdAt,
+				ID:        datatest.FakeProductID,
+			},
+			HasMore: true,
+		}, nil)
+
+	return b
+}
+
+// ListReturnsInvalidQuery configures the mock to reject the list query.
+func (b *ProductUsecaseBuilder) ListReturnsInvalidQuery() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		List(mock.Anything, mock.AnythingOfType("dto.ListProductsQuery")).
+		Return(nil, dto.ErrInvalidListQuery)
+
+	return b
+}
+
+// ListExpectsCurrency expects a listing in the given currency and returns an empty page.
+func (b *ProductUsecaseBuilder) ListExpectsCurrency(currency string) *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		List(mock.Anything, mock.MatchedBy(func(q dto.ListProductsQuery) bool {
+			return q.Currency == currency
+		})).
+		Return(&dto.ProductPage{Items: []entity.Product{}}, nil).
+		Once()
+
+	return b
+}
+
+// ListReturnErrDB configures the mock to simulate a database failure while listing products.
+func (b *ProductUsecaseBuilder) ListReturnErrDB() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		List(mock.Anything, mock.AnythingOfType("dto.ListProductsQuery")).
+		Return(nil, datatest.ErrUnexpectedDB)
+
+	return b
+}
+
+// UpdateProductSuccess sets up the mock to simulate a successful full update,
+// echoing the input back as the stored product at the version after the expected one.
+func (b *ProductUsecaseBuilder) UpdateProductSuccess() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		UpdateProduct(mock.Anything, mock.AnythingOfType("dto.UpdateProductInput")).
+		RunAndReturn(func(_ context.Context, input dto.UpdateProductInput) (*entity.Product, error) {
+			return &entity.Product{
+				ID:      input.ID,
+				Name:    input.Name,
+				Qty:     input.Qty,
+				Price:   datatest.FakePrice,
+				Version: input.ExpectedVersion + 1,
+			}, nil
+		})
+
+	return b
+}
+
+// UpdateProductReturnsInvalidPrice sets up the mock to return a domain validation error on full update.
+func (b *ProductUsecaseBuilder) UpdateProductReturnsInvalidPrice() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		UpdateProduct(mock.Anything, mock.AnythingOfType("dto.UpdateProductInput")).
+		Return(nil, fmt.Errorf("product validation failed: %w", &entity.ValidationError{
+			Fields: []entity.FieldError{
+				{Field: "price", Code: entity.CodePositive, Message: "price must be greater than zero"},
+			},
+		}))
+
+	return b
+}
+
+// UpdateProductNotFound configures the mock to report that the product to update does not exist.
+func (b *ProductUsecaseBuilder) UpdateProductNotFound() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		UpdateProduct(mock.Anything, mock.AnythingOfType("dto.UpdateProductInput")).
+		Return(nil, entity.ErrProductNotFound)
+
+	return b
+}
+
+// UpdateProductReturnsVersionMismatch configures the mock to report that the product
+// has been modified since the client read it.
+func (b *ProductUsecaseBuilder) UpdateProductReturnsVersionMismatch() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		UpdateProduct(mock.Anything, mock.AnythingOfType("dto.UpdateProductInput")).
+		Return(nil, entity.ErrProductVersionMismatch)
+
+	return b
+}
+
+// PatchProductSuccess sets up the mock to simulate a successful partial update.
+func (b *ProductUsecaseBuilder) PatchProductSuccess() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		PatchProduct(mock.Anything, mock.AnythingOfType("dto.PatchProductInput")).
+		Return(&entity.Product{ID: datatest.FakeProductID, Name: "Stored Product", Qty: 0, Price: datatest.FakePrice, Version: 2}, nil)
+
+	return b
+}
+
+// PatchProductReturnErrDB configures the mock to simulate a database failure during partial update.
+func (b *ProductUsecaseBuilder) PatchProductReturnErrDB() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		PatchProduct(mock.Anything, mock.AnythingOfType("dto.PatchProductInput")).
+		Return(nil, datatest.ErrUnexpectedDB)
+
+	return b
+}
+
+// DeleteProductSuccess sets up the mock to simulate a successful soft delete.
+func (b *ProductUsecaseBuilder) DeleteProductSuccess() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		DeleteProduct(mock.Anything, mock.AnythingOfType("uuid.UUID")).
+		Return(nil)
+
+	return b
+}
+
+// DeleteProductNotFound configures the mock to report that the product to delete does not exist.
+func (b *ProductUsecaseBuilder) DeleteProductNotFound() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		DeleteProduct(mock.Anything, mock.AnythingOfType("uuid.UUID")).
+		Return(entity.ErrProductNotFound)
+
+	return b
+}
+
+// RestoreProductSuccess sets up the mock to return the restored product.
+func (b *ProductUsecaseBuilder) RestoreProductSuccess() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		RestoreProduct(mock.Anything, mock.AnythingOfType("uuid.UUID")).
+		Return(&entity.Product{ID: datatest.FakeProductID, Name: "Stored Product", Qty: 3, Price: datatest.FakePrice, Version: 2}, nil)
+
+	return b
+}
+
+// RestoreProductNotFound configures the mock to report that no soft-deleted product matches.
+func (b *ProductUsecaseBuilder) RestoreProductNotFound() *ProductUsecaseBuilder {
+	b.instance.EXPECT().
+		RestoreProduct(mock.Anything, mock.AnythingOfType("uuid.UUID")).
+		Return(nil, entity.ErrProductNotFound)
+
+	return b
+}
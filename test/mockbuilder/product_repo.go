@@ -5,12 +5,16 @@ package mockbuilder
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/DucTran999/go-clean-archx/internal/dto"
 	"github.com/DucTran999/go-clean-archx/internal/entity"
 	"github.com/DucTran999/go-clean-archx/internal/port"
 	"github.com/DucTran999/go-clean-archx/test/datatest"
 	"github.com/DucTran999/go-clean-archx/test/mocks"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -47,6 +51,37 @@ func (b *ProductRepoBuilder) CreateProductSuccess() *ProductRepoBuilder {
 	return b
 }
 
+// CreateProductSKUTaken configures the mock to report that the product's SKU already exists.
+func (b *ProductRepoBuilder) CreateProductSKUTaken() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		Create(mock.Anything, mock.AnythingOfType("*entity.Product")).
+		Return(entity.ErrProductSKUTaken)
+
+	return b
+}
+
+// UpsertBySKUSuccess sets up the mock to store the product under the fixed fake ID,
+// reporting created as the outcome of the upsert.
+func (b *ProductRepoBuilder) UpsertBySKUSuccess(created bool) *ProductRepoBuilder {
+	b.instance.EXPECT().
+		UpsertBySKU(mock.Anything, mock.AnythingOfType("*entity.Product"), mock.Anything).
+		Run(func(_ context.Context, product *entity.Product, _ *int64) {
+			product.ID = datatest.FakeProductID
+		}).
+		Return(created, nil)
+
+	return b
+}
+
+// UpsertBySKUErrorDB configures the mock to simulate a database failure during an upsert.
+func (b *ProductRepoBuilder) UpsertBySKUErrorDB() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		UpsertBySKU(mock.Anything, mock.AnythingOfType("*entity.Product"), mock.Anything).
+		Return(false, datatest.ErrUnexpectedDB)
+
+	return b
+}
+
 // CreateProductErrorDB configures the mock to simulate a database failure during product creation.
 func (b *ProductRepoBuilder) CreateProductErrorDB() *ProductRepoBuilder {
 	b.instance.EXPECT().
@@ -55,3 +90,172 @@ func (b *ProductRepoBuilder) CreateProductErrorDB() *ProductRepoBuilder {
 
 	return b
 }
+
+// GetByIDSuccess sets up the mock to return a stored product carrying the fixed fake ID.
+func (b *ProductRepoBuilder) GetByIDSuccess() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		GetByID(mock.Anything, mock.AnythingOfType("dto.GetProductQuery")).
+		Return(&entity.Product{
+			ID:      datatest.FakeProductID,
+			Name:    "Stored Product",
+			Qty:     3,
+			Price:   datatest.FakePrice,
+			Version: 1,
+		}, nil)
+
+	return b
+}
+
+// GetByIDSuccessOnce is GetByIDSuccess for a single call; a second one fails the test.
+// Useful to assert that later reads are served by a cache.
+func (b *ProductRepoBuilder) GetByIDSuccessOnce() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		GetByID(mock.Anything, mock.AnythingOfType("dto.GetProductQuery")).
+		Return(&entity.Product{
+			ID:      datatest.FakeProductID,
+			Name:    "Stored Product",
+			Qty:     3,
+			Price:   datatest.FakePrice,
+			Version: 1,
+		}, nil).
+		Once()
+
+	return b
+}
+
+// GetByIDBlocksUntil is GetByIDSuccessOnce with a call that only returns once release fires,
+// which keeps concurrent readers waiting on the same load.
+func (b *ProductRepoBuilder) GetByIDBlocksUntil(release <-chan time.Time) *ProductRepoBuilder {
+	b.instance.EXPECT().
+		GetByID(mock.Anything, mock.AnythingOfType("dto.GetProductQuery")).
+		Return(&entity.Product{ID: datatest.FakeProductID, Name: "Stored Product", Price: datatest.FakePrice, Version: 1}, nil).
+		WaitUntil(release).
+		Once()
+
+	return b
+}
+
+// GetByIDNotFound configures the mock to report that the requested product does not exist.
+func (b *ProductRepoBuilder) GetByIDNotFound() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		GetByID(mock.Anything, mock.AnythingOfType("dto.GetProductQuery")).
+		Return(nil, entity.ErrProductNotFound)
+
+	return b
+}
+
+// GetByIDErrorDB configures the mock to simulate a database failure while fetching a product.
+func (b *ProductRepoBuilder) GetByIDErrorDB() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		GetByID(mock.Anything, mock.AnythingOfType("dto.GetProductQuery")).
+		Return(nil, datatest.ErrUnexpectedDB)
+
+	return b
+}
+
+// ListSuccess sets up the mock to return the given number of stored products,
+// regardless of the requested limit. The last product carries the fixed fake ID.
+func (b *ProductRepoBuilder) ListSuccess(count int) *ProductRepoBuilder {
+	products := make([]entity.Product, count)
+	for i := range products {
+		products[i] = entity.Product{
+			ID:        uuid.New(),
+			Name:      fmt.Sprintf("Stored Product %d", i),
+			Qty:       3,
+			Price:     datatest.FakePrice,
+			CreatedAt: time.Now().Add(-time.Duration(i) * time.Minute),
+		}
+	}
+	if count > 0 {
+		products[count-1].ID = datatest.FakeProductID
+	}
+
+	b.instance.EXPECT().
+		List(mock.Anything, mock.AnythingOfType("dto.ListProductsQuery")).
+		Return(products, nil)
+
+	return b
+}
+
+// ListErrorDB configures the mock to simulate a database failure while listing products.
+func (b *ProductRepoBuilder) ListErrorDB() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		List(mock.Anything, mock.AnythingOfType("dto.ListProductsQuery")).
+		Return(nil, datatest.ErrUnexpectedDB)
+
+	return b
+}
+
+// UpdateSuccess sets up the mock to simulate a successful product update.
+func (b *ProductRepoBuilder) UpdateSuccess() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		Update(mock.Anything, mock.AnythingOfType("*entity.Product")).
+		Return(nil)
+
+	return b
+}
+
+// UpdateErrorDB configures the mock to simulate a database failure during product update.
+func (b *ProductRepoBuilder) UpdateErrorDB() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		Update(mock.Anything, mock.AnythingOfType("*entity.Product")).
+		Return(datatest.ErrUnexpectedDB)
+
+	return b
+}
+
+// UpdateVersionMismatch configures the mock to report that the product was modified
+// by another request between the read and the write.
+func (b *ProductRepoBuilder) UpdateVersionMismatch() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		Update(mock.Anything, mock.AnythingOfType("*entity.Product")).
+		Return(entity.ErrProductVersionMismatch)
+
+	return b
+}
+
+// DeleteSuccess sets up the mock to simulate a successful soft delete.
+func (b *ProductRepoBuilder) DeleteSuccess() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		Delete(mock.Anything, mock.AnythingOfType("uuid.UUID")).
+		Return(nil)
+
+	return b
+}
+
+// DeleteNotFound configures the mock to report that no live product matches the ID.
+func (b *ProductRepoBuilder) DeleteNotFound() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		Delete(mock.Anything, mock.AnythingOfType("uuid.UUID")).
+		Return(entity.ErrProductNotFound)
+
+	return b
+}
+
+// RestoreSuccess sets up the mock to return the restored product.
+func (b *ProductRepoBuilder) RestoreSuccess() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		Restore(mock.Anything, mock.AnythingOfType("uuid.UUID")).
+		Return(&entity.Product{ID: datatest.FakeProductID, Name: "Stored Product", Qty: 3, Price: datatest.FakePrice, Version: 2}, nil)
+
+	return b
+}
+
+// RestoreErrorDB configures the mock to simulate a database failure during restore.
+func (b *ProductRepoBuilder) RestoreErrorDB() *ProductRepoBuilder {
+	b.instance.EXPECT().
+		Restore(mock.Anything, mock.AnythingOfType("uuid.UUID")).
+		Return(nil, datatest.ErrUnexpectedDB)
+
+	return b
+}
+
+// ListExpectQuery sets up the mock to only accept exactly the given query
+// and return no products. Useful to assert the defaults applied by the usecase.
+func (b *ProductRepoBuilder) ListExpectQuery(expected dto.ListProductsQuery) *ProductRepoBuilder {
+	b.instance.EXPECT().
+		List(mock.Anything, expected).
+		Return([]entity.Product{}, nil)
+
+	return b
+}
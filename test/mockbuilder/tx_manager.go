@@ -0,0 +1,38 @@
+package mockbuilder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/test/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+// TxManagerBuilder configures TxManager mocks.
+type TxManagerBuilder struct {
+	instance *mocks.TxManager
+}
+
+// NewTxManagerBuilder creates a builder whose WithinTx simply runs the unit of work
+// and returns its error, any number of times. Whether the work is committed is the
+// business of repository tests; usecase tests only see what fn returns.
+func NewTxManagerBuilder(t *testing.T) *TxManagerBuilder {
+	t.Helper()
+	instance := mocks.NewTxManager(t)
+	instance.EXPECT().
+		WithinTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+
+	return &TxManagerBuilder{
+		instance: instance,
+	}
+}
+
+// Build returns the mocked TxManager.
+func (b *TxManagerBuilder) Build() port.TxManager {
+	return b.instance
+}
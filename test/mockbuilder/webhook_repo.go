@@ -0,0 +1,202 @@
+package mockbuilder
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// WebhookRepoBuilder configures WebhookRepository mocks.
+type WebhookRepoBuilder struct {
+	instance *mocks.WebhookRepository
+}
+
+// NewWebhookRepoBuilder creates a builder with a fresh WebhookRepository mock.
+func NewWebhookRepoBuilder(t *testing.T) *WebhookRepoBuilder {
+	t.Helper()
+	return &WebhookRepoBuilder{
+		instance: mocks.NewWebhookRepository(t),
+	}
+}
+
+// Build returns the mocked WebhookRepository.
+func (b *WebhookRepoBuilder) Build() port.WebhookRepository {
+	return b.instance
+}
+
+// FakeWebhook returns the stored subscription used by webhook mocks.
+func FakeWebhook() *entity.WebhookSubscription {
+	return &entity.WebhookSubscription{
+		ID:         datatest.FakeWebhookID,
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: entity.EventTypes{entity.EventProductCreated, entity.EventProductDeleted},
+		Secret:     datatest.FakeWebhookSecret,
+		Active:     true,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// CreateSubscriptionSuccess simulates storing a subscription, assigning it the fake webhook ID.
+func (b *WebhookRepoBuilder) CreateSubscriptionSuccess() *WebhookRepoBuilder {
+	b.instance.EXPECT().
+		CreateSubscription(mock.Anything, mock.AnythingOfType("*entity.WebhookSubscription")).
+		Run(func(_ context.Context, sub *entity.WebhookSubscription) {
+			sub.ID = datatest.FakeWebhookID
+			sub.CreatedAt = time.Now()
+		}).
+		Return(nil).
+		Once()
+
+	return b
+}
+
+// CreateSubscriptionErrorDB simulates a database failure while storing a subscription.
+func (b *WebhookRepoBuilder) CreateSubscriptionErrorDB() *WebhookRepoBuilder {
+	b.instance.EXPECT().
+		CreateSubscription(mock.Anything, mock.AnythingOfType("*entity.WebhookSubscription")).
+		Return(datatest.ErrUnexpectedDB).
+		Once()
+
+	return b
+}
+
+// GetSubscriptionSuccess returns FakeWebhook for the fake webhook ID.
+func (b *WebhookRepoBuilder) GetSubscriptionSuccess() *WebhookRepoBuilder {
+	b.instance.EXPECT().
+		GetSubscription(mock.Anything, datatest.FakeWebhookID).
+		Return(FakeWebhook(), nil).
+		Once()
+
+	return b
+}
+
+// GetSubscriptionNotFound simulates a lookup of a missing subscription.
+func (b *WebhookRepoBuilder) GetSubscriptionNotFound() *WebhookRepoBuilder {
+	b.instance.EXPECT().
+		GetSubscription(mock.Anything, mock.AnythingOfType("uuid.UUID")).
+		Return(nil, entity.ErrWebhookNotFound).
+		Once()
+
+	return b
+}
+
+// UpdateSubscriptionSuccess accepts any update of the fake subscription.
+func (b *WebhookRepoBuilder) UpdateSubscriptionSuccess() *WebhookRepoBuilder {
+	b.instance.EXPECT().
+		UpdateSubscription(mock.Anything, mock.AnythingOfType("*entity.WebhookSubscription")).
+		Return(nil).
+		Once()
+
+	return b
+}
+
+// DeleteSubscriptionSuccess accepts the deletion of the fake subscription.
+func (b *WebhookRepoBuilder) DeleteSubscriptionSuccess() *WebhookRepoBuilder {
+	b.instance.EXPECT().DeleteSubscription(mock.Anything, datatest.FakeWebhookID).Return(nil).Once()
+
+	return b
+}
+
+// DeleteSubscriptionNotFound simulates the deletion of a missing subscription.
+func (b *WebhookRepoBuilder) DeleteSubscriptionNotFound() *WebhookRepoBuilder {
+	b.instance.EXPECT().
+		DeleteSubscription(mock.Anything, mock.AnythingOfType("uuid.UUID")).
+		Return(entity.ErrWebhookNotFound).
+		Once()
+
+	return b
+}
+
+// ListSubscribersReturns makes the subscriber lookup return subscriptions with the given IDs.
+func (b *WebhookRepoBuilder) ListSubscribersReturns(ids ...uuid.UUID) *WebhookRepoBuilder {
+	subs := make([]entity.WebhookSubscription, 0, len(ids))
+	for _, id := range ids {
+		sub := FakeWebhook()
+		sub.ID = id
+		subs = append(subs, *sub)
+	}
+
+	b.instance.EXPECT().
+		ListSubscribers(mock.Anything, mock.AnythingOfType("entity.EventType")).
+		Return(subs, nil).
+		Once()
+
+	return b
+}
+
+// ListSubscribersErrorDB simulates a database failure while looking up subscribers.
+func (b *WebhookRepoBuilder) ListSubscribersErrorDB() *WebhookRepoBuilder {
+	b.instance.EXPECT().
+		ListSubscribers(mock.Anything, mock.AnythingOfType("entity.EventType")).
+		Return(nil, datatest.ErrUnexpectedDB).
+		Once()
+
+	return b
+}
+
+// AddDeliveriesExpects expects one pending delivery of event per given subscription ID.
+func (b *WebhookRepoBuilder) AddDeliveriesExpects(event entity.Event, ids ...uuid.UUID) *WebhookRepoBuilder {
+	args := []any{mock.Anything}
+	for _, id := range ids {
+		args = append(args, mock.MatchedBy(func(d entity.WebhookDelivery) bool {
+			return d.SubscriptionID == id && d.EventID == event.ID && d.Status == entity.DeliveryPending
+		}))
+	}
+
+	b.instance.On("AddDeliveries", args...).Return(nil).Once()
+
+	return b
+}
+
+// ListDeliveriesExpectsLimit expects a delivery log query of the fake subscription with the given limit.
+func (b *WebhookRepoBuilder) ListDeliveriesExpectsLimit(limit int) *WebhookRepoBuilder {
+	b.instance.EXPECT().
+		ListDeliveries(mock.Anything, mock.MatchedBy(func(q dto.ListWebhookDeliveriesQuery) bool {
+			return q.SubscriptionID == datatest.FakeWebhookID && q.Limit == limit
+		})).
+		Return([]entity.WebhookDelivery{}, nil).
+		Once()
+
+	return b
+}
+
+// ClaimDueDeliveriesReturns makes the next claim return the given dispatches.
+func (b *WebhookRepoBuilder) ClaimDueDeliveriesReturns(dispatches ...dto.WebhookDispatch) *WebhookRepoBuilder {
+	b.instance.EXPECT().
+		ClaimDueDeliveries(mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("time.Duration")).
+		Return(slices.Clone(dispatches), nil).
+		Once()
+
+	return b
+}
+
+// ClaimDueDeliveriesErrorDB simulates a database failure while claiming deliveries.
+func (b *WebhookRepoBuilder) ClaimDueDeliveriesErrorDB() *WebhookRepoBuilder {
+	b.instance.EXPECT().
+		ClaimDueDeliveries(mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("time.Duration")).
+		Return(nil, datatest.ErrUnexpectedDB).
+		Once()
+
+	return b
+}
+
+// UpdateDeliveryRecords stores every delivery outcome in out, in order.
+func (b *WebhookRepoBuilder) UpdateDeliveryRecords(out *[]entity.WebhookDelivery) *WebhookRepoBuilder {
+	b.instance.EXPECT().
+		UpdateDelivery(mock.Anything, mock.AnythingOfType("*entity.WebhookDelivery")).
+		Run(func(_ context.Context, d *entity.WebhookDelivery) {
+			*out = append(*out, *d)
+		}).
+		Return(nil)
+
+	return b
+}
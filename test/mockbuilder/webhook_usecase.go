@@ -0,0 +1,132 @@
+package mockbuilder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DucTran999/go-clean-archx/internal/dto"
+	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/DucTran999/go-clean-archx/internal/port"
+	"github.com/DucTran999/go-clean-archx/test/datatest"
+	"github.com/DucTran999/go-clean-archx/test/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+// WebhookUsecaseBuilder configures WebhookUsecase mocks.
+type WebhookUsecaseBuilder struct {
+	instance *mocks.WebhookUsecase
+}
+
+// NewWebhookUsecaseBuilder creates a builder with a fresh WebhookUsecase mock.
+func NewWebhookUsecaseBuilder(t *testing.T) *WebhookUsecaseBuilder {
+	t.Helper()
+	return &WebhookUsecaseBuilder{
+		instance: mocks.NewWebhookUsecase(t),
+	}
+}
+
+// Build returns the mocked WebhookUsecase.
+func (b *WebhookUsecaseBuilder) Build() port.WebhookUsecase {
+	return b.instance
+}
+
+// CreateWebhookSuccess returns FakeWebhook for any valid request.
+func (b *WebhookUsecaseBuilder) CreateWebhookSuccess() *WebhookUsecaseBuilder {
+	b.instance.EXPECT().
+		CreateWebhook(mock.Anything, mock.AnythingOfType("dto.CreateWebhookInput")).
+		Return(FakeWebhook(), nil).
+		Once()
+
+	return b
+}
+
+// CreateWebhookReturnsInvalid simulates a subscription rejected by domain validation.
+func (b *WebhookUsecaseBuilder) CreateWebhookReturnsInvalid() *WebhookUsecaseBuilder {
+	b.instance.EXPECT().
+		CreateWebhook(mock.Anything, mock.AnythingOfType("dto.CreateWebhookInput")).
+		Return(nil, &entity.ValidationError{
+			Fields: []entity.FieldError{{Field: "url", Code: entity.CodeFormat, Message: "url must be an absolute http or https URL"}},
+			Err:    entity.ErrWebhookInvalid,
+		}).
+		Once()
+
+	return b
+}
+
+// ListWebhooksSuccess returns FakeWebhook as the only subscription.
+func (b *WebhookUsecaseBuilder) ListWebhooksSuccess() *WebhookUsecaseBuilder {
+	b.instance.EXPECT().
+		ListWebhooks(mock.Anything).
+		Return([]entity.WebhookSubscription{*FakeWebhook()}, nil).
+		Once()
+
+	return b
+}
+
+// GetWebhookSuccess returns FakeWebhook for the fake webhook ID.
+func (b *WebhookUsecaseBuilder) GetWebhookSuccess() *WebhookUsecaseBuilder {
+	b.instance.EXPECT().GetWebhook(mock.Anything, datatest.FakeWebhookID).Return(FakeWebhook(), nil).Once()
+
+	return b
+}
+
+// GetWebhookNotFound simulates a lookup of a missing subscription.
+func (b *WebhookUsecaseBuilder) GetWebhookNotFound() *WebhookUsecaseBuilder {
+	b.instance.EXPECT().
+		GetWebhook(mock.Anything, mock.AnythingOfType("uuid.UUID")).
+		Return(nil, entity.ErrWebhookNotFound).
+		Once()
+
+	return b
+}
+
+// UpdateWebhookSuccess applies the input to FakeWebhook and returns it.
+func (b *WebhookUsecaseBuilder) UpdateWebhookSuccess() *WebhookUsecaseBuilder {
+	b.instance.EXPECT().
+		UpdateWebhook(mock.Anything, mock.AnythingOfType("dto.UpdateWebhookInput")).
+		RunAndReturn(func(_ context.Context, input dto.UpdateWebhookInput) (*entity.WebhookSubscription, error) {
+			sub := FakeWebhook()
+			sub.URL = input.URL
+			sub.Active = input.Active
+			return sub, nil
+		}).
+		Once()
+
+	return b
+}
+
+// DeleteWebhookSuccess accepts the deletion of the fake subscription.
+func (b *WebhookUsecaseBuilder) DeleteWebhookSuccess() *WebhookUsecaseBuilder {
+	b.instance.EXPECT().DeleteWebhook(mock.Anything, datatest.FakeWebhookID).Return(nil).Once()
+
+	return b
+}
+
+// ListDeliveriesSuccess returns a log of one pending and one dead-lettered delivery.
+func (b *WebhookUsecaseBuilder) ListDeliveriesSuccess() *WebhookUsecaseBuilder {
+	sub := FakeWebhook()
+	now := time.Now()
+
+	pending := entity.NewWebhookDelivery(sub, entity.NewProductDeleted(datatest.FakeProductID), now)
+	pending.Fail(503, "webhook endpoint answered 503", now, time.Minute, 10)
+	dead := entity.NewWebhookDelivery(sub, entity.NewProductCreated(&entity.Product{ID: datatest.FakeProductID}), now)
+	dead.Fail(0, "connection refused", now, time.Minute, 1)
+
+	b.instance.EXPECT().
+		ListDeliveries(mock.Anything, mock.AnythingOfType("dto.ListWebhookDeliveriesQuery")).
+		Return([]entity.WebhookDelivery{pending, dead}, nil).
+		Once()
+
+	return b
+}
+
+// ListDeliveriesNotFound simulates the delivery log of a missing subscription.
+func (b *WebhookUsecaseBuilder) ListDeliveriesNotFound() *WebhookUsecaseBuilder {
+	b.instance.EXPECT().
+		ListDeliveries(mock.Anything, mock.AnythingOfType("dto.ListWebhookDeliveriesQuery")).
+		Return(nil, entity.ErrWebhookNotFound).
+		Once()
+
+	return b
+}
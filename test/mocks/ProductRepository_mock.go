@@ -7,7 +7,9 @@ package mocks
 import (
 	"context"
 
+	"github.com/DucTran999/go-clean-archx/internal/dto"
 	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -94,3 +96,393 @@ func (_c *ProductRepository_Create_Call) RunAndReturn(run func(ctx context.Conte
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpsertBySKU provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) UpsertBySKU(ctx context.Context, product *entity.Product, expectedVersion *int64) (bool, error) {
+	ret := _mock.Called(ctx, product, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertBySKU")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Product, *int64) (bool, error)); ok {
+		return returnFunc(ctx, product, expectedVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Product, *int64) bool); ok {
+		r0 = returnFunc(ctx, product, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *entity.Product, *int64) error); ok {
+		r1 = returnFunc(ctx, product, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepository_UpsertBySKU_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertBySKU'
+type ProductRepository_UpsertBySKU_Call struct {
+	*mock.Call
+}
+
+// UpsertBySKU is a helper method to define mock.On call
+//   - ctx context.Context
+//   - product *entity.Product
+//   - expectedVersion *int64
+func (_e *ProductRepository_Expecter) UpsertBySKU(ctx interface{}, product interface{}, expectedVersion interface{}) *ProductRepository_UpsertBySKU_Call {
+	return &ProductRepository_UpsertBySKU_Call{Call: _e.mock.On("UpsertBySKU", ctx, product, expectedVersion)}
+}
+
+func (_c *ProductRepository_UpsertBySKU_Call) Run(run func(ctx context.Context, product *entity.Product, expectedVersion *int64)) *ProductRepository_UpsertBySKU_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.Product
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Product)
+		}
+		var arg2 *int64
+		if args[2] != nil {
+			arg2 = args[2].(*int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_UpsertBySKU_Call) Return(created bool, err error) *ProductRepository_UpsertBySKU_Call {
+	_c.Call.Return(created, err)
+	return _c
+}
+
+func (_c *ProductRepository_UpsertBySKU_Call) RunAndReturn(run func(ctx context.Context, product *entity.Product, expectedVersion *int64) (bool, error)) *ProductRepository_UpsertBySKU_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) GetByID(ctx context.Context, query dto.GetProductQuery) (*entity.Product, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.GetProductQuery) (*entity.Product, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.GetProductQuery) *entity.Product); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.GetProductQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepository_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type ProductRepository_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query dto.GetProductQuery
+func (_e *ProductRepository_Expecter) GetByID(ctx interface{}, query interface{}) *ProductRepository_GetByID_Call {
+	return &ProductRepository_GetByID_Call{Call: _e.mock.On("GetByID", ctx, query)}
+}
+
+func (_c *ProductRepository_GetByID_Call) Run(run func(ctx context.Context, query dto.GetProductQuery)) *ProductRepository_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.GetProductQuery
+		if args[1] != nil {
+			arg1 = args[1].(dto.GetProductQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_GetByID_Call) Return(product *entity.Product, err error) *ProductRepository_GetByID_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *ProductRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context, query dto.GetProductQuery) (*entity.Product, error)) *ProductRepository_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) List(ctx context.Context, query dto.ListProductsQuery) ([]entity.Product, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []entity.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.ListProductsQuery) ([]entity.Product, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.ListProductsQuery) []entity.Product); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.ListProductsQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ProductRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query dto.ListProductsQuery
+func (_e *ProductRepository_Expecter) List(ctx interface{}, query interface{}) *ProductRepository_List_Call {
+	return &ProductRepository_List_Call{Call: _e.mock.On("List", ctx, query)}
+}
+
+func (_c *ProductRepository_List_Call) Run(run func(ctx context.Context, query dto.ListProductsQuery)) *ProductRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.ListProductsQuery
+		if args[1] != nil {
+			arg1 = args[1].(dto.ListProductsQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_List_Call) Return(products []entity.Product, err error) *ProductRepository_List_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *ProductRepository_List_Call) RunAndReturn(run func(ctx context.Context, query dto.ListProductsQuery) ([]entity.Product, error)) *ProductRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) Update(ctx context.Context, product *entity.Product) error {
+	ret := _mock.Called(ctx, product)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Product) error); ok {
+		r0 = returnFunc(ctx, product)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProductRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type ProductRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - product *entity.Product
+func (_e *ProductRepository_Expecter) Update(ctx interface{}, product interface{}) *ProductRepository_Update_Call {
+	return &ProductRepository_Update_Call{Call: _e.mock.On("Update", ctx, product)}
+}
+
+func (_c *ProductRepository_Update_Call) Run(run func(ctx context.Context, product *entity.Product)) *ProductRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.Product
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Product)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_Update_Call) Return(err error) *ProductRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductRepository_Update_Call) RunAndReturn(run func(ctx context.Context, product *entity.Product) error) *ProductRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProductRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type ProductRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *ProductRepository_Expecter) Delete(ctx interface{}, id interface{}) *ProductRepository_Delete_Call {
+	return &ProductRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *ProductRepository_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ProductRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_Delete_Call) Return(err error) *ProductRepository_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *ProductRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) Restore(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Product, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Product); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type ProductRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *ProductRepository_Expecter) Restore(ctx interface{}, id interface{}) *ProductRepository_Restore_Call {
+	return &ProductRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *ProductRepository_Restore_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ProductRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_Restore_Call) Return(product *entity.Product, err error) *ProductRepository_Restore_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *ProductRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*entity.Product, error)) *ProductRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
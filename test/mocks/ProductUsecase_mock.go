@@ -9,6 +9,7 @@ import (
 
 	"github.com/DucTran999/go-clean-archx/internal/dto"
 	"github.com/DucTran999/go-clean-archx/internal/entity"
+	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -106,3 +107,474 @@ func (_c *ProductUsecase_CreateProduct_Call) RunAndReturn(run func(ctx context.C
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpsertProductBySKU provides a mock function for the type ProductUsecase
+func (_mock *ProductUsecase) UpsertProductBySKU(ctx context.Context, input dto.UpsertProductInput) (*entity.Product, bool, error) {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertProductBySKU")
+	}
+
+	var r0 *entity.Product
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.UpsertProductInput) (*entity.Product, bool, error)); ok {
+		return returnFunc(ctx, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.UpsertProductInput) *entity.Product); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.UpsertProductInput) bool); ok {
+		r1 = returnFunc(ctx, input)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, dto.UpsertProductInput) error); ok {
+		r2 = returnFunc(ctx, input)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// ProductUsecase_UpsertProductBySKU_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertProductBySKU'
+type ProductUsecase_UpsertProductBySKU_Call struct {
+	*mock.Call
+}
+
+// UpsertProductBySKU is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input dto.UpsertProductInput
+func (_e *ProductUsecase_Expecter) UpsertProductBySKU(ctx interface{}, input interface{}) *ProductUsecase_UpsertProductBySKU_Call {
+	return &ProductUsecase_UpsertProductBySKU_Call{Call: _e.mock.On("UpsertProductBySKU", ctx, input)}
+}
+
+func (_c *ProductUsecase_UpsertProductBySKU_Call) Run(run func(ctx context.Context, input dto.UpsertProductInput)) *ProductUsecase_UpsertProductBySKU_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.UpsertProductInput
+		if args[1] != nil {
+			arg1 = args[1].(dto.UpsertProductInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductUsecase_UpsertProductBySKU_Call) Return(product *entity.Product, created bool, err error) *ProductUsecase_UpsertProductBySKU_Call {
+	_c.Call.Return(product, created, err)
+	return _c
+}
+
+func (_c *ProductUsecase_UpsertProductBySKU_Call) RunAndReturn(run func(ctx context.Context, input dto.UpsertProductInput) (*entity.Product, bool, error)) *ProductUsecase_UpsertProductBySKU_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByID provides a mock function for the type ProductUsecase
+func (_mock *ProductUsecase) GetByID(ctx context.Context, query dto.GetProductQuery) (*entity.Product, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.GetProductQuery) (*entity.Product, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.GetProductQuery) *entity.Product); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.GetProductQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductUsecase_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type ProductUsecase_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query dto.GetProductQuery
+func (_e *ProductUsecase_Expecter) GetByID(ctx interface{}, query interface{}) *ProductUsecase_GetByID_Call {
+	return &ProductUsecase_GetByID_Call{Call: _e.mock.On("GetByID", ctx, query)}
+}
+
+func (_c *ProductUsecase_GetByID_Call) Run(run func(ctx context.Context, query dto.GetProductQuery)) *ProductUsecase_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.GetProductQuery
+		if args[1] != nil {
+			arg1 = args[1].(dto.GetProductQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductUsecase_GetByID_Call) Return(product *entity.Product, err error) *ProductUsecase_GetByID_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *ProductUsecase_GetByID_Call) RunAndReturn(run func(ctx context.Context, query dto.GetProductQuery) (*entity.Product, error)) *ProductUsecase_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type ProductUsecase
+func (_mock *ProductUsecase) List(ctx context.Context, query dto.ListProductsQuery) (*dto.ProductPage, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 *dto.ProductPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.ListProductsQuery) (*dto.ProductPage, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.ListProductsQuery) *dto.ProductPage); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.ProductPage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.ListProductsQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductUsecase_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type ProductUsecase_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query dto.ListProductsQuery
+func (_e *ProductUsecase_Expecter) List(ctx interface{}, query interface{}) *ProductUsecase_List_Call {
+	return &ProductUsecase_List_Call{Call: _e.mock.On("List", ctx, query)}
+}
+
+func (_c *ProductUsecase_List_Call) Run(run func(ctx context.Context, query dto.ListProductsQuery)) *ProductUsecase_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.ListProductsQuery
+		if args[1] != nil {
+			arg1 = args[1].(dto.ListProductsQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductUsecase_List_Call) Return(productPage *dto.ProductPage, err error) *ProductUsecase_List_Call {
+	_c.Call.Return(productPage, err)
+	return _c
+}
+
+func (_c *ProductUsecase_List_Call) RunAndReturn(run func(ctx context.Context, query dto.ListProductsQuery) (*dto.ProductPage, error)) *ProductUsecase_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProduct provides a mock function for the type ProductUsecase
+func (_mock *ProductUsecase) UpdateProduct(ctx context.Context, input dto.UpdateProductInput) (*entity.Product, error) {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProduct")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.UpdateProductInput) (*entity.Product, error)); ok {
+		return returnFunc(ctx, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.UpdateProductInput) *entity.Product); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.UpdateProductInput) error); ok {
+		r1 = returnFunc(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductUsecase_UpdateProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProduct'
+type ProductUsecase_UpdateProduct_Call struct {
+	*mock.Call
+}
+
+// UpdateProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input dto.UpdateProductInput
+func (_e *ProductUsecase_Expecter) UpdateProduct(ctx interface{}, input interface{}) *ProductUsecase_UpdateProduct_Call {
+	return &ProductUsecase_UpdateProduct_Call{Call: _e.mock.On("UpdateProduct", ctx, input)}
+}
+
+func (_c *ProductUsecase_UpdateProduct_Call) Run(run func(ctx context.Context, input dto.UpdateProductInput)) *ProductUsecase_UpdateProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.UpdateProductInput
+		if args[1] != nil {
+			arg1 = args[1].(dto.UpdateProductInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductUsecase_UpdateProduct_Call) Return(product *entity.Product, err error) *ProductUsecase_UpdateProduct_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *ProductUsecase_UpdateProduct_Call) RunAndReturn(run func(ctx context.Context, input dto.UpdateProductInput) (*entity.Product, error)) *ProductUsecase_UpdateProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchProduct provides a mock function for the type ProductUsecase
+func (_mock *ProductUsecase) PatchProduct(ctx context.Context, input dto.PatchProductInput) (*entity.Product, error) {
+	ret := _mock.Called(ctx, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchProduct")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.PatchProductInput) (*entity.Product, error)); ok {
+		return returnFunc(ctx, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.PatchProductInput) *entity.Product); ok {
+		r0 = returnFunc(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.PatchProductInput) error); ok {
+		r1 = returnFunc(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductUsecase_PatchProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchProduct'
+type ProductUsecase_PatchProduct_Call struct {
+	*mock.Call
+}
+
+// PatchProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - input dto.PatchProductInput
+func (_e *ProductUsecase_Expecter) PatchProduct(ctx interface{}, input interface{}) *ProductUsecase_PatchProduct_Call {
+	return &ProductUsecase_PatchProduct_Call{Call: _e.mock.On("PatchProduct", ctx, input)}
+}
+
+func (_c *ProductUsecase_PatchProduct_Call) Run(run func(ctx context.Context, input dto.PatchProductInput)) *ProductUsecase_PatchProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.PatchProductInput
+		if args[1] != nil {
+			arg1 = args[1].(dto.PatchProductInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductUsecase_PatchProduct_Call) Return(product *entity.Product, err error) *ProductUsecase_PatchProduct_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *ProductUsecase_PatchProduct_Call) RunAndReturn(run func(ctx context.Context, input dto.PatchProductInput) (*entity.Product, error)) *ProductUsecase_PatchProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteProduct provides a mock function for the type ProductUsecase
+func (_mock *ProductUsecase) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProduct")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProductUsecase_DeleteProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProduct'
+type ProductUsecase_DeleteProduct_Call struct {
+	*mock.Call
+}
+
+// DeleteProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *ProductUsecase_Expecter) DeleteProduct(ctx interface{}, id interface{}) *ProductUsecase_DeleteProduct_Call {
+	return &ProductUsecase_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id)}
+}
+
+func (_c *ProductUsecase_DeleteProduct_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ProductUsecase_DeleteProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductUsecase_DeleteProduct_Call) Return(err error) *ProductUsecase_DeleteProduct_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductUsecase_DeleteProduct_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *ProductUsecase_DeleteProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreProduct provides a mock function for the type ProductUsecase
+func (_mock *ProductUsecase) RestoreProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreProduct")
+	}
+
+	var r0 *entity.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Product, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Product); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductUsecase_RestoreProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreProduct'
+type ProductUsecase_RestoreProduct_Call struct {
+	*mock.Call
+}
+
+// RestoreProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *ProductUsecase_Expecter) RestoreProduct(ctx interface{}, id interface{}) *ProductUsecase_RestoreProduct_Call {
+	return &ProductUsecase_RestoreProduct_Call{Call: _e.mock.On("RestoreProduct", ctx, id)}
+}
+
+func (_c *ProductUsecase_RestoreProduct_Call) Run(run func(ctx context.Context, id uuid.UUID)) *ProductUsecase_RestoreProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductUsecase_RestoreProduct_Call) Return(product *entity.Product, err error) *ProductUsecase_RestoreProduct_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *ProductUsecase_RestoreProduct_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*entity.Product, error)) *ProductUsecase_RestoreProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}